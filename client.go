@@ -51,7 +51,7 @@ func NewIRISHUBClient(cfg types.ClientConfig) IRISHUBClient {
 
 	// create a instance of baseClient
 	baseClient := modules.NewBaseClient(cfg, encodingConfig, nil)
-	keysClient := keys.NewClient(baseClient)
+	keysClient := keys.NewClient(baseClient, cfg.KeyDAO, cfg.Algo)
 
 	bankClient := bank.NewClient(baseClient, encodingConfig.Marshaler)
 	tokenClient := token.NewClient(baseClient, encodingConfig.Marshaler)
@@ -0,0 +1,222 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTampered is wrapped by the errors of Verify, when a record of the log was modified, deleted or reordered
+var ErrTampered = errors.New("audit log tampered")
+
+// Record is the record of a signature. The tx fields are empty when the sign bytes aren't a SIGN_MODE_DIRECT
+// SignDoc, e.g. the ADR-036 signatures, the SignBytesHash still identifies the signed data.
+type Record struct {
+	// Index is the position of the record in the log, from 0
+	Index         uint64    `json:"index"`
+	Time          time.Time `json:"time"`
+	KeyName       string    `json:"key_name"`
+	Address       string    `json:"address"`
+	ChainID       string    `json:"chain_id"`
+	AccountNumber uint64    `json:"account_number"`
+	Sequence      uint64    `json:"sequence"`
+	Msgs          []Msg     `json:"msgs"`
+	Fee           string    `json:"fee"`
+	Gas           uint64    `json:"gas"`
+	Memo          string    `json:"memo"`
+	// SignBytesHash is the hex sha256 of the sign bytes
+	SignBytesHash string `json:"sign_bytes_hash"`
+	// Signature is the hex signature
+	Signature string `json:"signature"`
+	// PrevHash is the Hash of the previous record, empty for the first record
+	PrevHash string `json:"prev_hash"`
+	// Hash is the hex sha256 of the JSON of the record without its Hash
+	Hash string `json:"hash"`
+}
+
+// Msg is a decoded msg of a tx, the Value is the proto JSON of the msg, or the base64 of its bytes
+// when the type isn't registered
+type Msg struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Head identifies the last record of a log, save it out of the log to detect the deletion of the last records
+type Head struct {
+	Index uint64 `json:"index"`
+	Hash  string `json:"hash"`
+}
+
+// Sink receives the records of the signatures
+type Sink interface {
+	// Append chains the record to the previous ones and writes it, returning the record written
+	Append(record Record) (Record, error)
+}
+
+// Store is the append-only storage of a Log
+type Store interface {
+	// Last returns the last record, false when the store is empty
+	Last() (Record, bool, error)
+	Write(record Record) error
+	// Iterate calls fn with the records in the order they were written
+	Iterate(fn func(record Record) error) error
+	Close() error
+}
+
+var _ Sink = (*Log)(nil)
+
+// Log is the hash-chained log of the records, every record holds the hash of the previous one
+type Log struct {
+	store Store
+
+	mu   sync.Mutex
+	last *Record
+}
+
+// NewLog returns the Log writing to the store, see NewFileLog and NewLevelDBLog
+func NewLog(store Store) (*Log, error) {
+	last, ok, err := store.Last()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{store: store}
+	if ok {
+		l.last = &last
+	}
+	return l, nil
+}
+
+// NewFileLog returns the Log appending the records as JSON lines to the file, created when missing
+func NewFileLog(filename string) (*Log, error) {
+	store, err := NewFileStore(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewLog(store)
+}
+
+// NewLevelDBLog returns the Log writing the records to the leveldb of the directory
+func NewLevelDBLog(dir string) (*Log, error) {
+	store, err := NewLevelDBStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewLog(store)
+}
+
+func (l *Log) Append(record Record) (Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	record.Index, record.PrevHash = 0, ""
+	if l.last != nil {
+		record.Index, record.PrevHash = l.last.Index+1, l.last.Hash
+	}
+	record.Time = record.Time.UTC()
+
+	hash, err := record.hash()
+	if err != nil {
+		return Record{}, err
+	}
+	record.Hash = hash
+
+	if err := l.store.Write(record); err != nil {
+		return Record{}, err
+	}
+	l.last = &record
+	return record, nil
+}
+
+// Head returns the head of the log, false when the log is empty
+func (l *Log) Head() (Head, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.last == nil {
+		return Head{}, false
+	}
+	return Head{Index: l.last.Index, Hash: l.last.Hash}, true
+}
+
+// Records returns the records of the log
+func (l *Log) Records() ([]Record, error) {
+	var records []Record
+	err := l.store.Iterate(func(record Record) error {
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// Verify checks the chain of the records, see Verify
+func (l *Log) Verify(checkpoints ...Head) (Head, error) {
+	return Verify(l.store, checkpoints...)
+}
+
+func (l *Log) Close() error {
+	return l.store.Close()
+}
+
+// Verify checks the hashes and the chain of the records of the store and returns its head. The modification,
+// the deletion or the reordering of any record fails with ErrTampered, except the deletion of the last records,
+// which is detected by the checkpoints: the heads returned before, which must still be in the log.
+func Verify(store Store, checkpoints ...Head) (Head, error) {
+	hashes := make(map[uint64]string, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		hashes[checkpoint.Index] = checkpoint.Hash
+	}
+
+	var (
+		head  Head
+		count uint64
+	)
+	err := store.Iterate(func(record Record) error {
+		if record.Index != count {
+			return fmt.Errorf("%w: record %d found at index %d", ErrTampered, record.Index, count)
+		}
+		if record.PrevHash != head.Hash {
+			return fmt.Errorf("%w: record %d doesn't follow record %d", ErrTampered, record.Index, head.Index)
+		}
+
+		hash, err := record.hash()
+		if err != nil {
+			return err
+		}
+		if record.Hash != hash {
+			return fmt.Errorf("%w: record %d modified", ErrTampered, record.Index)
+		}
+		if checkpoint, ok := hashes[record.Index]; ok && checkpoint != record.Hash {
+			return fmt.Errorf("%w: record %d doesn't match the checkpoint", ErrTampered, record.Index)
+		}
+
+		head = Head{Index: record.Index, Hash: record.Hash}
+		count++
+		return nil
+	})
+	if err != nil {
+		return Head{}, err
+	}
+
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Index >= count {
+			return Head{}, fmt.Errorf("%w: record %d deleted", ErrTampered, checkpoint.Index)
+		}
+	}
+	return head, nil
+}
+
+// hash returns the hex sha256 of the JSON of the record without its Hash
+func (r Record) hash() (string, error) {
+	r.Hash = ""
+	bz, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/irisnet/irishub-sdk-go/codec/types"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+	"github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+const password = "12345678"
+
+var bob = sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+
+func TestKeyManager(t *testing.T) {
+	log, err := NewFileLog(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = log.Close() })
+
+	km, address := newKeyManager(t, log)
+	now := time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)
+	km.(*keyManager).now = func() time.Time { return now }
+
+	data := signBytes(t, address, "memo")
+	signature, _, err := km.Sign("alice", password, data)
+	require.NoError(t, err)
+
+	records, err := log.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	record := records[0]
+	sum := sha256.Sum256(data)
+	require.Equal(t, uint64(0), record.Index)
+	require.Equal(t, now, record.Time)
+	require.Equal(t, "alice", record.KeyName)
+	require.Equal(t, address, record.Address)
+	require.Equal(t, "test-chain", record.ChainID)
+	require.Equal(t, uint64(1), record.AccountNumber)
+	require.Equal(t, uint64(3), record.Sequence)
+	require.Equal(t, "4uiris", record.Fee)
+	require.Equal(t, uint64(200000), record.Gas)
+	require.Equal(t, "memo", record.Memo)
+	require.Equal(t, hex.EncodeToString(sum[:]), record.SignBytesHash)
+	require.Equal(t, hex.EncodeToString(signature), record.Signature)
+	require.Empty(t, record.PrevHash)
+
+	require.Len(t, record.Msgs, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", record.Msgs[0].Type)
+	var send map[string]interface{}
+	require.NoError(t, json.Unmarshal(record.Msgs[0].Value, &send))
+	require.Equal(t, bob, send["to_address"])
+
+	// the failed signatures aren't recorded
+	_, _, err = km.Sign("bob", password, data)
+	require.Error(t, err)
+
+	// the sign bytes of other formats are recorded with their hash
+	_, _, err = km.Sign("alice", password, []byte(`{"msgs":[]}`))
+	require.NoError(t, err)
+
+	head, err := log.Verify()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), head.Index)
+	records, err = log.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, records[0].Hash, records[1].PrevHash)
+	require.Empty(t, records[1].ChainID)
+	require.Empty(t, records[1].Msgs)
+}
+
+func TestFileLog(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.log")
+	log, err := NewFileLog(filename)
+	require.NoError(t, err)
+	km, address := newKeyManager(t, log)
+	for i := 0; i < 3; i++ {
+		_, _, err := km.Sign("alice", password, signBytes(t, address, "memo"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, log.Close())
+
+	// the log is chained to the records written before
+	log, err = NewFileLog(filename)
+	require.NoError(t, err)
+	_, _, err = NewKeyManager(km.(*keyManager).KeyManager, log).Sign("alice", password, signBytes(t, address, "memo"))
+	require.NoError(t, err)
+	checkpoint, err := log.Verify()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), checkpoint.Index)
+	require.NoError(t, log.Close())
+
+	original, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	lines := bytes.SplitAfter(original, []byte("\n"))[:4]
+
+	testCases := []struct {
+		name  string
+		lines [][]byte
+	}{
+		{"modified", [][]byte{lines[0], bytes.Replace(lines[1], []byte(`"memo":"memo"`), []byte(`"memo":"other"`), 1), lines[2], lines[3]}},
+		{"deleted", [][]byte{lines[0], lines[2], lines[3]}},
+		{"deleted first", [][]byte{lines[1], lines[2], lines[3]}},
+		{"reordered", [][]byte{lines[0], lines[2], lines[1], lines[3]}},
+		{"deleted last", [][]byte{lines[0], lines[1], lines[2]}},
+		{"truncated", [][]byte{lines[0], lines[1], lines[2], lines[3][:10]}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, ioutil.WriteFile(filename, bytes.Join(tc.lines, nil), 0600))
+			store, err := NewFileStore(filename)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = store.Close() })
+
+			_, err = Verify(store, checkpoint)
+			require.True(t, errors.Is(err, ErrTampered), err)
+		})
+	}
+
+	// the deletion of the last records is only detected with a checkpoint
+	require.NoError(t, ioutil.WriteFile(filename, bytes.Join(lines[:3], nil), 0600))
+	store, err := NewFileStore(filename)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	head, err := Verify(store)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), head.Index)
+}
+
+func TestLevelDBLog(t *testing.T) {
+	dir := t.TempDir()
+	log, err := NewLevelDBLog(dir)
+	require.NoError(t, err)
+	km, address := newKeyManager(t, log)
+	for i := 0; i < 3; i++ {
+		_, _, err := km.Sign("alice", password, signBytes(t, address, "memo"))
+		require.NoError(t, err)
+	}
+	head, ok := log.Head()
+	require.True(t, ok)
+	require.Equal(t, uint64(2), head.Index)
+	require.NoError(t, log.Close())
+
+	log, err = NewLevelDBLog(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = log.Close() })
+	_, err = log.Verify(head)
+	require.NoError(t, err)
+
+	db := log.store.(LevelDBStore).db
+	bz, err := db.Get(recordKey(1))
+	require.NoError(t, err)
+
+	// modified
+	require.NoError(t, db.Set(recordKey(1), bytes.Replace(bz, []byte(`"sequence":3`), []byte(`"sequence":4`), 1)))
+	_, err = log.Verify()
+	require.True(t, errors.Is(err, ErrTampered), err)
+
+	// deleted
+	require.NoError(t, db.Delete(recordKey(1)))
+	_, err = log.Verify()
+	require.True(t, errors.Is(err, ErrTampered), err)
+
+	// deleted last
+	require.NoError(t, db.Set(recordKey(1), bz))
+	require.NoError(t, db.Delete(recordKey(2)))
+	_, err = log.Verify()
+	require.NoError(t, err)
+	_, err = log.Verify(head)
+	require.True(t, errors.Is(err, ErrTampered), err)
+}
+
+func newKeyManager(t *testing.T, sink Sink) (sdk.KeyManager, string) {
+	inner := modules.NewKeyManager(store.NewMemory(nil), "secp256k1")
+	address, _, err := inner.Insert("alice", password)
+	require.NoError(t, err)
+	return NewKeyManager(inner, sink), address
+}
+
+func signBytes(t *testing.T, from, memo string) []byte {
+	send, err := codectypes.NewAnyWithValue(&bank.MsgSend{
+		FromAddress: from,
+		ToAddress:   bob,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uiris", 10)),
+	})
+	require.NoError(t, err)
+
+	body, err := proto.Marshal(&tx.TxBody{Messages: []*codectypes.Any{send}, Memo: memo})
+	require.NoError(t, err)
+
+	authInfo, err := proto.Marshal(&tx.AuthInfo{
+		SignerInfos: []*tx.SignerInfo{{Sequence: 3}},
+		Fee:         &tx.Fee{Amount: sdk.NewCoins(sdk.NewInt64Coin("uiris", 4)), GasLimit: 200000},
+	})
+	require.NoError(t, err)
+
+	bz, err := proto.Marshal(&tx.SignDoc{BodyBytes: body, AuthInfoBytes: authInfo, ChainId: "test-chain", AccountNumber: 1})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestSigningAudit(t *testing.T) {
+	log, err := NewFileLog(filepath.Join(t.TempDir(), "log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = log.Close() })
+
+	km := NewKeyManager(modules.NewKeyManager(store.NewMemory(nil), "secp256k1"), log)
+	chain, client, alice, bob := fakechaintest.Setup(t, sdk.KeyManagerOption(km))
+
+	amount, err := sdk.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	baseTx := fakechaintest.BaseTx("alice")
+	baseTx.Memo = "audited"
+	_, sdkErr := client.Bank.Send(bob, amount, baseTx)
+	require.NoError(t, sdkErr)
+
+	records, err := log.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "alice", records[0].KeyName)
+	require.Equal(t, alice, records[0].Address)
+	require.Equal(t, chain.ChainID(), records[0].ChainID)
+	require.Equal(t, "audited", records[0].Memo)
+	require.Len(t, records[0].Msgs, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", records[0].Msgs[0].Type)
+
+	_, err = log.Verify()
+	require.NoError(t, err)
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/codec"
+	codectypes "github.com/irisnet/irishub-sdk-go/codec/types"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+// keyManager is the KeyManager recording every signature to the sink
+type keyManager struct {
+	sdk.KeyManager
+	sink Sink
+	now  func() time.Time
+}
+
+// NewKeyManager returns the KeyManager signing with km, e.g. modules.NewKeyManager or a remote signer,
+// and recording every signature to the sink, e.g. a Log, to be set with types.KeyManagerOption so that
+// Factory.Sign records every tx. A signature which can't be recorded isn't returned.
+func NewKeyManager(km sdk.KeyManager, sink Sink) sdk.KeyManager {
+	return &keyManager{
+		KeyManager: km,
+		sink:       sink,
+		now:        time.Now,
+	}
+}
+
+func (k *keyManager) Sign(name, password string, data []byte) ([]byte, tmcrypto.PubKey, error) {
+	signature, pubKey, err := k.KeyManager.Sign(name, password, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record := NewRecord(name, data, signature, pubKey)
+	record.Time = k.now()
+	if _, err := k.sink.Append(record); err != nil {
+		return nil, nil, fmt.Errorf("name %s: failed to record the signature: %w", name, err)
+	}
+	return signature, pubKey, nil
+}
+
+// NewRecord returns the record of the signature, without its time and its chain fields
+func NewRecord(name string, signBytes, signature []byte, pubKey tmcrypto.PubKey) Record {
+	sum := sha256.Sum256(signBytes)
+	record := Record{
+		KeyName:       name,
+		Address:       sdk.AccAddress(pubKey.Address()).String(),
+		SignBytesHash: hex.EncodeToString(sum[:]),
+		Signature:     hex.EncodeToString(signature),
+	}
+
+	var doc tx.SignDoc
+	if err := proto.Unmarshal(signBytes, &doc); err != nil {
+		return record
+	}
+	var body tx.TxBody
+	if err := proto.Unmarshal(doc.BodyBytes, &body); err != nil {
+		return record
+	}
+	var authInfo tx.AuthInfo
+	if err := proto.Unmarshal(doc.AuthInfoBytes, &authInfo); err != nil {
+		return record
+	}
+
+	record.ChainID = doc.ChainId
+	record.AccountNumber = doc.AccountNumber
+	record.Memo = body.Memo
+	for _, msg := range body.Messages {
+		record.Msgs = append(record.Msgs, decodeMsg(msg))
+	}
+	if len(authInfo.SignerInfos) > 0 {
+		record.Sequence = authInfo.SignerInfos[0].Sequence
+	}
+	if authInfo.Fee != nil {
+		record.Fee = authInfo.Fee.Amount.String()
+		record.Gas = authInfo.Fee.GasLimit
+	}
+	return record
+}
+
+// decodeMsg returns the proto JSON of the msg when its type is registered, the base64 of its bytes otherwise
+func decodeMsg(any *codectypes.Any) Msg {
+	msg := Msg{Type: any.TypeUrl}
+	raw, _ := json.Marshal(any.Value)
+
+	typ := proto.MessageType(strings.TrimPrefix(any.TypeUrl, "/"))
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		msg.Value = raw
+		return msg
+	}
+	value, ok := reflect.New(typ.Elem()).Interface().(proto.Message)
+	if !ok || proto.Unmarshal(any.Value, value) != nil {
+		msg.Value = raw
+		return msg
+	}
+	bz, err := codec.ProtoMarshalJSON(value)
+	if err != nil {
+		msg.Value = raw
+		return msg
+	}
+	msg.Value = bz
+	return msg
+}
@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+const auditDBName = "audit"
+
+var (
+	_ Store = (*FileStore)(nil)
+	_ Store = LevelDBStore{}
+)
+
+// FileStore stores the records as JSON lines, every record is synced to the disk before the signature is returned
+type FileStore struct {
+	filename string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileStore opens the file in append-only mode, the file is created when missing
+func NewFileStore(filename string) (*FileStore, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{filename: filename, file: file}, nil
+}
+
+func (s *FileStore) Last() (Record, bool, error) {
+	var (
+		last Record
+		ok   bool
+	)
+	err := s.Iterate(func(record Record) error {
+		last, ok = record, true
+		return nil
+	})
+	return last, ok, err
+}
+
+func (s *FileStore) Write(record Record) error {
+	bz, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(bz, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *FileStore) Iterate(fn func(record Record) error) error {
+	file, err := os.Open(s.filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		bz, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(bz) == 0 {
+			return nil
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		var record Record
+		if err := json.Unmarshal(bytes.TrimSpace(bz), &record); err != nil {
+			return fmt.Errorf("%w: invalid record at line %d: %s", ErrTampered, line, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
+
+// LevelDBStore stores the records in a leveldb, by index
+type LevelDBStore struct {
+	db dbm.DB
+}
+
+// NewLevelDBStore opens the leveldb of the directory, created when missing
+func NewLevelDBStore(dir string) (LevelDBStore, error) {
+	db, err := dbm.NewGoLevelDB(auditDBName, dir)
+	if err != nil {
+		return LevelDBStore{}, err
+	}
+	return LevelDBStore{db: db}, nil
+}
+
+func (s LevelDBStore) Last() (Record, bool, error) {
+	it, err := s.db.ReverseIterator(nil, nil)
+	if err != nil {
+		return Record{}, false, err
+	}
+	defer it.Close()
+
+	if !it.Valid() {
+		return Record{}, false, it.Error()
+	}
+	record, err := unmarshalRecord(it.Value())
+	if err != nil {
+		return Record{}, false, err
+	}
+	return record, true, nil
+}
+
+func (s LevelDBStore) Write(record Record) error {
+	key := recordKey(record.Index)
+	exists, err := s.db.Has(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("record %d already written", record.Index)
+	}
+
+	bz, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(key, bz)
+}
+
+func (s LevelDBStore) Iterate(fn func(record Record) error) error {
+	it, err := s.db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		record, err := unmarshalRecord(it.Value())
+		if err != nil {
+			return err
+		}
+		if len(it.Key()) != 8 || binary.BigEndian.Uint64(it.Key()) != record.Index {
+			return fmt.Errorf("%w: record %d stored at key %X", ErrTampered, record.Index, it.Key())
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (s LevelDBStore) Close() error {
+	return s.db.Close()
+}
+
+// recordKey returns the big-endian index, so that the records are iterated in order
+func recordKey(index uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, index)
+	return key
+}
+
+func unmarshalRecord(bz []byte) (Record, error) {
+	var record Record
+	if err := json.Unmarshal(bz, &record); err != nil {
+		return Record{}, fmt.Errorf("%w: invalid record: %s", ErrTampered, err)
+	}
+	return record, nil
+}
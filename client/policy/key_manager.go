@@ -0,0 +1,240 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+var (
+	msgSendTypeURL      = "/" + proto.MessageName(&bank.MsgSend{})
+	msgMultiSendTypeURL = "/" + proto.MessageName(&bank.MsgMultiSend{})
+)
+
+// keyManager is the KeyManager signing only the txs complying with the policy
+type keyManager struct {
+	sdk.KeyManager
+	policy Policy
+	memo   *regexp.Regexp
+	now    func() time.Time
+	// allowed and denied are the recipients of the policy by address bytes, the bech32 strings may differ in case
+	allowed map[string]bool
+	denied  map[string]bool
+
+	// mu serializes the signatures, so that the concurrent txs can't exceed the limits per window together
+	mu    sync.Mutex
+	spent map[string][]spending
+}
+
+// spending is the amount of a tx signed by a key
+type spending struct {
+	at     time.Time
+	amount sdk.Coins
+}
+
+// NewKeyManager returns the KeyManager signing with km, e.g. modules.NewKeyManager or a remote
+// signer, only the SIGN_MODE_DIRECT txs complying with the policy, to be set with types.KeyManagerOption.
+// A tx violating the policy fails with a *Violation. The limits per window are tracked by key, in memory.
+func NewKeyManager(km sdk.KeyManager, policy Policy) (sdk.KeyManager, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	var memo *regexp.Regexp
+	if policy.MemoPattern != "" {
+		memo = regexp.MustCompile(policy.MemoPattern)
+	}
+	allowed, err := addressSet(policy.AllowedRecipients)
+	if err != nil {
+		return nil, err
+	}
+	denied, err := addressSet(policy.DeniedRecipients)
+	if err != nil {
+		return nil, err
+	}
+	return &keyManager{
+		KeyManager: km,
+		policy:     policy,
+		memo:       memo,
+		now:        time.Now,
+		allowed:    allowed,
+		denied:     denied,
+		spent:      make(map[string][]spending),
+	}, nil
+}
+
+// addressSet returns the set of the bytes of the bech32 addresses
+func addressSet(addresses []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		addr, err := sdk.AccAddressFromBech32(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %s: %w", address, err)
+		}
+		set[string(addr)] = true
+	}
+	return set, nil
+}
+
+func (k *keyManager) Sign(name, password string, data []byte) ([]byte, tmcrypto.PubKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	amount, err := k.evaluate(name, data, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("name %s: %w", name, err)
+	}
+
+	signature, pubKey, err := k.KeyManager.Sign(name, password, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !amount.Empty() {
+		k.spent[name] = append(k.spent[name], spending{at: now, amount: amount})
+	}
+	return signature, pubKey, nil
+}
+
+// evaluate returns the amount sent by the tx of the sign bytes, or the violation of the policy
+func (k *keyManager) evaluate(name string, signBytes []byte, now time.Time) (sdk.Coins, error) {
+	var doc tx.SignDoc
+	if err := proto.Unmarshal(signBytes, &doc); err != nil {
+		return nil, violation(RuleDecode, "invalid sign bytes: %s", err)
+	}
+	var body tx.TxBody
+	if err := proto.Unmarshal(doc.BodyBytes, &body); err != nil {
+		return nil, violation(RuleDecode, "invalid tx body: %s", err)
+	}
+	var authInfo tx.AuthInfo
+	if err := proto.Unmarshal(doc.AuthInfoBytes, &authInfo); err != nil {
+		return nil, violation(RuleDecode, "invalid auth info: %s", err)
+	}
+
+	outputs, err := k.outputs(body)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := sdk.NewCoins()
+	for _, output := range outputs {
+		if err := k.checkRecipient(output.Address); err != nil {
+			return nil, err
+		}
+		amount = amount.Add(output.Coins...)
+	}
+	if err := k.checkLimits(name, amount, now); err != nil {
+		return nil, err
+	}
+
+	var fee tx.Fee
+	if authInfo.Fee != nil {
+		fee = *authInfo.Fee
+	}
+	if !k.policy.MaxFee.Empty() && !fee.Amount.IsAllLTE(k.policy.MaxFee) {
+		return nil, violation(RuleMaxFee, "fee %s exceeds %s", fee.Amount, k.policy.MaxFee)
+	}
+	if k.policy.MaxGas > 0 && fee.GasLimit > k.policy.MaxGas {
+		return nil, violation(RuleMaxGas, "gas %d exceeds %d", fee.GasLimit, k.policy.MaxGas)
+	}
+
+	if k.memo != nil && !k.memo.MatchString(body.Memo) {
+		return nil, violation(RuleMemo, "memo %q doesn't match %s", body.Memo, k.policy.MemoPattern)
+	}
+	return amount, nil
+}
+
+// outputs checks the msg types and returns the recipients and the amounts of the bank msgs
+func (k *keyManager) outputs(body tx.TxBody) ([]bank.Output, error) {
+	var outputs []bank.Output
+	for _, msg := range body.Messages {
+		if len(k.policy.AllowedMsgTypes) > 0 && !contains(k.policy.AllowedMsgTypes, msg.TypeUrl) {
+			return nil, violation(RuleMsgType, "msg %s not allowed", msg.TypeUrl)
+		}
+
+		switch msg.TypeUrl {
+		case msgSendTypeURL:
+			var send bank.MsgSend
+			if err := proto.Unmarshal(msg.Value, &send); err != nil {
+				return nil, violation(RuleDecode, "invalid %s: %s", msg.TypeUrl, err)
+			}
+			outputs = append(outputs, bank.Output{Address: send.ToAddress, Coins: send.Amount})
+		case msgMultiSendTypeURL:
+			var multiSend bank.MsgMultiSend
+			if err := proto.Unmarshal(msg.Value, &multiSend); err != nil {
+				return nil, violation(RuleDecode, "invalid %s: %s", msg.TypeUrl, err)
+			}
+			outputs = append(outputs, multiSend.Outputs...)
+		}
+	}
+	return outputs, nil
+}
+
+// checkRecipient compares the address bytes, the chain accepts the recipients in upper case too
+func (k *keyManager) checkRecipient(address string) error {
+	addr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return violation(RuleDecode, "invalid recipient %s: %s", address, err)
+	}
+	if k.denied[string(addr)] {
+		return violation(RuleRecipient, "recipient %s denied", address)
+	}
+	if len(k.allowed) > 0 && !k.allowed[string(addr)] {
+		return violation(RuleRecipient, "recipient %s not allowed", address)
+	}
+	return nil
+}
+
+func (k *keyManager) checkLimits(name string, amount sdk.Coins, now time.Time) error {
+	var maxWindow time.Duration
+	for _, limit := range k.policy.Limits {
+		sent := amount.AmountOf(limit.Denom)
+		if limit.PerTx != nil && sent.GT(*limit.PerTx) {
+			return violation(RuleAmountPerTx, "%s%s exceeds %s%s per tx", sent, limit.Denom, limit.PerTx, limit.Denom)
+		}
+
+		if limit.PerWindow == nil {
+			continue
+		}
+		window := time.Duration(limit.Window)
+		if window > maxWindow {
+			maxWindow = window
+		}
+		for _, s := range k.spent[name] {
+			if now.Sub(s.at) < window {
+				sent = sent.Add(s.amount.AmountOf(limit.Denom))
+			}
+		}
+		if sent.GT(*limit.PerWindow) {
+			return violation(RuleAmountPerWindow, "%s%s exceeds %s%s per %s", sent, limit.Denom, limit.PerWindow, limit.Denom, window)
+		}
+	}
+
+	// forget the txs out of every window
+	spent := k.spent[name][:0]
+	for _, s := range k.spent[name] {
+		if now.Sub(s.at) < maxWindow {
+			spent = append(spent, s)
+		}
+	}
+	k.spent[name] = spent
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
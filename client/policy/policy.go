@@ -0,0 +1,156 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// ErrViolation is wrapped by every Violation
+var ErrViolation = errors.New("signing policy violation")
+
+// Rule is the rule of the policy violated by a tx
+type Rule string
+
+const (
+	// RuleDecode is violated by the sign bytes which aren't a SIGN_MODE_DIRECT tx
+	RuleDecode          Rule = "decode"
+	RuleMsgType         Rule = "msg_type"
+	RuleRecipient       Rule = "recipient"
+	RuleAmountPerTx     Rule = "amount_per_tx"
+	RuleAmountPerWindow Rule = "amount_per_window"
+	RuleMaxFee          Rule = "max_fee"
+	RuleMaxGas          Rule = "max_gas"
+	RuleMemo            Rule = "memo"
+)
+
+// Violation is the error of a tx violating a rule of the policy, see errors.As
+type Violation struct {
+	Rule   Rule
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrViolation, v.Rule, v.Reason)
+}
+
+func (v *Violation) Unwrap() error {
+	return ErrViolation
+}
+
+func violation(rule Rule, format string, args ...interface{}) *Violation {
+	return &Violation{Rule: rule, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Policy is the set of rules every signed tx must comply with, the zero value of a rule doesn't restrict anything.
+// The recipients and the amounts are those of the bank MsgSend and MsgMultiSend, restrict the msg types to
+// enforce them on every tx.
+type Policy struct {
+	// AllowedMsgTypes are the type urls of the allowed msgs, e.g. /cosmos.bank.v1beta1.MsgSend
+	AllowedMsgTypes []string `json:"allowed_msg_types,omitempty"`
+	// AllowedRecipients are the only addresses the coins can be sent to when not empty
+	AllowedRecipients []string `json:"allowed_recipients,omitempty"`
+	// DeniedRecipients are the addresses the coins can't be sent to
+	DeniedRecipients []string `json:"denied_recipients,omitempty"`
+	// Limits are the limits of the amounts sent, by denom
+	Limits []Limit `json:"limits,omitempty"`
+	// MaxFee is the maximum fee of a tx, the fees of the other denoms are rejected
+	MaxFee sdk.Coins `json:"max_fee,omitempty"`
+	// MaxGas is the maximum gas of a tx
+	MaxGas uint64 `json:"max_gas,omitempty"`
+	// MemoPattern is the regular expression the memo must match, e.g. ^order-[0-9]+$
+	MemoPattern string `json:"memo_pattern,omitempty"`
+}
+
+// Limit is the limit of the amount of a denom sent by a key, per tx and per rolling window
+type Limit struct {
+	Denom string `json:"denom"`
+	// PerTx is the maximum amount of a tx, unlimited when nil
+	PerTx *sdk.Int `json:"per_tx,omitempty"`
+	// PerWindow is the maximum amount of the txs signed during the Window, unlimited when nil
+	PerWindow *sdk.Int `json:"per_window,omitempty"`
+	Window    Duration `json:"window,omitempty"`
+}
+
+// Duration is the time.Duration written as a string, e.g. "24h"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// LoadPolicy reads the JSON policy of the file, the unknown fields are rejected so that a misspelled rule
+// is never silently ignored
+func LoadPolicy(filename string) (Policy, error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.DisallowUnknownFields()
+
+	var p Policy
+	if err := decoder.Decode(&p); err != nil {
+		return Policy{}, fmt.Errorf("invalid policy %s: %w", filename, err)
+	}
+	if err := p.Validate(); err != nil {
+		return Policy{}, fmt.Errorf("invalid policy %s: %w", filename, err)
+	}
+	return p, nil
+}
+
+// Validate checks the addresses, the limits and the memo pattern of the policy
+func (p Policy) Validate() error {
+	for _, address := range append(append([]string{}, p.AllowedRecipients...), p.DeniedRecipients...) {
+		if _, err := sdk.AccAddressFromBech32(address); err != nil {
+			return fmt.Errorf("invalid recipient %s: %w", address, err)
+		}
+	}
+
+	denoms := make(map[string]bool, len(p.Limits))
+	for _, limit := range p.Limits {
+		if err := sdk.ValidateDenom(limit.Denom); err != nil {
+			return err
+		}
+		if denoms[limit.Denom] {
+			return fmt.Errorf("duplicate limit of %s", limit.Denom)
+		}
+		denoms[limit.Denom] = true
+
+		if limit.PerTx != nil && (limit.PerTx.IsNil() || limit.PerTx.IsNegative()) {
+			return fmt.Errorf("negative limit per tx of %s", limit.Denom)
+		}
+		if limit.PerWindow != nil && (limit.PerWindow.IsNil() || limit.PerWindow.IsNegative() || limit.Window <= 0) {
+			return fmt.Errorf("the limit per window of %s must be positive, over a positive window", limit.Denom)
+		}
+	}
+
+	if !p.MaxFee.Empty() && !p.MaxFee.IsValid() {
+		return fmt.Errorf("invalid max fee %s", p.MaxFee)
+	}
+
+	if _, err := regexp.Compile(p.MemoPattern); err != nil {
+		return fmt.Errorf("invalid memo pattern: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/irisnet/irishub-sdk-go/codec/types"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+	"github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+const (
+	password = "12345678"
+	alice    = "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z"
+)
+
+var (
+	bob = sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+
+	policyJSON = `{
+	"allowed_msg_types": ["/cosmos.bank.v1beta1.MsgSend", "/cosmos.bank.v1beta1.MsgMultiSend"],
+	"denied_recipients": ["` + bob + `"],
+	"limits": [{"denom": "uiris", "per_tx": "500", "per_window": "1000", "window": "1h"}],
+	"max_fee": [{"denom": "uiris", "amount": "100"}],
+	"max_gas": 200000,
+	"memo_pattern": "^order-[0-9]+$"
+}`
+)
+
+func TestPolicy(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(policyJSON), 0600))
+	p, err := LoadPolicy(filename)
+	require.NoError(t, err)
+
+	inner := modules.NewKeyManager(store.NewMemory(nil), "secp256k1")
+	_, _, err = inner.Insert("hot", password)
+	require.NoError(t, err)
+	km, err := NewKeyManager(inner, p)
+	require.NoError(t, err)
+
+	now := time.Now()
+	km.(*keyManager).now = func() time.Time { return now }
+
+	send := func(to string, amount int64) proto.Message {
+		return &bank.MsgSend{FromAddress: alice, ToAddress: to, Amount: sdk.NewCoins(sdk.NewInt64Coin("uiris", amount))}
+	}
+	valid := txSpec{msgs: []proto.Message{send(alice, 400)}, fee: 100, gas: 200000, memo: "order-1"}
+
+	testCases := []struct {
+		name string
+		spec txSpec
+		rule Rule
+	}{
+		{"msg type", txSpec{msgs: []proto.Message{&bank.Input{}}, fee: 100, gas: 200000, memo: "order-1"}, RuleMsgType},
+		{"denied recipient", valid.with(func(s *txSpec) { s.msgs = []proto.Message{send(bob, 1)} }), RuleRecipient},
+		// the chain accepts the upper case addresses
+		{"denied recipient in upper case", valid.with(func(s *txSpec) { s.msgs = []proto.Message{send(strings.ToUpper(bob), 1)} }), RuleRecipient},
+		{"invalid recipient", valid.with(func(s *txSpec) { s.msgs = []proto.Message{send("iaa1invalid", 1)} }), RuleDecode},
+		{"denied recipient of a multisend", valid.with(func(s *txSpec) {
+			s.msgs = []proto.Message{&bank.MsgMultiSend{Outputs: []bank.Output{
+				{Address: alice, Coins: sdk.NewCoins(sdk.NewInt64Coin("uiris", 1))},
+				{Address: bob, Coins: sdk.NewCoins(sdk.NewInt64Coin("uiris", 1))},
+			}}}
+		}), RuleRecipient},
+		{"amount per tx", valid.with(func(s *txSpec) { s.msgs = []proto.Message{send(alice, 300), send(alice, 300)} }), RuleAmountPerTx},
+		{"max fee", valid.with(func(s *txSpec) { s.fee = 101 }), RuleMaxFee},
+		{"max gas", valid.with(func(s *txSpec) { s.gas = 200001 }), RuleMaxGas},
+		{"memo", valid.with(func(s *txSpec) { s.memo = "order-1 and more" }), RuleMemo},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := km.Sign("hot", password, signBytes(t, tc.spec))
+			requireViolation(t, err, tc.rule)
+		})
+	}
+
+	_, _, err = km.Sign("hot", password, []byte("not a tx"))
+	requireViolation(t, err, RuleDecode)
+
+	// 1000uiris per hour
+	_, _, err = km.Sign("hot", password, signBytes(t, valid))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Minute)
+	_, _, err = km.Sign("hot", password, signBytes(t, valid))
+	require.NoError(t, err)
+	_, _, err = km.Sign("hot", password, signBytes(t, valid))
+	requireViolation(t, err, RuleAmountPerWindow)
+
+	now = now.Add(31 * time.Minute)
+	_, _, err = km.Sign("hot", password, signBytes(t, valid))
+	require.NoError(t, err)
+
+	// the recipients of the policy are matched whatever their case
+	km, err = NewKeyManager(inner, Policy{AllowedRecipients: []string{strings.ToUpper(alice)}})
+	require.NoError(t, err)
+	_, _, err = km.Sign("hot", password, signBytes(t, valid))
+	require.NoError(t, err)
+	_, _, err = km.Sign("hot", password, signBytes(t, valid.with(func(s *txSpec) { s.msgs = []proto.Message{send(bob, 1)} })))
+	requireViolation(t, err, RuleRecipient)
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown rule":    `{"max_gaz": 1}`,
+		"invalid memo":    `{"memo_pattern": "("}`,
+		"invalid window":  `{"limits": [{"denom": "uiris", "per_window": "1"}]}`,
+		"invalid address": `{"allowed_recipients": ["alice"]}`,
+	} {
+		filename := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0600))
+		_, err := LoadPolicy(filename)
+		require.Error(t, err, name)
+	}
+}
+
+func requireViolation(t *testing.T, err error, rule Rule) {
+	require.True(t, errors.Is(err, ErrViolation), err)
+	var v *Violation
+	require.True(t, errors.As(err, &v))
+	require.Equal(t, rule, v.Rule, v.Reason)
+}
+
+type txSpec struct {
+	msgs []proto.Message
+	fee  int64
+	gas  uint64
+	memo string
+}
+
+func (s txSpec) with(f func(*txSpec)) txSpec {
+	f(&s)
+	return s
+}
+
+func signBytes(t *testing.T, spec txSpec) []byte {
+	body := tx.TxBody{Memo: spec.memo}
+	for _, msg := range spec.msgs {
+		any, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		body.Messages = append(body.Messages, any)
+	}
+	bodyBytes, err := proto.Marshal(&body)
+	require.NoError(t, err)
+
+	authInfo, err := proto.Marshal(&tx.AuthInfo{
+		SignerInfos: []*tx.SignerInfo{{Sequence: 1}},
+		Fee:         &tx.Fee{Amount: sdk.NewCoins(sdk.NewInt64Coin("uiris", spec.fee)), GasLimit: spec.gas},
+	})
+	require.NoError(t, err)
+
+	bz, err := proto.Marshal(&tx.SignDoc{BodyBytes: bodyBytes, AuthInfoBytes: authInfo, ChainId: "test-chain", AccountNumber: 1})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestSigningPolicy(t *testing.T) {
+	perTx := sdk.NewInt(10000000)
+	km, err := NewKeyManager(modules.NewKeyManager(store.NewMemory(nil), "secp256k1"), Policy{
+		AllowedMsgTypes: []string{"/cosmos.bank.v1beta1.MsgSend"},
+		Limits:          []Limit{{Denom: sdk.BaseDenom, PerTx: &perTx}},
+	})
+	require.NoError(t, err)
+	chain, client, _, bob := fakechaintest.Setup(t, sdk.KeyManagerOption(km))
+
+	amount, err := sdk.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	_, sdkErr := client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, sdkErr)
+	require.Equal(t, "10000000", chain.Balances(bob).AmountOf(sdk.BaseDenom).String())
+
+	amount, err = sdk.ParseDecCoins("11iris")
+	require.NoError(t, err)
+	_, sdkErr = client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), ErrViolation.Error())
+	require.Equal(t, "10000000", chain.Balances(bob).AmountOf(sdk.BaseDenom).String())
+}
@@ -0,0 +1,194 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	serviceName = "irishub.sdk.signer.v1.Signer"
+
+	methodSign   = "/" + serviceName + "/Sign"
+	methodPubKey = "/" + serviceName + "/PubKey"
+	methodList   = "/" + serviceName + "/List"
+
+	// codecName is the content subtype of the signer service, it doesn't replace the "json" codec of the process
+	codecName = "irishub-signer-json"
+)
+
+// the server looks the codec up by the content subtype of the requests
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes the messages of the signer service in JSON, so the service needs no generated code
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+var _ Signer = &GRPCClient{}
+
+// GRPCClient is the Signer calling a signer service over gRPC
+type GRPCClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewGRPCClient connects to the signer service at addr. The connection uses mutual TLS with tlsConfig,
+// which must hold the client certificate, e.g. the config returned by NewClientTLSConfig.
+func NewGRPCClient(addr string, tlsConfig *tls.Config, opts ...grpc.DialOption) (*GRPCClient, error) {
+	if tlsConfig == nil {
+		return nil, errNoTLSConfig
+	}
+	return dialGRPC(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), opts...)
+}
+
+// NewInsecureGRPCClient connects to the signer service at addr without TLS, the signer must only be
+// reachable through a trusted channel, e.g. a unix socket
+func NewInsecureGRPCClient(addr string, opts ...grpc.DialOption) (*GRPCClient, error) {
+	return dialGRPC(addr, grpc.WithInsecure(), opts...)
+}
+
+func dialGRPC(addr string, transport grpc.DialOption, opts ...grpc.DialOption) (*GRPCClient, error) {
+	opts = append([]grpc.DialOption{transport, grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{}))}, opts...)
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCClient{conn: conn}, nil
+}
+
+func (c *GRPCClient) Sign(ctx context.Context, req SignRequest) (res SignResponse, err error) {
+	err = c.invoke(ctx, methodSign, req, &res)
+	return res, err
+}
+
+func (c *GRPCClient) PubKey(ctx context.Context, req PubKeyRequest) (res PubKeyResponse, err error) {
+	err = c.invoke(ctx, methodPubKey, req, &res)
+	return res, err
+}
+
+func (c *GRPCClient) List(ctx context.Context, req ListRequest) (res ListResponse, err error) {
+	err = c.invoke(ctx, methodList, req, &res)
+	return res, err
+}
+
+// Close closes the connection
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *GRPCClient) invoke(ctx context.Context, method string, req, res interface{}) error {
+	err := c.conn.Invoke(ctx, method, req, res)
+	if err == nil {
+		return nil
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch s.Code() {
+	case codes.PermissionDenied:
+		return remoteError(ErrRejected, s.Message())
+	case codes.NotFound:
+		return remoteError(ErrKeyNotFound, s.Message())
+	case codes.DeadlineExceeded:
+		return remoteError(context.DeadlineExceeded, s.Message())
+	default:
+		return err
+	}
+}
+
+// RegisterGRPCServer registers the signer service of the Signer on the gRPC server.
+// The server should be created with the credentials returned by NewServerTLSConfig to require the client certificates.
+func RegisterGRPCServer(server *grpc.Server, signer Signer) {
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*Signer)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "Sign", Handler: signHandler},
+			{MethodName: "PubKey", Handler: pubKeyHandler},
+			{MethodName: "List", Handler: listHandler},
+		},
+		Streams: []grpc.StreamDesc{},
+	}, signer)
+}
+
+func signHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	var req SignRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := srv.(Signer).Sign(ctx, req.(SignRequest))
+		return res, statusError(err)
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: methodSign}, handler)
+}
+
+func pubKeyHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	var req PubKeyRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := srv.(Signer).PubKey(ctx, req.(PubKeyRequest))
+		return res, statusError(err)
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: methodPubKey}, handler)
+}
+
+func listHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	var req ListRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := srv.(Signer).List(ctx, req.(ListRequest))
+		return res, statusError(err)
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: methodList}, handler)
+}
+
+// statusError returns the gRPC status of the error of the Signer
+func statusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrRejected):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
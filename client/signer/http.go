@@ -0,0 +1,184 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	pathSign   = "/v1/sign"
+	pathPubKey = "/v1/pubkey"
+	pathList   = "/v1/list"
+
+	// maximum size of a request body accepted by the handler
+	maxBodySize = 1 << 20
+)
+
+var _ Signer = HTTPClient{}
+
+// HTTPClient is the Signer calling a signer service over HTTP, the requests and responses are encoded in JSON
+type HTTPClient struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPClient returns the client of the signer service at url, e.g. https://127.0.0.1:8443.
+// The connection uses mutual TLS with tlsConfig, which must hold the client certificate,
+// e.g. the config returned by NewClientTLSConfig.
+func NewHTTPClient(url string, tlsConfig *tls.Config) (HTTPClient, error) {
+	if tlsConfig == nil {
+		return HTTPClient{}, errNoTLSConfig
+	}
+	if !strings.HasPrefix(url, "https://") {
+		return HTTPClient{}, fmt.Errorf("the url %s of the signer service isn't https", url)
+	}
+	return newHTTPClient(url, tlsConfig), nil
+}
+
+// NewInsecureHTTPClient returns the client of the signer service at url without TLS, the signer must
+// only be reachable through a trusted channel, e.g. the loopback interface of a test
+func NewInsecureHTTPClient(url string) HTTPClient {
+	return newHTTPClient(url, nil)
+}
+
+func newHTTPClient(url string, tlsConfig *tls.Config) HTTPClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return HTTPClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Transport: transport},
+	}
+}
+
+func (c HTTPClient) Sign(ctx context.Context, req SignRequest) (res SignResponse, err error) {
+	err = c.post(ctx, pathSign, req, &res)
+	return res, err
+}
+
+func (c HTTPClient) PubKey(ctx context.Context, req PubKeyRequest) (res PubKeyResponse, err error) {
+	err = c.post(ctx, pathPubKey, req, &res)
+	return res, err
+}
+
+func (c HTTPClient) List(ctx context.Context, req ListRequest) (res ListResponse, err error) {
+	err = c.post(ctx, pathList, req, &res)
+	return res, err
+}
+
+func (c HTTPClient) post(ctx context.Context, path string, req, res interface{}) error {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, c.url+path, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := c.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(httpRes.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		var errRes errorResponse
+		if err := json.Unmarshal(body, &errRes); err != nil || errRes.Error == "" {
+			errRes.Error = strings.TrimSpace(string(body))
+		}
+
+		switch httpRes.StatusCode {
+		case http.StatusForbidden:
+			return remoteError(ErrRejected, errRes.Error)
+		case http.StatusNotFound:
+			return remoteError(ErrKeyNotFound, errRes.Error)
+		case http.StatusGatewayTimeout:
+			return remoteError(context.DeadlineExceeded, errRes.Error)
+		default:
+			return fmt.Errorf("remote signer error %d: %s", httpRes.StatusCode, errRes.Error)
+		}
+	}
+	return json.Unmarshal(body, res)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHTTPHandler returns the HTTP handler of the signer service of the Signer.
+// It should be served with the TLS config returned by NewServerTLSConfig to require the client certificates.
+func NewHTTPHandler(signer Signer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathSign, func(w http.ResponseWriter, r *http.Request) {
+		var req SignRequest
+		if decodeRequest(w, r, &req) {
+			res, err := signer.Sign(r.Context(), req)
+			writeResponse(w, res, err)
+		}
+	})
+	mux.HandleFunc(pathPubKey, func(w http.ResponseWriter, r *http.Request) {
+		var req PubKeyRequest
+		if decodeRequest(w, r, &req) {
+			res, err := signer.PubKey(r.Context(), req)
+			writeResponse(w, res, err)
+		}
+	})
+	mux.HandleFunc(pathList, func(w http.ResponseWriter, r *http.Request) {
+		var req ListRequest
+		if decodeRequest(w, r, &req) {
+			res, err := signer.List(r.Context(), req)
+			writeResponse(w, res, err)
+		}
+	})
+	return mux
+}
+
+// decodeRequest decodes the JSON body of the request, it writes the error response and returns false on failure
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, res interface{}, err error) {
+	switch {
+	case err == nil:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	case errors.Is(err, ErrRejected):
+		writeError(w, http.StatusForbidden, err)
+	case errors.Is(err, ErrKeyNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,163 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// DefaultTimeout is the timeout of the requests to the signer
+const DefaultTimeout = 10 * time.Second
+
+var _ sdk.KeyManager = keyManager{}
+
+// keyManager is the KeyManager delegating the signatures to a remote signer, the private keys never enter the process
+type keyManager struct {
+	signer  Signer
+	timeout time.Duration
+}
+
+// NewKeyManager returns the KeyManager signing with the Signer, e.g. a GRPCClient or an HTTPClient, to be set
+// with types.KeyManagerOption. The names of the keys are the key ids of the signer, and the passwords are ignored.
+// Every request fails after the timeout, DefaultTimeout is used when zero.
+func NewKeyManager(signer Signer, timeout time.Duration) sdk.KeyManager {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return keyManager{signer: signer, timeout: timeout}
+}
+
+func (k keyManager) Sign(name, password string, data []byte) ([]byte, tmcrypto.PubKey, error) {
+	req := SignRequest{KeyID: name, SignBytes: data, SignMode: SignModeArbitrary}
+	// the ADR-036 sign bytes are sent without summary, the signer applies its own policy to them
+	if _, err := DecodeArbitrary(data); err != nil {
+		summary, err := DecodeSummary(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.SignMode, req.ChainID, req.Summary = SignModeDirect, summary.ChainID, summary
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+
+	res, err := k.signer.Sign(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("name %s: %w", name, err)
+	}
+
+	pubKey, err := cryptoamino.PubKeyFromBytes(res.PubKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("name %s: invalid pubkey returned by the remote signer: %w", name, err)
+	}
+	// never broadcast a signature the chain would reject
+	if !pubKey.VerifySignature(data, res.Signature) {
+		return nil, nil, fmt.Errorf("name %s: invalid signature returned by the remote signer", name)
+	}
+	return res.Signature, pubKey, nil
+}
+
+func (k keyManager) Find(name, password string) (tmcrypto.PubKey, sdk.AccAddress, error) {
+	key, err := k.ShowPublic(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key.PubKey, key.Address, nil
+}
+
+func (k keyManager) ShowPublic(name string) (sdk.KeyMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+
+	res, err := k.signer.PubKey(ctx, PubKeyRequest{KeyID: name})
+	if err != nil {
+		return sdk.KeyMetadata{}, fmt.Errorf("name %s: %w", name, err)
+	}
+	return keyMetadata(res)
+}
+
+func (k keyManager) List() ([]sdk.KeyMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+
+	res, err := k.signer.List(ctx, ListRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]sdk.KeyMetadata, 0, len(res.Keys))
+	for _, key := range res.Keys {
+		metadata, err := keyMetadata(key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, metadata)
+	}
+	return keys, nil
+}
+
+func (k keyManager) Insert(name, password string) (string, string, error) {
+	return "", "", ErrUnsupported
+}
+
+func (k keyManager) InsertWithAlgo(name, password, algo string) (string, string, error) {
+	return "", "", ErrUnsupported
+}
+
+func (k keyManager) InsertWithOptions(name, password string, opts sdk.KeyOptions) (string, string, error) {
+	return "", "", ErrUnsupported
+}
+
+func (k keyManager) Recover(name, password, mnemonic, hdPath string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (k keyManager) RecoverWithAlgo(name, password, mnemonic, hdPath, algo string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (k keyManager) RecoverWithOptions(name, password, mnemonic string, opts sdk.KeyOptions) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (k keyManager) Import(name, password string, privKeyArmor string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (k keyManager) ImportWatchOnly(name, password, address string, pubKey tmcrypto.PubKey) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (k keyManager) Export(name, password string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (k keyManager) Delete(name, password string) error {
+	return ErrUnsupported
+}
+
+func (k keyManager) ChangePassword(name, oldPassword, newPassword string) error {
+	return ErrUnsupported
+}
+
+func (k keyManager) Rename(name, newName, password string) error {
+	return ErrUnsupported
+}
+
+func keyMetadata(res PubKeyResponse) (sdk.KeyMetadata, error) {
+	pubKey, err := cryptoamino.PubKeyFromBytes(res.PubKey)
+	if err != nil {
+		return sdk.KeyMetadata{}, fmt.Errorf("name %s: invalid pubkey returned by the remote signer: %w", res.KeyID, err)
+	}
+	return sdk.KeyMetadata{
+		Name:    res.KeyID,
+		Algo:    res.Algo,
+		PubKey:  pubKey,
+		Address: sdk.AccAddress(pubKey.Address().Bytes()),
+	}, nil
+}
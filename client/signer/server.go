@@ -0,0 +1,165 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+)
+
+// PasswordFunc returns the password of the key
+type PasswordFunc func(keyID string) (string, error)
+
+var _ Signer = keyDAOSigner{}
+
+// keyDAOSigner is the reference Signer, signing with the keys of a KeyDAO
+type keyDAOSigner struct {
+	keyDAO   store.KeyDAO
+	password PasswordFunc
+	chainIDs map[string]bool
+}
+
+// NewKeyDAOSigner returns the reference Signer signing with the keys of the KeyDAO, to be served by
+// RegisterGRPCServer or NewHTTPHandler in the signer process. It rejects the requests whose summary
+// doesn't match the sign bytes, and the requests of other chains than chainIDs when given.
+// The arbitrary data is only signed in an ADR-036 sign doc of the address of the key.
+func NewKeyDAOSigner(keyDAO store.KeyDAO, password PasswordFunc, chainIDs ...string) Signer {
+	s := keyDAOSigner{
+		keyDAO:   keyDAO,
+		password: password,
+	}
+	if len(chainIDs) > 0 {
+		s.chainIDs = make(map[string]bool, len(chainIDs))
+		for _, chainID := range chainIDs {
+			s.chainIDs[chainID] = true
+		}
+	}
+	return s
+}
+
+func (s keyDAOSigner) Sign(ctx context.Context, req SignRequest) (SignResponse, error) {
+	var arbitrary *ArbitraryDoc
+	switch req.SignMode {
+	case SignModeDirect, "":
+		if err := s.checkTx(req); err != nil {
+			return SignResponse{}, err
+		}
+	case SignModeArbitrary:
+		doc, err := checkArbitrary(req)
+		if err != nil {
+			return SignResponse{}, err
+		}
+		arbitrary = &doc
+	default:
+		return SignResponse{}, fmt.Errorf("%w: unknown sign mode %s", ErrRejected, req.SignMode)
+	}
+
+	info, err := s.read(req.KeyID)
+	if err != nil {
+		return SignResponse{}, err
+	}
+	if arbitrary != nil {
+		if err := checkArbitrarySigner(*arbitrary, info); err != nil {
+			return SignResponse{}, err
+		}
+	}
+
+	privKey, err := cryptoamino.PrivKeyFromBytes([]byte(info.PrivKeyArmor))
+	if err != nil {
+		return SignResponse{}, err
+	}
+	// the ante handler of the chain rejects the ed25519 signatures of the txs
+	if arbitrary == nil && privKey.PubKey().Type() == string(hd.Ed25519Type) {
+		return SignResponse{}, fmt.Errorf("key %s: %w: %s", req.KeyID, sdk.ErrTxKeyAlgo, privKey.PubKey().Type())
+	}
+
+	signature, err := privKey.Sign(req.SignBytes)
+	if err != nil {
+		return SignResponse{}, err
+	}
+	return SignResponse{Signature: signature, PubKey: info.PubKey}, nil
+}
+
+// checkTx rejects the tx whose summary doesn't match the sign bytes, or of a chain not allowed
+func (s keyDAOSigner) checkTx(req SignRequest) error {
+	summary, err := DecodeSummary(req.SignBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrRejected, err)
+	}
+	if !summary.Equal(req.Summary) || summary.ChainID != req.ChainID {
+		return fmt.Errorf("%w: the summary doesn't match the sign bytes", ErrRejected)
+	}
+	if s.chainIDs != nil && !s.chainIDs[summary.ChainID] {
+		return fmt.Errorf("%w: chain-id %s not allowed", ErrRejected, summary.ChainID)
+	}
+	return nil
+}
+
+// checkArbitrary rejects the request of arbitrary data whose sign bytes aren't an ADR-036 sign doc,
+// so that no tx can be signed in this mode
+func checkArbitrary(req SignRequest) (ArbitraryDoc, error) {
+	if req.ChainID != "" || !req.Summary.Equal(TxSummary{}) {
+		return ArbitraryDoc{}, fmt.Errorf("%w: the sign request of arbitrary data has a summary", ErrRejected)
+	}
+	doc, err := DecodeArbitrary(req.SignBytes)
+	if err != nil {
+		return ArbitraryDoc{}, fmt.Errorf("%w: %s", ErrRejected, err)
+	}
+	return doc, nil
+}
+
+// checkArbitrarySigner rejects the arbitrary data signed on behalf of another address than the key's
+func checkArbitrarySigner(doc ArbitraryDoc, info store.KeyInfo) error {
+	pubKey, err := cryptoamino.PubKeyFromBytes(info.PubKey)
+	if err != nil {
+		return err
+	}
+	signer, err := sdk.AccAddressFromBech32(doc.Signer)
+	if err != nil || !signer.Equals(sdk.AccAddress(pubKey.Address())) {
+		return fmt.Errorf("%w: the signer %s of the data isn't the key", ErrRejected, doc.Signer)
+	}
+	return nil
+}
+
+func (s keyDAOSigner) PubKey(ctx context.Context, req PubKeyRequest) (PubKeyResponse, error) {
+	info, err := s.keyDAO.ReadMetadata(req.KeyID)
+	if err != nil || len(info.PubKey) == 0 {
+		// e.g. the keyring-file backend encrypts the public key too
+		if info, err = s.read(req.KeyID); err != nil {
+			return PubKeyResponse{}, err
+		}
+	}
+	return PubKeyResponse{KeyID: req.KeyID, Algo: info.Algo, PubKey: info.PubKey}, nil
+}
+
+func (s keyDAOSigner) List(ctx context.Context, req ListRequest) (ListResponse, error) {
+	names, err := s.keyDAO.List()
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	res := ListResponse{Keys: make([]PubKeyResponse, 0, len(names))}
+	for _, name := range names {
+		key, err := s.PubKey(ctx, PubKeyRequest{KeyID: name})
+		if err != nil {
+			return ListResponse{}, err
+		}
+		res.Keys = append(res.Keys, key)
+	}
+	return res, nil
+}
+
+func (s keyDAOSigner) read(keyID string) (store.KeyInfo, error) {
+	if !s.keyDAO.Has(keyID) {
+		return store.KeyInfo{}, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+
+	password, err := s.password(keyID)
+	if err != nil {
+		return store.KeyInfo{}, err
+	}
+	return s.keyDAO.Read(keyID, password)
+}
@@ -0,0 +1,385 @@
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	irishub "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/client/signer"
+	codectypes "github.com/irisnet/irishub-sdk-go/codec/types"
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/keys"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+	"github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+const (
+	chainID  = "test-chain"
+	password = "12345678"
+)
+
+func TestKeyManager(t *testing.T) {
+	certs := newCerts(t)
+	keyDAO, address := newKeyDAO(t)
+	s := signer.NewKeyDAOSigner(keyDAO, func(string) (string, error) { return password, nil }, chainID)
+
+	clients := map[string]signer.Signer{
+		"grpc": newGRPCClient(t, certs, s),
+		"http": newHTTPClient(t, certs, s),
+	}
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			km := signer.NewKeyManager(client, time.Second)
+
+			pubKey, addr, err := km.Find("alice", "")
+			require.NoError(t, err)
+			require.Equal(t, address, addr)
+
+			list, err := km.List()
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.Equal(t, "alice", list[0].Name)
+			require.Equal(t, "secp256k1", list[0].Algo)
+
+			data := signBytes(t, chainID)
+			signature, signPubKey, err := km.Sign("alice", "", data)
+			require.NoError(t, err)
+			require.True(t, pubKey.Equals(signPubKey))
+			require.True(t, pubKey.VerifySignature(data, signature))
+
+			_, _, err = km.Sign("bob", "", data)
+			require.True(t, errors.Is(err, signer.ErrKeyNotFound), err)
+
+			// the signer only signs for its chain
+			_, _, err = km.Sign("alice", "", signBytes(t, "another-chain"))
+			require.True(t, errors.Is(err, signer.ErrRejected), err)
+
+			// the ADR-036 sign bytes are signed without summary
+			data = keys.ArbitrarySignBytes(address.String(), []byte("data"))
+			signature, _, err = km.Sign("alice", "", data)
+			require.NoError(t, err)
+			require.True(t, pubKey.VerifySignature(data, signature))
+
+			// but only on behalf of the key
+			_, _, err = km.Sign("alice", "", keys.ArbitrarySignBytes(sdk.AccAddress(make([]byte, 20)).String(), []byte("data")))
+			require.True(t, errors.Is(err, signer.ErrRejected), err)
+
+			_, _, err = km.Insert("bob", password)
+			require.True(t, errors.Is(err, signer.ErrUnsupported))
+		})
+	}
+}
+
+func TestRejectSignMode(t *testing.T) {
+	keyDAO, address := newKeyDAO(t)
+	s := signer.NewKeyDAOSigner(keyDAO, func(string) (string, error) { return password, nil })
+
+	arbitrary := keys.ArbitrarySignBytes(address.String(), []byte("data"))
+	doc, err := signer.DecodeArbitrary(arbitrary)
+	require.NoError(t, err)
+	require.Equal(t, address.String(), doc.Signer)
+	require.Equal(t, []byte("data"), doc.Data)
+	_, err = signer.DecodeArbitrary(signBytes(t, chainID))
+	require.Error(t, err)
+
+	for name, req := range map[string]signer.SignRequest{
+		"arbitrary as tx":   {KeyID: "alice", SignBytes: arbitrary, SignMode: signer.SignModeDirect},
+		"tx as arbitrary":   {KeyID: "alice", SignBytes: signBytes(t, chainID), SignMode: signer.SignModeArbitrary},
+		"unknown sign mode": {KeyID: "alice", SignBytes: arbitrary, SignMode: "unknown"},
+		"arbitrary with summary": {
+			KeyID: "alice", SignBytes: arbitrary, SignMode: signer.SignModeArbitrary, Summary: signer.TxSummary{Memo: "memo"},
+		},
+	} {
+		_, err := s.Sign(context.Background(), req)
+		require.True(t, errors.Is(err, signer.ErrRejected), name)
+	}
+}
+
+func TestRejectTxKeyAlgo(t *testing.T) {
+	keyDAO, address := newAlgoKeyDAO(t, "ed25519")
+	s := signer.NewKeyDAOSigner(keyDAO, func(string) (string, error) { return password, nil })
+
+	data := signBytes(t, chainID)
+	summary, err := signer.DecodeSummary(data)
+	require.NoError(t, err)
+	_, err = s.Sign(context.Background(), signer.SignRequest{KeyID: "alice", SignBytes: data, ChainID: chainID, Summary: summary})
+	require.True(t, errors.Is(err, sdk.ErrTxKeyAlgo), err)
+
+	// the arbitrary data is still signed
+	arbitrary := keys.ArbitrarySignBytes(address.String(), []byte("data"))
+	_, err = s.Sign(context.Background(), signer.SignRequest{KeyID: "alice", SignBytes: arbitrary, SignMode: signer.SignModeArbitrary})
+	require.NoError(t, err)
+}
+
+func TestClientTLSRequired(t *testing.T) {
+	_, err := signer.NewGRPCClient("127.0.0.1:0", nil)
+	require.Error(t, err)
+	_, err = signer.NewHTTPClient("https://127.0.0.1:8443", nil)
+	require.Error(t, err)
+	_, err = signer.NewHTTPClient("http://127.0.0.1:8443", &tls.Config{MinVersion: tls.VersionTLS12})
+	require.Error(t, err)
+}
+
+func TestRejectTamperedSummary(t *testing.T) {
+	keyDAO, _ := newKeyDAO(t)
+	s := signer.NewKeyDAOSigner(keyDAO, func(string) (string, error) { return password, nil })
+
+	data := signBytes(t, chainID)
+	summary, err := signer.DecodeSummary(data)
+	require.NoError(t, err)
+	require.Equal(t, chainID, summary.ChainID)
+	require.Equal(t, []string{"/cosmos.bank.v1beta1.MsgSend"}, summary.Msgs)
+	require.Equal(t, uint64(3), summary.Sequence)
+	require.Equal(t, "4uiris", summary.Fee)
+	require.Equal(t, "memo", summary.Memo)
+
+	summary.Memo = "another memo"
+	_, err = s.Sign(context.Background(), signer.SignRequest{KeyID: "alice", SignBytes: data, ChainID: chainID, Summary: summary})
+	require.True(t, errors.Is(err, signer.ErrRejected), err)
+}
+
+func TestMutualTLS(t *testing.T) {
+	certs := newCerts(t)
+	keyDAO, _ := newKeyDAO(t)
+	s := signer.NewKeyDAOSigner(keyDAO, func(string) (string, error) { return password, nil })
+
+	// the client without certificate is refused
+	serverTLS, err := signer.NewServerTLSConfig(certs.serverCert, certs.serverKey, certs.ca)
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(signer.NewHTTPHandler(s))
+	server.TLS = serverTLS
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	pool, err := x509.SystemCertPool()
+	require.NoError(t, err)
+	caPEM, err := ioutil.ReadFile(certs.ca)
+	require.NoError(t, err)
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	client, err := signer.NewHTTPClient(server.URL, &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+	_, err = client.List(context.Background(), signer.ListRequest{})
+	require.Error(t, err)
+}
+
+func TestTimeout(t *testing.T) {
+	certs := newCerts(t)
+	s := slowSigner{}
+
+	clients := map[string]signer.Signer{
+		"grpc": newGRPCClient(t, certs, s),
+		"http": newHTTPClient(t, certs, s),
+	}
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			km := signer.NewKeyManager(client, 100*time.Millisecond)
+			_, _, err := km.Sign("alice", "", signBytes(t, chainID))
+			require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		})
+	}
+}
+
+// slowSigner never answers before the deadline
+type slowSigner struct{}
+
+func (slowSigner) Sign(ctx context.Context, req signer.SignRequest) (signer.SignResponse, error) {
+	<-ctx.Done()
+	return signer.SignResponse{}, ctx.Err()
+}
+
+func (slowSigner) PubKey(ctx context.Context, req signer.PubKeyRequest) (signer.PubKeyResponse, error) {
+	<-ctx.Done()
+	return signer.PubKeyResponse{}, ctx.Err()
+}
+
+func (slowSigner) List(ctx context.Context, req signer.ListRequest) (signer.ListResponse, error) {
+	<-ctx.Done()
+	return signer.ListResponse{}, ctx.Err()
+}
+
+func newGRPCClient(t *testing.T, certs certFiles, s signer.Signer) signer.Signer {
+	serverTLS, err := signer.NewServerTLSConfig(certs.serverCert, certs.serverKey, certs.ca)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS)))
+	signer.RegisterGRPCServer(server, s)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	clientTLS, err := signer.NewClientTLSConfig(certs.clientCert, certs.clientKey, certs.ca)
+	require.NoError(t, err)
+	client, err := signer.NewGRPCClient(lis.Addr().String(), clientTLS)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func newHTTPClient(t *testing.T, certs certFiles, s signer.Signer) signer.Signer {
+	serverTLS, err := signer.NewServerTLSConfig(certs.serverCert, certs.serverKey, certs.ca)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(signer.NewHTTPHandler(s))
+	server.TLS = serverTLS
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	clientTLS, err := signer.NewClientTLSConfig(certs.clientCert, certs.clientKey, certs.ca)
+	require.NoError(t, err)
+	client, err := signer.NewHTTPClient(server.URL, clientTLS)
+	require.NoError(t, err)
+	return client
+}
+
+func newKeyDAO(t *testing.T) (store.KeyDAO, sdk.AccAddress) {
+	return newAlgoKeyDAO(t, "secp256k1")
+}
+
+func newAlgoKeyDAO(t *testing.T, algo string) (store.KeyDAO, sdk.AccAddress) {
+	km, err := crypto.NewAlgoKeyManager(algo)
+	require.NoError(t, err)
+	_, privKey := km.Generate()
+
+	keyDAO := store.NewMemory(nil)
+	require.NoError(t, keyDAO.Write("alice", password, store.KeyInfo{
+		Name:         "alice",
+		PubKey:       cryptoamino.MarshalPubkey(privKey.PubKey()),
+		PrivKeyArmor: string(cryptoamino.MarshalPrivKey(privKey)),
+		Algo:         algo,
+	}))
+	return keyDAO, sdk.AccAddress(privKey.PubKey().Address())
+}
+
+func signBytes(t *testing.T, chainID string) []byte {
+	body, err := proto.Marshal(&tx.TxBody{
+		Messages: []*codectypes.Any{{TypeUrl: "/cosmos.bank.v1beta1.MsgSend"}},
+		Memo:     "memo",
+	})
+	require.NoError(t, err)
+
+	authInfo, err := proto.Marshal(&tx.AuthInfo{
+		SignerInfos: []*tx.SignerInfo{{Sequence: 3}},
+		Fee:         &tx.Fee{Amount: sdk.NewCoins(sdk.NewInt64Coin("uiris", 4)), GasLimit: 200000},
+	})
+	require.NoError(t, err)
+
+	bz, err := proto.Marshal(&tx.SignDoc{BodyBytes: body, AuthInfoBytes: authInfo, ChainId: chainID, AccountNumber: 1})
+	require.NoError(t, err)
+	return bz
+}
+
+type certFiles struct {
+	ca                    string
+	serverCert, serverKey string
+	clientCert, clientKey string
+}
+
+// newCerts writes a CA, the server certificate of 127.0.0.1 and a client certificate signed by the CA
+func newCerts(t *testing.T) certFiles {
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	files := certFiles{ca: filepath.Join(dir, "ca.pem")}
+	writePEM(t, files.ca, "CERTIFICATE", caDER)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		certFile, keyFile := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem")
+		writePEM(t, certFile, "CERTIFICATE", der)
+		writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+		return certFile, keyFile
+	}
+	files.serverCert, files.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	files.clientCert, files.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+	return files
+}
+
+func writePEM(t *testing.T, filename, blockType string, bz []byte) {
+	require.NoError(t, ioutil.WriteFile(filename, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bz}), 0600))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	keyDAO := store.NewMemory(nil)
+	chain, _, alice, bob := fakechaintest.Setup(t, sdk.KeyDAOOption(keyDAO))
+
+	// the signer process holds the keys
+	server := httptest.NewServer(signer.NewHTTPHandler(
+		signer.NewKeyDAOSigner(keyDAO, func(string) (string, error) { return fakechaintest.Password, nil }, chain.ChainID()),
+	))
+	t.Cleanup(server.Close)
+
+	cfg, err := chain.Config(sdk.KeyManagerOption(signer.NewKeyManager(signer.NewInsecureHTTPClient(server.URL), 0)))
+	require.NoError(t, err)
+	client := irishub.NewIRISHUBClient(cfg)
+
+	amount, err := sdk.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	_, err = client.Bank.Send(bob, amount, sdk.BaseTx{From: "alice", Gas: 200000})
+	require.NoError(t, err)
+	require.Equal(t, "10000000", chain.Balances(bob).AmountOf(sdk.BaseDenom).String())
+
+	address, err := client.Key.Show("alice", "")
+	require.NoError(t, err)
+	require.Equal(t, alice, address)
+
+	signature, err := client.Key.SignArbitrary("alice", "", []byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, client.Key.VerifyArbitrary(alice, []byte("data"), signature))
+
+	_, _, err = client.Key.Add("carol", fakechaintest.Password)
+	require.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+var (
+	// ErrUnsupported is returned by the methods of the KeyManager managing the keys, the keys are managed by the signer
+	ErrUnsupported = errors.New("not supported by the remote signer")
+	// ErrRejected is returned when the signer refused to sign
+	ErrRejected = errors.New("sign request rejected by the remote signer")
+	// ErrKeyNotFound is returned when the signer doesn't hold the key
+	ErrKeyNotFound = errors.New("key not found by the remote signer")
+)
+
+const (
+	// SignModeDirect is the mode of the SIGN_MODE_DIRECT sign bytes of a tx, summarized by the TxSummary
+	SignModeDirect = "direct"
+	// SignModeArbitrary is the mode of the ADR-036 sign bytes of arbitrary data, which can never be a valid tx,
+	// the summary and the chain-id are empty
+	SignModeArbitrary = "adr036"
+
+	// msgSignDataType is the amino type of the message of the ADR-036 sign doc
+	msgSignDataType = "sign/MsgSignData"
+)
+
+// Signer is the signing service, it holds the private keys and signs on behalf of the KeyManager
+type Signer interface {
+	// Sign signs the sign bytes with the key, it returns an error wrapping ErrRejected to refuse the request
+	Sign(ctx context.Context, req SignRequest) (SignResponse, error)
+	// PubKey returns the public key of the key, or an error wrapping ErrKeyNotFound
+	PubKey(ctx context.Context, req PubKeyRequest) (PubKeyResponse, error)
+	// List returns the public keys of all the keys
+	List(ctx context.Context, req ListRequest) (ListResponse, error)
+}
+
+// SignRequest asks the signer to sign the sign bytes of a transaction, or of arbitrary data
+type SignRequest struct {
+	KeyID     string `json:"key_id"`
+	SignBytes []byte `json:"sign_bytes"`
+	// SignMode is SignModeDirect or SignModeArbitrary, the empty mode is SignModeDirect
+	SignMode string    `json:"sign_mode,omitempty"`
+	ChainID  string    `json:"chain_id"`
+	Summary  TxSummary `json:"summary"`
+}
+
+// SignResponse is the signature of a SignRequest
+type SignResponse struct {
+	Signature []byte `json:"signature"`
+	// PubKey is the amino encoded public key of the key
+	PubKey []byte `json:"pub_key"`
+}
+
+// PubKeyRequest asks the signer for the public key of a key
+type PubKeyRequest struct {
+	KeyID string `json:"key_id"`
+}
+
+// PubKeyResponse is the public information of a key
+type PubKeyResponse struct {
+	KeyID string `json:"key_id"`
+	Algo  string `json:"algo"`
+	// PubKey is the amino encoded public key of the key
+	PubKey []byte `json:"pub_key"`
+}
+
+// ListRequest asks the signer for all its keys
+type ListRequest struct{}
+
+// ListResponse is the public information of all the keys of the signer
+type ListResponse struct {
+	Keys []PubKeyResponse `json:"keys"`
+}
+
+// TxSummary is the human readable content of the sign bytes, so the signer can apply its policies
+// without decoding the transaction
+type TxSummary struct {
+	ChainID       string   `json:"chain_id"`
+	AccountNumber uint64   `json:"account_number"`
+	Sequence      uint64   `json:"sequence"`
+	Msgs          []string `json:"msgs"`
+	Memo          string   `json:"memo"`
+	Fee           string   `json:"fee"`
+	Gas           uint64   `json:"gas"`
+	TimeoutHeight uint64   `json:"timeout_height"`
+}
+
+// Equal reports whether the summaries are the same
+func (s TxSummary) Equal(other TxSummary) bool {
+	if len(s.Msgs) != len(other.Msgs) {
+		return false
+	}
+	for i := range s.Msgs {
+		if s.Msgs[i] != other.Msgs[i] {
+			return false
+		}
+	}
+	return s.ChainID == other.ChainID &&
+		s.AccountNumber == other.AccountNumber &&
+		s.Sequence == other.Sequence &&
+		s.Memo == other.Memo &&
+		s.Fee == other.Fee &&
+		s.Gas == other.Gas &&
+		s.TimeoutHeight == other.TimeoutHeight
+}
+
+// DecodeSummary decodes the SIGN_MODE_DIRECT sign bytes of a transaction. The messages are
+// summarized by their type url, e.g. /cosmos.bank.v1beta1.MsgSend, and the sequence is the one of the first signer.
+func DecodeSummary(signBytes []byte) (TxSummary, error) {
+	var doc tx.SignDoc
+	if err := proto.Unmarshal(signBytes, &doc); err != nil {
+		return TxSummary{}, fmt.Errorf("invalid sign bytes: %w", err)
+	}
+
+	var body tx.TxBody
+	if err := proto.Unmarshal(doc.BodyBytes, &body); err != nil {
+		return TxSummary{}, fmt.Errorf("invalid tx body: %w", err)
+	}
+
+	var authInfo tx.AuthInfo
+	if err := proto.Unmarshal(doc.AuthInfoBytes, &authInfo); err != nil {
+		return TxSummary{}, fmt.Errorf("invalid auth info: %w", err)
+	}
+
+	summary := TxSummary{
+		ChainID:       doc.ChainId,
+		AccountNumber: doc.AccountNumber,
+		Memo:          body.Memo,
+		TimeoutHeight: body.TimeoutHeight,
+	}
+	for _, msg := range body.Messages {
+		summary.Msgs = append(summary.Msgs, msg.TypeUrl)
+	}
+	if len(authInfo.SignerInfos) > 0 {
+		summary.Sequence = authInfo.SignerInfos[0].Sequence
+	}
+	if authInfo.Fee != nil {
+		summary.Fee = authInfo.Fee.Amount.String()
+		summary.Gas = authInfo.Fee.GasLimit
+	}
+	return summary, nil
+}
+
+// ArbitraryDoc is the content of the ADR-036 sign bytes of arbitrary data
+type ArbitraryDoc struct {
+	// Signer is the bech32 address of the signer
+	Signer string
+	Data   []byte
+}
+
+type arbitrarySignDoc struct {
+	AccountNumber string `json:"account_number"`
+	ChainID       string `json:"chain_id"`
+	Fee           struct {
+		Amount []json.RawMessage `json:"amount"`
+		Gas    string            `json:"gas"`
+	} `json:"fee"`
+	Memo string `json:"memo"`
+	Msgs []struct {
+		Type  string `json:"type"`
+		Value struct {
+			Data   []byte `json:"data"`
+			Signer string `json:"signer"`
+		} `json:"value"`
+	} `json:"msgs"`
+	Sequence string `json:"sequence"`
+}
+
+// DecodeArbitrary decodes the ADR-036 sign bytes of arbitrary data, the amino JSON sign doc of a single
+// MsgSignData with an empty chain-id, a zero fee, account number and sequence
+func DecodeArbitrary(signBytes []byte) (ArbitraryDoc, error) {
+	var doc arbitrarySignDoc
+	if err := json.Unmarshal(signBytes, &doc); err != nil {
+		return ArbitraryDoc{}, fmt.Errorf("invalid sign bytes: %w", err)
+	}
+	if doc.ChainID != "" || doc.AccountNumber != "0" || doc.Sequence != "0" || doc.Memo != "" ||
+		len(doc.Fee.Amount) != 0 || doc.Fee.Gas != "0" {
+		return ArbitraryDoc{}, errors.New("invalid sign bytes: not an ADR-036 sign doc")
+	}
+	if len(doc.Msgs) != 1 || doc.Msgs[0].Type != msgSignDataType || doc.Msgs[0].Value.Signer == "" {
+		return ArbitraryDoc{}, fmt.Errorf("invalid sign bytes: expected a single %s", msgSignDataType)
+	}
+	return ArbitraryDoc{Signer: doc.Msgs[0].Value.Signer, Data: doc.Msgs[0].Value.Data}, nil
+}
+
+// remoteError returns the error of the signer wrapping err, msg is the message of the error returned by the signer
+func remoteError(err error, msg string) error {
+	msg = strings.TrimPrefix(strings.TrimPrefix(msg, err.Error()), ": ")
+	if msg == "" {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, msg)
+}
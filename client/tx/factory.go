@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/types/tx/signing"
 )
@@ -250,6 +251,14 @@ func (f *Factory) Sign(name string, txBuilder sdk.TxBuilder) error {
 	if err != nil {
 		return err
 	}
+	// a watch-only address
+	if pubkey == nil {
+		return fmt.Errorf("name %s: %w", name, sdk.ErrWatchOnly)
+	}
+	// the ante handler of the chain rejects the ed25519 signatures of the txs
+	if pubkey.Type() == string(hd.Ed25519Type) {
+		return fmt.Errorf("name %s: %w: %s", name, sdk.ErrTxKeyAlgo, pubkey.Type())
+	}
 
 	// For SIGN_MODE_DIRECT, calling SetSignatures calls setSignerInfos on
 	// Factory under the hood, and SignerInfos is needed to generated the
@@ -9,6 +9,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/crypto/keys/ed25519"
 	kmultisig "github.com/irisnet/irishub-sdk-go/crypto/keys/multisig"
 	"github.com/irisnet/irishub-sdk-go/crypto/keys/secp256k1"
+	"github.com/irisnet/irishub-sdk-go/crypto/keys/sm2"
 	cryptotypes "github.com/irisnet/irishub-sdk-go/crypto/types"
 )
 
@@ -28,6 +29,7 @@ func RegisterCrypto(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(tmed25519.PubKey{}, tmed25519.PubKeyName, nil)
 	cdc.RegisterConcrete(&ed25519.PubKey{}, ed25519.PubKeyName, nil)
 	cdc.RegisterConcrete(&secp256k1.PubKey{}, secp256k1.PubKeyName, nil)
+	cdc.RegisterConcrete(&sm2.PubKey{}, sm2.PubKeyName, nil)
 	cdc.RegisterConcrete(&kmultisig.LegacyAminoPubKey{}, kmultisig.PubKeyAminoRoute, nil)
 
 	cdc.RegisterInterface((*crypto.PrivKey)(nil), nil)
@@ -36,6 +38,7 @@ func RegisterCrypto(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(tmed25519.PrivKey{}, tmed25519.PrivKeyName, nil)
 	cdc.RegisterConcrete(&ed25519.PrivKey{}, ed25519.PrivKeyName, nil)
 	cdc.RegisterConcrete(&secp256k1.PrivKey{}, secp256k1.PrivKeyName, nil)
+	cdc.RegisterConcrete(&sm2.PrivKey{}, sm2.PrivKeyName, nil)
 }
 
 // PrivKeyFromBytes unmarshals private key bytes and returns a PrivKey
@@ -57,3 +60,14 @@ func MarshalPubkey(pubkey crypto.PubKey) []byte {
 func MarshalPrivKey(privKey crypto.PrivKey) []byte {
 	return amino.MustMarshalBinaryBare(privKey)
 }
+
+// MarshalPubKeyJSON returns the amino JSON of the public key, e.g. {"type":"tendermint/PubKeySecp256k1","value":"<base64>"}
+func MarshalPubKeyJSON(pubKey crypto.PubKey) ([]byte, error) {
+	return amino.MarshalJSON(pubKey)
+}
+
+// PubKeyFromJSON unmarshals the amino JSON of a public key
+func PubKeyFromJSON(bz []byte) (pubKey crypto.PubKey, err error) {
+	err = amino.UnmarshalJSON(bz, &pubKey)
+	return
+}
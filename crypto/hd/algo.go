@@ -5,8 +5,11 @@ import (
 
 	bip39 "github.com/cosmos/go-bip39"
 	"github.com/tendermint/tendermint/crypto"
+	stded25519 "golang.org/x/crypto/ed25519"
 
+	"github.com/irisnet/irishub-sdk-go/crypto/keys/ed25519"
 	"github.com/irisnet/irishub-sdk-go/crypto/keys/secp256k1"
+	"github.com/irisnet/irishub-sdk-go/crypto/keys/sm2"
 )
 
 type SignatureAlgo interface {
@@ -19,6 +22,10 @@ func NewSigningAlgoFromString(str string) (SignatureAlgo, error) {
 	switch str {
 	case string(Secp256k1.Name()):
 		return Secp256k1, nil
+	case string(Sm2.Name()):
+		return Sm2, nil
+	case string(Ed25519.Name()):
+		return Ed25519, nil
 	default:
 		return nil, fmt.Errorf("provided algorithm `%s` is not supported", str)
 	}
@@ -33,15 +40,22 @@ const (
 	// Secp256k1Type uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1Type = PubKeyType("secp256k1")
 	// Ed25519Type represents the Ed25519Type signature system.
-	// It is currently not supported for end-user keys (wallets/ledgers).
+	// It is not supported by the ledgers, and the cosmos-sdk ante handler rejects the transactions it signs,
+	// so its keys only sign arbitrary data, e.g. ADR-036.
 	Ed25519Type = PubKeyType("ed25519")
 	// Sr25519Type represents the Sr25519Type signature system.
 	Sr25519Type = PubKeyType("sr25519")
+	// Sm2Type uses the SM2 parameters of GB/T 32918.
+	Sm2Type = PubKeyType("sm2")
 )
 
 var (
 	// Secp256k1 uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1 = secp256k1Algo{}
+	// Sm2 uses the SM2 parameters of GB/T 32918.
+	Sm2 = sm2Algo{}
+	// Ed25519 uses the Ed25519 signature system.
+	Ed25519 = ed25519Algo{}
 )
 
 type DeriveFn func(mnemonic string, bip39Passphrase, hdPath string) ([]byte, error)
@@ -86,3 +100,59 @@ func (s secp256k1Algo) Generate() GenerateFn {
 		return &secp256k1.PrivKey{Key: bzArr}
 	}
 }
+
+type sm2Algo struct {
+}
+
+func (s sm2Algo) Name() PubKeyType {
+	return Sm2Type
+}
+
+// Derive derives and returns the private key bytes for the given seed and HD path,
+// the derivation is the same as secp256k1's. The bytes out of the range of the sm2
+// private keys are rejected, instead of being reduced into another key.
+func (s sm2Algo) Derive() DeriveFn {
+	return func(mnemonic string, bip39Passphrase, hdPath string) ([]byte, error) {
+		bz, err := Secp256k1.Derive()(mnemonic, bip39Passphrase, hdPath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := sm2.PrivKeyFromBytes(bz); err != nil {
+			return nil, fmt.Errorf("hd path %s: %w", hdPath, err)
+		}
+		return bz, nil
+	}
+}
+
+// Generate generates a sm2 private key from the bytes returned by Derive.
+func (s sm2Algo) Generate() GenerateFn {
+	return func(bz []byte) crypto.PrivKey {
+		return &sm2.PrivKey{Key: bz}
+	}
+}
+
+type ed25519Algo struct {
+}
+
+func (s ed25519Algo) Name() PubKeyType {
+	return Ed25519Type
+}
+
+// Derive derives and returns the seed of the ed25519 private key for the given seed and HD path by SLIP-0010,
+// every index of the path must be hardened.
+func (s ed25519Algo) Derive() DeriveFn {
+	return func(mnemonic string, bip39Passphrase, hdPath string) ([]byte, error) {
+		seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return DeriveEd25519KeyForPath(seed, hdPath)
+	}
+}
+
+// Generate generates an ed25519 private key from the seed returned by Derive.
+func (s ed25519Algo) Generate() GenerateFn {
+	return func(bz []byte) crypto.PrivKey {
+		return &ed25519.PrivKey{Key: stded25519.NewKeyFromSeed(bz)}
+	}
+}
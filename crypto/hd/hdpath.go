@@ -16,6 +16,9 @@ const (
 	BIP44Prefix = "44'/118'/"
 	PartialPath = "0'/0/0"
 	FullPath    = BIP44Prefix + PartialPath
+
+	// CoinType is the BIP44 coin type of the accounts, as registered by SLIP-0044 for the Cosmos Hub
+	CoinType uint32 = 118
 )
 
 // BIP44Params wraps BIP 44 params (5 level BIP 32 path).
@@ -261,6 +264,12 @@ func i64(key []byte, data []byte) (il [32]byte, ir [32]byte) {
 	return
 }
 
+// NewAccountParams returns the BIP 44 params of the address index of the account:
+// m / 44' / 118' / account' / 0 / address_index
+func NewAccountParams(account, addressIdx uint32) *BIP44Params {
+	return NewFundraiserParams(account, CoinType, addressIdx)
+}
+
 // CreateHDPath returns BIP 44 object from account and index parameters.
 func CreateHDPath(coinType, account, index uint32) *BIP44Params {
 	return NewFundraiserParams(account, coinType, index)
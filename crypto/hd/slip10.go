@@ -0,0 +1,39 @@
+package hd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ed25519FullPath is the default path of the ed25519 keys, SLIP-0010 only derives the hardened indexes of ed25519
+const Ed25519FullPath = BIP44Prefix + "0'/0'/0'"
+
+// ed25519Curve is the key of the HMAC of the SLIP-0010 master key of ed25519
+const ed25519Curve = "ed25519 seed"
+
+// DeriveEd25519KeyForPath derives the ed25519 private key seed by following the SLIP-0010 path from the BIP39 seed.
+// Every index of the path must be hardened, e.g. Ed25519FullPath.
+// See https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+func DeriveEd25519KeyForPath(seed []byte, path string) ([]byte, error) {
+	key, chainCode := i64([]byte(ed25519Curve), seed)
+	if len(path) == 0 {
+		return key[:], nil
+	}
+
+	for _, part := range strings.Split(path, "/") {
+		if !isHardened(part) {
+			return nil, fmt.Errorf("invalid SLIP-0010 path %s: the index %s of an ed25519 key must be hardened, e.g. %s",
+				path, part, Ed25519FullPath)
+		}
+		idx, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SLIP-0010 path %s: %s", path, err)
+		}
+
+		data := append([]byte{0}, key[:]...)
+		data = append(data, uint32ToBytes(uint32(idx)|0x80000000)...)
+		key, chainCode = i64(chainCode[:], data)
+	}
+	return key[:], nil
+}
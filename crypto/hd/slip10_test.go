@@ -0,0 +1,39 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+)
+
+// TestDeriveEd25519KeyForPath checks the test vector 1 for ed25519 of SLIP-0010
+func TestDeriveEd25519KeyForPath(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"":                        "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		"0'":                      "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		"0'/1'":                   "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		"0'/1'/2'":                "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+		"0'/1'/2'/2'":             "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+		"0'/1'/2'/2'/1000000000'": "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+	} {
+		key, err := DeriveEd25519KeyForPath(seed, path)
+		require.NoError(t, err, path)
+		require.Equal(t, expected, hex.EncodeToString(key), path)
+	}
+
+	key, err := DeriveEd25519KeyForPath(seed, "")
+	require.NoError(t, err)
+	pubKey := ed25519.NewKeyFromSeed(key).Public().(ed25519.PublicKey)
+	require.Equal(t, "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed", hex.EncodeToString(pubKey))
+
+	_, err = DeriveEd25519KeyForPath(seed, FullPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be hardened")
+	_, err = DeriveEd25519KeyForPath(seed, "2147483648'")
+	require.Error(t, err)
+}
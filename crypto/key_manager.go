@@ -38,15 +38,30 @@ func NewKeyManager() KeyManager {
 }
 
 func NewAlgoKeyManager(algo string) (KeyManager, error) {
-	entropy, err := bip39.NewEntropy(256)
+	mnemonic, err := NewMnemonic(24)
 	if err != nil {
 		return nil, err
 	}
-	mnemonic, err := bip39.NewMnemonic(entropy)
+	return NewMnemonicKeyManager(mnemonic, algo)
+}
+
+// NewMnemonic returns a new mnemonic of 12 or 24 words
+func NewMnemonic(words int) (string, error) {
+	var bitSize int
+	switch words {
+	case 12:
+		bitSize = 128
+	case 24:
+		bitSize = 256
+	default:
+		return "", fmt.Errorf("mnemonic length should either be 12 or 24")
+	}
+
+	entropy, err := bip39.NewEntropy(bitSize)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return NewMnemonicKeyManager(mnemonic, algo)
+	return bip39.NewMnemonic(entropy)
 }
 
 func NewMnemonicKeyManager(mnemonic string, algo string) (KeyManager, error) {
@@ -54,16 +69,26 @@ func NewMnemonicKeyManager(mnemonic string, algo string) (KeyManager, error) {
 		mnemonic: mnemonic,
 		algo:     algo,
 	}
-	err := k.recoveryFromMnemonic(mnemonic, hd.FullPath, algo)
+	hdPath := hd.FullPath
+	if algo == string(hd.Ed25519Type) {
+		hdPath = hd.Ed25519FullPath
+	}
+	err := k.recoveryFromMnemonic(mnemonic, defaultBIP39Passphrase, hdPath, algo)
 	return &k, err
 }
 
 func NewMnemonicKeyManagerWithHDPath(mnemonic, algo, hdPath string) (KeyManager, error) {
+	return NewMnemonicKeyManagerWithPassphrase(mnemonic, defaultBIP39Passphrase, algo, hdPath)
+}
+
+// NewMnemonicKeyManagerWithPassphrase returns the KeyManager of the key derived by the hd path
+// from the mnemonic and the BIP39 passphrase, a.k.a. the 25th word
+func NewMnemonicKeyManagerWithPassphrase(mnemonic, bip39Passphrase, algo, hdPath string) (KeyManager, error) {
 	k := keyManager{
 		mnemonic: mnemonic,
 		algo:     algo,
 	}
-	err := k.recoveryFromMnemonic(mnemonic, hdPath, algo)
+	err := k.recoveryFromMnemonic(mnemonic, bip39Passphrase, hdPath, algo)
 	return &k, err
 }
 
@@ -87,7 +112,7 @@ func (m *keyManager) Sign(data []byte) ([]byte, error) {
 	return m.privKey.Sign(data)
 }
 
-func (m *keyManager) recoveryFromMnemonic(mnemonic, hdPath, algoStr string) error {
+func (m *keyManager) recoveryFromMnemonic(mnemonic, bip39Passphrase, hdPath, algoStr string) error {
 	words := strings.Split(mnemonic, " ")
 	if len(words) != 12 && len(words) != 24 {
 		return fmt.Errorf("mnemonic length should either be 12 or 24")
@@ -99,7 +124,7 @@ func (m *keyManager) recoveryFromMnemonic(mnemonic, hdPath, algoStr string) erro
 	}
 
 	// create master key and derive first key for keyring
-	derivedPriv, err := algo.Derive()(mnemonic, defaultBIP39Passphrase, hdPath)
+	derivedPriv, err := algo.Derive()(mnemonic, bip39Passphrase, hdPath)
 	if err != nil {
 		return err
 	}
@@ -1,11 +1,13 @@
 package crypto_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 )
 
@@ -23,3 +25,78 @@ func TestNewMnemonicKeyManager(t *testing.T) {
 	address := sdk.AccAddress(pubKey.Address()).String()
 	assert.Equal(t, "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z", address)
 }
+
+func TestSm2KeyManager(t *testing.T) {
+	mnemonic := "nerve leader thank marriage spice task van start piece crowd run hospital control outside cousin romance left choice poet wagon rude climb leisure spring"
+
+	km, err := crypto.NewMnemonicKeyManager(mnemonic, "sm2")
+	assert.NoError(t, err)
+	// the same path derives the same key
+	km2, err := crypto.NewMnemonicKeyManager(mnemonic, "sm2")
+	assert.NoError(t, err)
+	assert.True(t, km.ExportPubKey().Equals(km2.ExportPubKey()))
+
+	pubKey := km.ExportPubKey()
+	assert.Equal(t, "sm2", pubKey.Type())
+	assert.NotEqual(t, "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z", sdk.AccAddress(pubKey.Address()).String())
+
+	msg := []byte("hello")
+	sig, err := km.Sign(msg)
+	assert.NoError(t, err)
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+
+	armor, err := km.ExportPrivKey("12345678")
+	assert.NoError(t, err)
+
+	privKey, algo, err := crypto.NewKeyManager().ImportPrivKey(armor, "12345678")
+	assert.NoError(t, err)
+	assert.Equal(t, "sm2", algo)
+	assert.True(t, pubKey.Equals(privKey.PubKey()))
+}
+
+func TestEd25519KeyManager(t *testing.T) {
+	mnemonic := "nerve leader thank marriage spice task van start piece crowd run hospital control outside cousin romance left choice poet wagon rude climb leisure spring"
+
+	// the default path of ed25519 is hardened
+	km, err := crypto.NewMnemonicKeyManager(mnemonic, "ed25519")
+	assert.NoError(t, err)
+	km2, err := crypto.NewMnemonicKeyManagerWithHDPath(mnemonic, "ed25519", hd.Ed25519FullPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "ed25519", km.ExportPubKey().Type())
+	assert.True(t, km.ExportPubKey().Equals(km2.ExportPubKey()))
+
+	_, err = crypto.NewAlgoKeyManager("ed25519")
+	assert.NoError(t, err)
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, words := range []int{12, 24} {
+		mnemonic, err := crypto.NewMnemonic(words)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Split(mnemonic, " "), words)
+	}
+
+	_, err := crypto.NewMnemonic(18)
+	assert.Error(t, err)
+}
+
+func TestMnemonicKeyManagerWithPassphrase(t *testing.T) {
+	mnemonic := "nerve leader thank marriage spice task van start piece crowd run hospital control outside cousin romance left choice poet wagon rude climb leisure spring"
+
+	// the empty passphrase derives the usual key
+	km, err := crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, "", "secp256k1", hd.NewAccountParams(0, 0).String())
+	assert.NoError(t, err)
+	assert.Equal(t, "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z", sdk.AccAddress(km.ExportPubKey().Address()).String())
+
+	km, err = crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, "passphrase", "secp256k1", hd.FullPath)
+	assert.NoError(t, err)
+	km2, err := crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, "passphrase", "secp256k1", hd.FullPath)
+	assert.NoError(t, err)
+	assert.True(t, km.ExportPubKey().Equals(km2.ExportPubKey()))
+	assert.NotEqual(t, "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z", sdk.AccAddress(km.ExportPubKey().Address()).String())
+
+	km2, err = crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, "passphrase", "secp256k1", hd.NewAccountParams(1, 2).String())
+	assert.NoError(t, err)
+	assert.False(t, km.ExportPubKey().Equals(km2.ExportPubKey()))
+	assert.Equal(t, "44'/118'/1'/0/2", hd.NewAccountParams(1, 2).String())
+}
@@ -0,0 +1,292 @@
+package sm2
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	gmsm "github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm3"
+
+	"github.com/irisnet/irishub-sdk-go/codec"
+	cryptotypes "github.com/irisnet/irishub-sdk-go/crypto/types"
+)
+
+var _ cryptotypes.PrivKey = &PrivKey{}
+var _ codec.AminoMarshaler = &PrivKey{}
+
+const (
+	PrivKeySize   = 32
+	SignatureSize = 64
+	keyType       = "sm2"
+	PrivKeyName   = "tendermint/PrivKeySm2"
+	PubKeyName    = "tendermint/PubKeySm2"
+)
+
+// DefaultUID is the user identity hashed in the signatures, as defined by GM/T 0009-2012
+var DefaultUID = []byte("1234567812345678")
+
+var one = big.NewInt(1)
+
+// Curve returns the sm2p256v1 curve of GB/T 32918.5, implemented by tjfoc/gmsm as the chain
+func Curve() elliptic.Curve {
+	return gmsm.P256Sm2()
+}
+
+// Bytes returns the byte representation of the Private Key.
+func (privKey *PrivKey) Bytes() []byte {
+	return privKey.Key
+}
+
+// PubKey performs the point-scalar multiplication from the privKey on the
+// generator point to get the pubkey.
+func (privKey *PrivKey) PubKey() crypto.PubKey {
+	x, y := Curve().ScalarBaseMult(privKey.Key)
+	return &PubKey{Key: compress(x, y)}
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the
+func (privKey *PrivKey) Equals(other crypto.PrivKey) bool {
+	return privKey.Type() == other.Type() && subtle.ConstantTimeCompare(privKey.Bytes(), other.Bytes()) == 1
+}
+
+func (privKey *PrivKey) Type() string {
+	return keyType
+}
+
+// Sign creates an SM2 signature of the msg with the DefaultUID, as defined by GB/T 32918.2-2016.
+// The returned signature is of the form R || S.
+func (privKey *PrivKey) Sign(msg []byte) ([]byte, error) {
+	return privKey.sign(crypto.CReader(), msg)
+}
+
+func (privKey *PrivKey) sign(rand io.Reader, msg []byte) ([]byte, error) {
+	d := new(big.Int).SetBytes(privKey.Key)
+	if !validScalar(d) {
+		return nil, errors.New("invalid sm2 private key")
+	}
+
+	curve := Curve()
+	x, y := curve.ScalarBaseMult(privKey.Key)
+	priv := &gmsm.PrivateKey{PublicKey: gmsm.PublicKey{Curve: curve, X: x, Y: y}, D: d}
+	r, s, err := gmsm.Sm2Sign(priv, msg, DefaultUID, rand)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, SignatureSize)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
+
+// MarshalAmino overrides Amino binary marshalling.
+func (privKey PrivKey) MarshalAmino() ([]byte, error) {
+	return privKey.Key, nil
+}
+
+// UnmarshalAmino overrides Amino binary marshalling.
+func (privKey *PrivKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PrivKeySize {
+		return fmt.Errorf("invalid privkey size")
+	}
+	privKey.Key = bz
+
+	return nil
+}
+
+// MarshalAminoJSON overrides Amino JSON marshalling.
+func (privKey PrivKey) MarshalAminoJSON() ([]byte, error) {
+	// When we marshal to Amino JSON, we don't marshal the "key" field itself,
+	// just its contents (i.e. the key bytes).
+	return privKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON overrides Amino JSON marshalling.
+func (privKey *PrivKey) UnmarshalAminoJSON(bz []byte) error {
+	return privKey.UnmarshalAmino(bz)
+}
+
+// GenPrivKey generates a new sm2 private key.
+// It uses OS randomness to generate the private key.
+func GenPrivKey() *PrivKey {
+	k, err := randScalar(crypto.CReader())
+	if err != nil {
+		panic(err)
+	}
+	return &PrivKey{Key: scalarBytes(k)}
+}
+
+// GenPrivKeyFromSecret hashes the secret with SM3, and uses that 32 byte output to create the private key.
+//
+// It makes sure the private key is a valid scalar by setting:
+//
+// c = sm3(secret)
+// k = (c mod (n − 2)) + 1, where n = curve order.
+func GenPrivKeyFromSecret(secret []byte) *PrivKey {
+	fe := new(big.Int).SetBytes(sm3.Sm3Sum(secret))
+	n := new(big.Int).Sub(Curve().Params().N, big.NewInt(2))
+	fe.Mod(fe, n)
+	fe.Add(fe, one)
+	return &PrivKey{Key: scalarBytes(fe)}
+}
+
+// PrivKeyFromBytes returns the private key of the 32 bytes, e.g. derived from a HD path.
+// It returns an error if the bytes are out of the valid range [1, n - 2].
+func PrivKeyFromBytes(bz []byte) (*PrivKey, error) {
+	if len(bz) != PrivKeySize {
+		return nil, fmt.Errorf("invalid privkey size")
+	}
+	if !validScalar(new(big.Int).SetBytes(bz)) {
+		return nil, errors.New("sm2 private key out of range")
+	}
+	return &PrivKey{Key: append([]byte{}, bz...)}, nil
+}
+
+//-------------------------------------
+
+var _ cryptotypes.PubKey = &PubKey{}
+var _ codec.AminoMarshaler = &PubKey{}
+
+// PubKeySize is comprised of 32 bytes for one field element
+// (the x-coordinate), plus one byte for the parity of the y-coordinate.
+const PubKeySize = 33
+
+// Address returns the first 20 bytes of SHA256(pubkey)
+func (pubKey *PubKey) Address() crypto.Address {
+	if len(pubKey.Key) != PubKeySize {
+		panic("length of pubkey is incorrect")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey.Key))
+}
+
+// Bytes returns the pubkey byte format.
+func (pubKey *PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+func (pubKey *PubKey) String() string {
+	return fmt.Sprintf("PubKeySm2{%X}", pubKey.Key)
+}
+
+func (pubKey *PubKey) Type() string {
+	return keyType
+}
+
+func (pubKey *PubKey) Equals(other crypto.PubKey) bool {
+	return pubKey.Type() == other.Type() && bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+// VerifySignature verifies a signature of the form R || S with the DefaultUID.
+func (pubKey *PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	x, y, err := decompress(pubKey.Key)
+	if err != nil {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	return gmsm.Sm2Verify(&gmsm.PublicKey{Curve: Curve(), X: x, Y: y}, msg, DefaultUID, r, s)
+}
+
+// MarshalAmino overrides Amino binary marshalling.
+func (pubKey PubKey) MarshalAmino() ([]byte, error) {
+	return pubKey.Key, nil
+}
+
+// UnmarshalAmino overrides Amino binary marshalling.
+func (pubKey *PubKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PubKeySize {
+		return fmt.Errorf("invalid pubkey size")
+	}
+	pubKey.Key = bz
+
+	return nil
+}
+
+// MarshalAminoJSON overrides Amino JSON marshalling.
+func (pubKey PubKey) MarshalAminoJSON() ([]byte, error) {
+	// When we marshal to Amino JSON, we don't marshal the "key" field itself,
+	// just its contents (i.e. the key bytes).
+	return pubKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON overrides Amino JSON marshalling.
+func (pubKey *PubKey) UnmarshalAminoJSON(bz []byte) error {
+	return pubKey.UnmarshalAmino(bz)
+}
+
+//-------------------------------------
+
+// randScalar returns a random scalar in [1, n - 2]
+func randScalar(rand io.Reader) (*big.Int, error) {
+	var bz [PrivKeySize]byte
+	for {
+		if _, err := io.ReadFull(rand, bz[:]); err != nil {
+			return nil, err
+		}
+		k := new(big.Int).SetBytes(bz[:])
+		if validScalar(k) {
+			return k, nil
+		}
+	}
+}
+
+// validScalar returns whether the scalar is a valid private key, in [1, n - 2]
+// as (1 + d) must be invertible
+func validScalar(d *big.Int) bool {
+	return d.Sign() > 0 && d.Cmp(new(big.Int).Sub(Curve().Params().N, one)) < 0
+}
+
+func scalarBytes(i *big.Int) []byte {
+	return i.FillBytes(make([]byte, 32))
+}
+
+// compress returns the compressed form of the point, 0x02 or 0x03 followed by the x-coordinate
+func compress(x, y *big.Int) []byte {
+	bz := make([]byte, PubKeySize)
+	bz[0] = 0x02 + byte(y.Bit(0))
+	x.FillBytes(bz[1:])
+	return bz
+}
+
+func decompress(bz []byte) (x, y *big.Int, err error) {
+	if len(bz) != PubKeySize || (bz[0] != 0x02 && bz[0] != 0x03) {
+		return nil, nil, errors.New("invalid sm2 public key")
+	}
+
+	params := Curve().Params()
+	p := params.P
+	x = new(big.Int).SetBytes(bz[1:])
+	if x.Cmp(p) >= 0 {
+		return nil, nil, errors.New("invalid sm2 public key")
+	}
+
+	// y² = x³ - 3x + b
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x)
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+	y2.Sub(y2, threeX)
+	y2.Add(y2, params.B)
+	y2.Mod(y2, p)
+
+	y = new(big.Int).ModSqrt(y2, p)
+	if y == nil {
+		return nil, nil, errors.New("invalid sm2 public key")
+	}
+	if y.Bit(0) != uint(bz[0]-0x02) {
+		y.Sub(p, y)
+	}
+	return x, y, nil
+}
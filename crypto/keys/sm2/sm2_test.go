@@ -0,0 +1,155 @@
+package sm2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	gmsm "github.com/tjfoc/gmsm/sm2"
+
+	"github.com/irisnet/irishub-sdk-go/codec"
+)
+
+// the example of the signature of GB/T 32918.5-2017 on the sm2p256v1 curve, with the default uid
+func TestSignKnownAnswer(t *testing.T) {
+	privKey := &PrivKey{Key: fromHex(t, "3945208f7b2144b13f36e38ac6d39f95889393692860b51a42fb81ef4df7c5b8")}
+	pubKey := privKey.PubKey().(*PubKey)
+	x, y, err := decompress(pubKey.Key)
+	require.NoError(t, err)
+	require.Equal(t, "09f9df311e5421a150dd7d161e4bc5c672179fad1833fc076bb08ff356f35020", hex.EncodeToString(scalarBytes(x)))
+	require.Equal(t, "ccea490ce26775a52dc6ea718cc1aa600aed05fbf35e084a6632f6072da9ad13", hex.EncodeToString(scalarBytes(y)))
+
+	// gmsm draws k as (b mod (n - 1)) + 1 from 40 random bytes b
+	k := new(big.Int).SetBytes(fromHex(t, "59276e27d506861a16680f3ad9c02dccef3cc1fa3cdbe4ce6d54b80deac1bc21"))
+	random := bytes.NewReader(k.Sub(k, big.NewInt(1)).FillBytes(make([]byte, 40)))
+
+	msg := []byte("message digest")
+	sig, err := privKey.sign(random, msg)
+	require.NoError(t, err)
+	require.Equal(t, "f5a03b0648d2c4630eeac513e1bb81a15944da3827d5b74143ac7eaceee720b3", hex.EncodeToString(sig[:32]))
+	require.Equal(t, "b1b6aa29df212fd8763182bc0d421ca1bb9038fd1f7f42d4840b69c485bbc1aa", hex.EncodeToString(sig[32:]))
+	require.True(t, pubKey.VerifySignature(msg, sig))
+}
+
+// the signatures are verified by the gmsm verifier of the chain, and the other way round
+func TestCrossVerify(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		privKey := GenPrivKey()
+		pubKey := privKey.PubKey().(*PubKey)
+		x, y, err := decompress(pubKey.Key)
+		require.NoError(t, err)
+		gmPriv := &gmsm.PrivateKey{PublicKey: gmsm.PublicKey{Curve: Curve(), X: x, Y: y}, D: new(big.Int).SetBytes(privKey.Key)}
+
+		msg := crypto.CRandBytes(64)
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		require.True(t, gmsm.Sm2Verify(&gmPriv.PublicKey, msg, DefaultUID, r, s))
+
+		r, s, err = gmsm.Sm2Sign(gmPriv, msg, DefaultUID, rand.Reader)
+		require.NoError(t, err)
+		sig = make([]byte, SignatureSize)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		require.True(t, pubKey.VerifySignature(msg, sig))
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+	require.True(t, pubKey.VerifySignature(msg, sig))
+
+	// mutate the signature
+	sig[7] ^= byte(0x01)
+	require.False(t, pubKey.VerifySignature(msg, sig))
+	sig[7] ^= byte(0x01)
+
+	// another message
+	msg[0] ^= byte(0x01)
+	require.False(t, pubKey.VerifySignature(msg, sig))
+
+	// another key
+	require.False(t, GenPrivKey().PubKey().VerifySignature(msg, sig))
+}
+
+func TestPubKeyCompression(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		privKey := GenPrivKey()
+		x, y := Curve().ScalarBaseMult(privKey.Key)
+
+		pubKey := privKey.PubKey().(*PubKey)
+		dx, dy, err := decompress(pubKey.Key)
+		require.NoError(t, err)
+		require.Equal(t, x, dx)
+		require.Equal(t, y, dy)
+		require.True(t, Curve().IsOnCurve(dx, dy))
+	}
+
+	_, _, err := decompress(bytes.Repeat([]byte{0x04}, PubKeySize))
+	require.Error(t, err)
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := GenPrivKeyFromSecret([]byte("secret"))
+	require.Equal(t, privKey, GenPrivKeyFromSecret([]byte("secret")))
+	require.NotEqual(t, privKey, GenPrivKeyFromSecret([]byte("another secret")))
+	require.Len(t, privKey.Key, PrivKeySize)
+
+	fromBytes, err := PrivKeyFromBytes(privKey.Key)
+	require.NoError(t, err)
+	require.True(t, privKey.Equals(fromBytes))
+
+	// the out of range bytes are rejected
+	n := Curve().Params().N
+	for _, bz := range [][]byte{
+		make([]byte, PrivKeySize),
+		scalarBytes(new(big.Int).Sub(n, big.NewInt(1))),
+		scalarBytes(n),
+		bytes.Repeat([]byte{0xff}, PrivKeySize),
+		{0x01},
+	} {
+		_, err := PrivKeyFromBytes(bz)
+		require.Error(t, err)
+	}
+	_, err = PrivKeyFromBytes(scalarBytes(new(big.Int).Sub(n, big.NewInt(2))))
+	require.NoError(t, err)
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return bz
+}
+
+func TestAminoMarshal(t *testing.T) {
+	cdc := codec.NewLegacyAmino()
+	cdc.RegisterConcrete(&PubKey{}, PubKeyName, nil)
+	cdc.RegisterConcrete(&PrivKey{}, PrivKeyName, nil)
+
+	privKey := GenPrivKey()
+	bz, err := cdc.MarshalBinaryBare(privKey)
+	require.NoError(t, err)
+	var decodedPriv PrivKey
+	require.NoError(t, cdc.UnmarshalBinaryBare(bz, &decodedPriv))
+	require.True(t, privKey.Equals(&decodedPriv))
+
+	pubKey := privKey.PubKey().(*PubKey)
+	bz, err = cdc.MarshalJSON(pubKey)
+	require.NoError(t, err)
+	var decodedPub PubKey
+	require.NoError(t, cdc.UnmarshalJSON(bz, &decodedPub))
+	require.True(t, pubKey.Equals(&decodedPub))
+
+	require.Error(t, decodedPub.UnmarshalAmino([]byte{0x02}))
+}
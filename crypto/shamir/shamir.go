@@ -0,0 +1,129 @@
+// Package shamir implements the Shamir's secret sharing over GF(256), splitting a secret into n shares
+// any k of which rebuild the secret, and the encoding of the shares as checksummed BIP39 words.
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// MaxShares is the maximum number of shares of a secret
+const MaxShares = 255
+
+var (
+	// ErrChecksum is returned when a share is mistyped or corrupted
+	ErrChecksum = errors.New("invalid share checksum")
+	// ErrMismatch is returned when the shares don't belong to the same secret
+	ErrMismatch = errors.New("shares of different secrets")
+	// ErrNotEnoughShares is returned when less shares than the threshold are given
+	ErrNotEnoughShares = errors.New("not enough shares")
+	// ErrInvalidShares is returned when the shares are well formed but don't rebuild the secret
+	ErrInvalidShares = errors.New("invalid shares")
+)
+
+// exp and log are the tables of the multiplication in GF(256) with the AES polynomial x^8 + x^4 + x^3 + x + 1
+var exp, log [256]byte
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		log[x] = byte(i)
+		// multiply by the generator x + 1
+		x ^= xtime(x)
+	}
+	exp[255] = exp[0]
+}
+
+func xtime(x byte) byte {
+	if x&0x80 != 0 {
+		return x<<1 ^ 0x1b
+	}
+	return x << 1
+}
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return exp[(int(log[a])+int(log[b]))%255]
+}
+
+func div(a, b byte) byte {
+	if b == 0 {
+		panic("shamir: division by zero")
+	}
+	if a == 0 {
+		return 0
+	}
+	return exp[(int(log[a])-int(log[b])+255)%255]
+}
+
+// Split splits the secret into n shares, any k of which rebuild the secret with Combine. The share i is
+// the value at x = i + 1 of a random polynomial of degree k - 1 for every byte of the secret.
+func Split(secret []byte, n, k int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+	if k < 2 || k > n || n > MaxShares {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares, must be 2 <= threshold <= shares <= %d", k, n, MaxShares)
+	}
+
+	coefficients := make([]byte, len(secret)*(k-1))
+	if _, err := rand.Read(coefficients); err != nil {
+		return nil, err
+	}
+
+	shares := make([][]byte, n)
+	for i := range shares {
+		x := byte(i + 1)
+		share := make([]byte, len(secret))
+		for j, s := range secret {
+			// Horner's method from the highest coefficient down to the secret
+			var y byte
+			for c := k - 2; c >= 0; c-- {
+				y = mul(y, x) ^ coefficients[j*(k-1)+c]
+			}
+			share[j] = mul(y, x) ^ s
+		}
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// Combine rebuilds the secret from the shares at the x coordinates xs, at least the threshold of the split
+func Combine(xs []byte, shares [][]byte) ([]byte, error) {
+	if len(xs) != len(shares) || len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	seen := make(map[byte]bool, len(xs))
+	for i, x := range xs {
+		if x == 0 || seen[x] {
+			return nil, fmt.Errorf("%w: duplicate or zero x coordinate %d", ErrMismatch, x)
+		}
+		seen[x] = true
+		if len(shares[i]) != len(shares[0]) {
+			return nil, fmt.Errorf("%w: different share lengths", ErrMismatch)
+		}
+	}
+	return interpolate(xs, shares, 0), nil
+}
+
+// interpolate returns the value at x of the polynomials through the points (xs[i], ys[i])
+func interpolate(xs []byte, ys [][]byte, x byte) []byte {
+	value := make([]byte, len(ys[0]))
+	for i, xi := range xs {
+		// the Lagrange basis polynomial of xi at x, the subtraction is the addition in GF(256)
+		basis := byte(1)
+		for j, xj := range xs {
+			if i != j {
+				basis = mul(basis, div(x^xj, xi^xj))
+			}
+		}
+		for b := range value {
+			value[b] ^= mul(basis, ys[i][b])
+		}
+	}
+	return value
+}
@@ -0,0 +1,113 @@
+package shamir_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/crypto/shamir"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("the secret of the officers")
+	shares, err := shamir.Split(secret, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	// every 3 shares rebuild the secret
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			for k := j + 1; k < 5; k++ {
+				combined, err := shamir.Combine(
+					[]byte{byte(i + 1), byte(j + 1), byte(k + 1)},
+					[][]byte{shares[i], shares[j], shares[k]},
+				)
+				require.NoError(t, err)
+				require.Equal(t, secret, combined)
+			}
+		}
+	}
+
+	// 2 shares reveal nothing
+	combined, err := shamir.Combine([]byte{1, 2}, shares[:2])
+	require.NoError(t, err)
+	require.NotEqual(t, secret, combined)
+
+	_, err = shamir.Split(secret, 3, 4)
+	require.Error(t, err)
+	_, err = shamir.Split(secret, 3, 1)
+	require.Error(t, err)
+}
+
+func TestShareWords(t *testing.T) {
+	entropy, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+	shares, err := shamir.SplitSecret(shamir.KindEntropy, entropy, 3, 2)
+	require.NoError(t, err)
+
+	parsed := make([]shamir.Share, len(shares))
+	for i, share := range shares {
+		parsed[i], err = shamir.ParseShare(share.String())
+		require.NoError(t, err)
+		require.Equal(t, share, parsed[i])
+	}
+
+	kind, secret, err := shamir.Recover([]shamir.Share{parsed[2], parsed[0]})
+	require.NoError(t, err)
+	require.Equal(t, shamir.KindEntropy, kind)
+	require.Equal(t, entropy, secret)
+
+	// the extra shares are verified too
+	_, secret, err = shamir.Recover(parsed)
+	require.NoError(t, err)
+	require.Equal(t, entropy, secret)
+
+	_, _, err = shamir.Recover(parsed[:1])
+	require.True(t, errors.Is(err, shamir.ErrNotEnoughShares), err)
+
+	// a mistyped word
+	words := strings.Fields(shares[0].String())
+	words[5] = bip39.WordList[(bip39.ReverseWordMap[words[5]]+1)%2048]
+	_, err = shamir.ParseShare(strings.Join(words, " "))
+	require.True(t, errors.Is(err, shamir.ErrChecksum), err)
+	_, err = shamir.ParseShare("abandon ability")
+	require.True(t, errors.Is(err, shamir.ErrChecksum), err)
+	_, err = shamir.ParseShare("abandon notaword")
+	require.Error(t, err)
+
+	// a share of another split
+	others, err := shamir.SplitSecret(shamir.KindEntropy, entropy, 3, 2)
+	require.NoError(t, err)
+	others[0].ID = shares[0].ID + 1
+	_, _, err = shamir.Recover([]shamir.Share{shares[0], others[0]})
+	require.True(t, errors.Is(err, shamir.ErrMismatch), err)
+
+	// a well formed share of a wrong value
+	wrong := parsed[1]
+	wrong.Value = append([]byte{wrong.Value[0] ^ 1}, wrong.Value[1:]...)
+	wrong, err = shamir.ParseShare(wrong.String())
+	require.NoError(t, err)
+	_, _, err = shamir.Recover([]shamir.Share{parsed[0], wrong})
+	require.True(t, errors.Is(err, shamir.ErrInvalidShares), err)
+	_, _, err = shamir.Recover([]shamir.Share{parsed[0], parsed[2], wrong})
+	require.True(t, errors.Is(err, shamir.ErrInvalidShares), err)
+}
+
+func TestEntropyFromMnemonic(t *testing.T) {
+	for _, bitSize := range []int{128, 160, 192, 224, 256} {
+		entropy, err := bip39.NewEntropy(bitSize)
+		require.NoError(t, err)
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		require.NoError(t, err)
+
+		recovered, err := shamir.EntropyFromMnemonic(mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, entropy, recovered)
+	}
+
+	_, err := shamir.EntropyFromMnemonic("abandon abandon abandon")
+	require.Error(t, err)
+}
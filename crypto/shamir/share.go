@@ -0,0 +1,222 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	bip39 "github.com/cosmos/go-bip39"
+)
+
+// Kind is the kind of the secret of the shares
+type Kind byte
+
+const (
+	// KindEntropy is the entropy of a BIP39 mnemonic
+	KindEntropy Kind = 1
+	// KindPrivKey is the amino encoded private key of a key without mnemonic
+	KindPrivKey Kind = 2
+)
+
+const (
+	version = 1
+	// headerLen is the version, the identifier, the kind, the threshold, the index and the value length
+	headerLen   = 7
+	checksumLen = 4
+	digestLen   = 4
+	wordBits    = 11
+)
+
+// Share is a share of a secret
+type Share struct {
+	// ID identifies the shares of the same split
+	ID        uint16
+	Kind      Kind
+	Threshold byte
+	// Index is the x coordinate of the share, from 1
+	Index byte
+	// Value is the share of the secret followed by its digest
+	Value []byte
+}
+
+// SplitSecret splits the secret into n shares with the threshold k. The digest of the secret is shared
+// with the secret, so that Recover detects the wrong shares without revealing anything about the secret.
+func SplitSecret(kind Kind, secret []byte, n, k int) ([]Share, error) {
+	if len(secret)+digestLen > 255 {
+		return nil, fmt.Errorf("secret of %d bytes too long", len(secret))
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+
+	values, err := Split(append(append([]byte{}, secret...), digest(secret)...), n, k)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]Share, n)
+	for i, value := range values {
+		shares[i] = Share{
+			ID:        binary.BigEndian.Uint16(id[:]),
+			Kind:      kind,
+			Threshold: byte(k),
+			Index:     byte(i + 1),
+			Value:     value,
+		}
+	}
+	return shares, nil
+}
+
+// Recover rebuilds the secret from at least the threshold of its shares. The shares beyond the threshold
+// must be consistent with the others.
+func Recover(shares []Share) (Kind, []byte, error) {
+	if len(shares) == 0 {
+		return 0, nil, ErrNotEnoughShares
+	}
+
+	first := shares[0]
+	for _, share := range shares[1:] {
+		if share.ID != first.ID || share.Kind != first.Kind || share.Threshold != first.Threshold ||
+			len(share.Value) != len(first.Value) {
+			return 0, nil, ErrMismatch
+		}
+	}
+	threshold := int(first.Threshold)
+	if len(shares) < threshold {
+		return 0, nil, fmt.Errorf("%w: %d of %d", ErrNotEnoughShares, len(shares), threshold)
+	}
+
+	xs, ys := make([]byte, len(shares)), make([][]byte, len(shares))
+	for i, share := range shares {
+		xs[i], ys[i] = share.Index, share.Value
+	}
+
+	value, err := Combine(xs[:threshold], ys[:threshold])
+	if err != nil {
+		return 0, nil, err
+	}
+	for i := threshold; i < len(shares); i++ {
+		if !bytes.Equal(interpolate(xs[:threshold], ys[:threshold], xs[i]), ys[i]) {
+			return 0, nil, fmt.Errorf("%w: share %d is inconsistent with the others", ErrInvalidShares, xs[i])
+		}
+	}
+
+	secret := value[:len(value)-digestLen]
+	if !bytes.Equal(digest(secret), value[len(value)-digestLen:]) {
+		return 0, nil, ErrInvalidShares
+	}
+	return first.Kind, secret, nil
+}
+
+// String encodes the share as the words of the BIP39 English word list, each word holding 11 bits of the share
+// followed by its checksum
+func (s Share) String() string {
+	bz := make([]byte, headerLen, headerLen+len(s.Value)+checksumLen)
+	bz[0] = version
+	binary.BigEndian.PutUint16(bz[1:3], s.ID)
+	bz[3], bz[4], bz[5], bz[6] = byte(s.Kind), s.Threshold, s.Index, byte(len(s.Value))
+	bz = append(bz, s.Value...)
+	bz = append(bz, checksum(bz)...)
+
+	words := make([]string, 0, (len(bz)*8+wordBits-1)/wordBits)
+	var acc, bits uint
+	for _, b := range bz {
+		acc, bits = acc<<8|uint(b), bits+8
+		for bits >= wordBits {
+			bits -= wordBits
+			words = append(words, bip39.WordList[acc>>bits&(1<<wordBits-1)])
+		}
+	}
+	if bits > 0 {
+		words = append(words, bip39.WordList[acc<<(wordBits-bits)&(1<<wordBits-1)])
+	}
+	return strings.Join(words, " ")
+}
+
+// ParseShare decodes the words of a share and verifies its checksum
+func ParseShare(words string) (Share, error) {
+	var (
+		bz        []byte
+		acc, bits uint
+		fields    = strings.Fields(words)
+	)
+	for _, word := range fields {
+		index, ok := bip39.ReverseWordMap[strings.ToLower(word)]
+		if !ok {
+			return Share{}, fmt.Errorf("invalid share word %q", word)
+		}
+		acc, bits = acc<<wordBits|uint(index), bits+wordBits
+		for bits >= 8 {
+			bits -= 8
+			bz = append(bz, byte(acc>>bits))
+		}
+	}
+
+	if len(bz) < headerLen+checksumLen {
+		return Share{}, fmt.Errorf("%w: share too short", ErrChecksum)
+	}
+	if bz[0] != version {
+		return Share{}, fmt.Errorf("unsupported share version %d", bz[0])
+	}
+	n := headerLen + int(bz[6]) + checksumLen
+	// the last word is padded with less than 8 zero bits, or a zero byte
+	if len(bz) < n || len(bz) > n+1 || (len(bz) == n+1 && bz[n] != 0) || acc&(1<<bits-1) != 0 {
+		return Share{}, fmt.Errorf("%w: invalid share length", ErrChecksum)
+	}
+	if !bytes.Equal(checksum(bz[:n-checksumLen]), bz[n-checksumLen:n]) {
+		return Share{}, ErrChecksum
+	}
+
+	share := Share{
+		ID:        binary.BigEndian.Uint16(bz[1:3]),
+		Kind:      Kind(bz[3]),
+		Threshold: bz[4],
+		Index:     bz[5],
+		Value:     bz[headerLen : n-checksumLen],
+	}
+	if share.Kind != KindEntropy && share.Kind != KindPrivKey {
+		return Share{}, fmt.Errorf("unsupported share kind %d", share.Kind)
+	}
+	if share.Threshold < 2 || share.Index == 0 {
+		return Share{}, fmt.Errorf("invalid share threshold %d or index %d", share.Threshold, share.Index)
+	}
+	return share, nil
+}
+
+// EntropyFromMnemonic returns the entropy of the BIP39 mnemonic
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+
+	var (
+		entropy   []byte
+		acc, bits uint
+	)
+	words := strings.Fields(mnemonic)
+	// the mnemonic holds the entropy followed by a checksum of 1 bit every 32 bits of entropy
+	entropyBits := len(words) * wordBits * 32 / 33
+	for _, word := range words {
+		acc, bits = acc<<wordBits|uint(bip39.ReverseWordMap[word]), bits+wordBits
+		for bits >= 8 && len(entropy) < entropyBits/8 {
+			bits -= 8
+			entropy = append(entropy, byte(acc>>bits))
+		}
+	}
+	return entropy, nil
+}
+
+func digest(secret []byte) []byte {
+	sum := sha256.Sum256(secret)
+	return sum[:digestLen]
+}
+
+func checksum(bz []byte) []byte {
+	sum := sha256.Sum256(bz)
+	return sum[:checksumLen]
+}
@@ -0,0 +1,354 @@
+package fakechain
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	cryptotypes "github.com/irisnet/irishub-sdk-go/crypto/types"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/coinswap"
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/tx/signing"
+)
+
+// error codes of the cosmos-sdk root codespace returned by the chain
+const (
+	codeTxDecode          uint32 = 2
+	codeUnauthorized      uint32 = 4
+	codeInsufficientFunds uint32 = 5
+	codeUnknownRequest    uint32 = 6
+	codeInvalidAddress    uint32 = 7
+	codeInvalidPubKey     uint32 = 8
+	codeUnknownAddress    uint32 = 9
+	codeOutOfGas          uint32 = 11
+	codeInvalidRequest    uint32 = 18
+	codeWrongSequence     uint32 = 32
+)
+
+var feeCollector = sdk.AccAddress(tmcrypto.AddressHash([]byte("fee_collector"))).String()
+
+// chainError is an abci error returned in CheckTx or DeliverTx
+type chainError struct {
+	codespace string
+	code      uint32
+	log       string
+}
+
+func newError(code uint32, format string, args ...interface{}) chainError {
+	return newModuleError(sdk.RootCodespace, code, format, args...)
+}
+
+func newModuleError(codespace string, code uint32, format string, args ...interface{}) chainError {
+	return chainError{
+		codespace: codespace,
+		code:      code,
+		log:       fmt.Sprintf(format, args...),
+	}
+}
+
+func (e chainError) Error() string {
+	return e.log
+}
+
+func toChainError(err error) chainError {
+	if e, ok := err.(chainError); ok {
+		return e
+	}
+	return newError(codeInvalidRequest, err.Error())
+}
+
+// sigTx is implemented by the transactions decoded by the TxConfig
+type sigTx interface {
+	sdk.Tx
+	GetSigners() []sdk.AccAddress
+	GetSignaturesV2() ([]signing.SignatureV2, error)
+	GetGas() uint64
+	GetFee() sdk.Coins
+	FeePayer() sdk.AccAddress
+}
+
+// execResult is the outcome of running a transaction against a copy of the state
+type execResult struct {
+	state   state
+	events  []abci.Event
+	gasUsed uint64
+	// log is the JSON of the events of every message, as the log of a successful DeliverTx
+	log string
+}
+
+// checkTx decodes the transaction, verifies the signatures and charges the fees
+func (c *Chain) checkTx(st state, txBytes []byte, simulate bool) (sigTx, execResult, error) {
+	decoded, err := c.encodingConfig.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, execResult{}, newError(codeTxDecode, "%s: tx parse error", err.Error())
+	}
+
+	tx, ok := decoded.(sigTx)
+	if !ok {
+		return nil, execResult{}, newError(codeTxDecode, "invalid transaction type %T", decoded)
+	}
+
+	if err := tx.ValidateBasic(); err != nil {
+		return nil, execResult{}, newError(codeInvalidRequest, err.Error())
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, execResult{}, newError(codeInvalidRequest, err.Error())
+		}
+	}
+
+	gasUsed := uint64(baseGas + gasPerMsg*len(tx.GetMsgs()) + gasPerByte*len(txBytes))
+	if !simulate && tx.GetGas() < gasUsed {
+		return nil, execResult{}, newError(codeOutOfGas, "out of gas in location: ReadFlat; gasWanted: %d, gasUsed: %d: out of gas", tx.GetGas(), gasUsed)
+	}
+
+	sigs, err := tx.GetSignaturesV2()
+	if err != nil {
+		return nil, execResult{}, newError(codeUnauthorized, err.Error())
+	}
+
+	signers := tx.GetSigners()
+	if len(sigs) != len(signers) {
+		return nil, execResult{}, newError(codeUnauthorized, "wrong number of signers; expected %d, got %d", len(signers), len(sigs))
+	}
+
+	next := st.clone()
+	em := sdk.NewEventManager()
+
+	fee := tx.GetFee()
+	if !fee.IsZero() {
+		payer := tx.FeePayer().String()
+		if _, ok := next.accounts[payer]; !ok {
+			return nil, execResult{}, newError(codeUnknownAddress, "fee payer address: %s does not exist: unknown address", payer)
+		}
+		if err := next.subCoins(payer, fee); err != nil {
+			return nil, execResult{}, newError(codeInsufficientFunds, "%s: insufficient funds to pay for fees", err.Error())
+		}
+		next.addCoins(feeCollector, fee)
+		em.EmitEvents(transferEvents(payer, feeCollector, fee))
+	}
+	em.EmitEvent(sdk.NewEvent("tx", sdk.NewAttribute("fee", fee.String())))
+
+	for i, signer := range signers {
+		address := signer.String()
+		acc, ok := next.accounts[address]
+		if !ok {
+			return nil, execResult{}, newError(codeUnknownAddress, "account %s does not exist: unknown address", address)
+		}
+
+		sig := sigs[i]
+		if sig.Sequence != acc.sequence {
+			return nil, execResult{}, newError(codeWrongSequence, "account sequence mismatch, expected %d, got %d: incorrect account sequence", acc.sequence, sig.Sequence)
+		}
+
+		if acc.pubKey == nil && sig.PubKey != nil {
+			pubKey, ok := sig.PubKey.(cryptotypes.PubKey)
+			if !ok || !signer.Equals(sdk.AccAddress(pubKey.Address())) {
+				return nil, execResult{}, newError(codeInvalidPubKey, "pubKey does not match signer address %s with signer index: %d: invalid pubkey", address, i)
+			}
+			acc.pubKey = pubKey
+		}
+
+		data, ok := sig.Data.(*signing.SingleSignatureData)
+		if !ok {
+			return nil, execResult{}, newError(codeUnauthorized, "only single signatures are supported")
+		}
+
+		if !simulate && c.verifySignatures {
+			if acc.pubKey == nil {
+				return nil, execResult{}, newError(codeInvalidPubKey, "pubkey on account is not set: invalid pubkey")
+			}
+
+			signerData := sdk.SignerData{
+				ChainID:       c.chainID,
+				AccountNumber: acc.number,
+				Sequence:      acc.sequence,
+			}
+			signBytes, err := c.signModes.GetSignBytes(data.SignMode, signerData, tx)
+			if err != nil {
+				return nil, execResult{}, newError(codeUnauthorized, err.Error())
+			}
+			if !acc.pubKey.VerifySignature(signBytes, data.Signature) {
+				return nil, execResult{}, newError(codeUnauthorized, "signature verification failed; please verify account number (%d) and chain-id (%s): unauthorized", acc.number, c.chainID)
+			}
+		}
+
+		em.EmitEvent(sdk.NewEvent("tx", sdk.NewAttribute("acc_seq", fmt.Sprintf("%s/%d", address, acc.sequence))))
+		em.EmitEvent(sdk.NewEvent("tx", sdk.NewAttribute("signature", base64.StdEncoding.EncodeToString(data.Signature))))
+
+		acc.sequence++
+		next.accounts[address] = acc
+	}
+
+	return tx, execResult{
+		state:   next,
+		events:  em.ABCIEvents(),
+		gasUsed: gasUsed,
+	}, nil
+}
+
+// deliverTx runs the messages of the transaction, the changes are discarded if any message fails
+func (c *Chain) deliverTx(st state, tx sigTx) (execResult, error) {
+	next := st.clone()
+	em := sdk.NewEventManager()
+
+	var logs sdk.ABCIMessageLogs
+	for i, msg := range tx.GetMsgs() {
+		events, err := c.handleMsg(&next, msg)
+		if err != nil {
+			e := toChainError(err)
+			e.log = fmt.Sprintf("failed to execute message; message index: %d: %s", i, e.log)
+			return execResult{}, e
+		}
+
+		events = append(sdk.Events{sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyAction, msg.Type()))}, events...)
+		em.EmitEvents(events)
+		logs = append(logs, sdk.NewABCIMessageLog(uint32(i), "", events))
+	}
+
+	return execResult{
+		state:  next,
+		events: em.ABCIEvents(),
+		log:    logs.String(),
+	}, nil
+}
+
+func (c *Chain) handleMsg(st *state, msg sdk.Msg) (sdk.Events, error) {
+	switch msg := msg.(type) {
+	case *bank.MsgSend:
+		return handleMsgSend(st, msg)
+	case *bank.MsgMultiSend:
+		return handleMsgMultiSend(st, msg)
+	case *token.MsgIssueToken:
+		return handleMsgIssueToken(st, msg)
+	case *token.MsgEditToken:
+		return handleMsgEditToken(st, msg)
+	case *token.MsgMintToken:
+		return handleMsgMintToken(st, msg)
+	case *token.MsgTransferTokenOwner:
+		return handleMsgTransferTokenOwner(st, msg)
+	case *coinswap.MsgAddLiquidity:
+		return handleMsgAddLiquidity(st, msg, c.blockTime())
+	case *coinswap.MsgRemoveLiquidity:
+		return handleMsgRemoveLiquidity(st, msg, c.blockTime())
+	case *coinswap.MsgSwapOrder:
+		return handleMsgSwapOrder(st, msg, c.blockTime())
+	default:
+		return nil, newError(codeUnknownRequest, "unrecognized %s message type: %T: unknown request", msg.Route(), msg)
+	}
+}
+
+// broadcast runs the transaction and commits it in a new block when it passes CheckTx
+func (c *Chain) broadcast(txBytes tmtypes.Tx) (abci.ResponseCheckTx, *txRecord) {
+	c.mu.Lock()
+
+	tx, checked, err := c.checkTx(c.state, txBytes, false)
+	if err != nil {
+		c.mu.Unlock()
+		e := toChainError(err)
+		return abci.ResponseCheckTx{Code: e.code, Codespace: e.codespace, Log: e.log}, nil
+	}
+	c.state = checked.state
+
+	result := abci.ResponseDeliverTx{
+		GasWanted: int64(tx.GetGas()),
+		GasUsed:   int64(checked.gasUsed),
+	}
+
+	delivered, err := c.deliverTx(c.state, tx)
+	if err != nil {
+		e := toChainError(err)
+		result.Code, result.Codespace, result.Log = e.code, e.codespace, e.log
+	} else {
+		c.state = delivered.state
+		result.Events = append(checked.events, delivered.events...)
+		result.Log = delivered.log
+	}
+
+	record := &txRecord{
+		hash:   txBytes.Hash(),
+		tx:     txBytes,
+		result: result,
+	}
+	b := c.commitBlock([]*txRecord{record})
+	record.events = txEvents(record)
+	c.mu.Unlock()
+
+	c.publish(b)
+	return abci.ResponseCheckTx{GasWanted: result.GasWanted}, record
+}
+
+// simulate runs the transaction against a copy of the state without verifying the signatures, the sequences
+// are checked as by the ante handler of the chain
+func (c *Chain) simulate(txBytes []byte) (sdk.SimulationResponse, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tx, checked, err := c.checkTx(c.state, txBytes, true)
+	if err != nil {
+		return sdk.SimulationResponse{}, err
+	}
+
+	delivered, err := c.deliverTx(checked.state, tx)
+	if err != nil {
+		return sdk.SimulationResponse{}, err
+	}
+
+	return sdk.SimulationResponse{
+		GasInfo: sdk.GasInfo{
+			GasWanted: tx.GetGas(),
+			GasUsed:   checked.gasUsed,
+		},
+		Result: &sdk.Result{
+			Events: append(checked.events, delivered.events...),
+		},
+	}, nil
+}
+
+func (c *Chain) blockTime() int64 {
+	return c.latestBlock().block.Time.Add(blockInterval).Unix()
+}
+
+// transferEvents returns the events emitted by the bank module for a transfer
+func transferEvents(sender, recipient string, amount sdk.Coins) sdk.Events {
+	return sdk.Events{
+		sdk.NewEvent("coin_spent",
+			sdk.NewAttribute("spender", sender),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+		),
+		sdk.NewEvent("coin_received",
+			sdk.NewAttribute("receiver", recipient),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+		),
+		sdk.NewEvent("transfer",
+			sdk.NewAttribute("recipient", recipient),
+			sdk.NewAttribute(sdk.AttributeKeySender, sender),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+		),
+		sdk.NewEvent(sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, sender),
+		),
+	}
+}
+
+// txEvents flattens the events of the transaction for query matching
+func txEvents(r *txRecord) map[string][]string {
+	events := map[string][]string{
+		"tm.event":  {tmtypes.EventTx},
+		"tx.hash":   {fmt.Sprintf("%X", r.hash)},
+		"tx.height": {fmt.Sprintf("%d", r.height)},
+	}
+	for _, event := range r.result.Events {
+		for _, attr := range event.Attributes {
+			key := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+			events[key] = append(events[key], string(attr.Value))
+		}
+	}
+	return events
+}
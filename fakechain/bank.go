@@ -0,0 +1,52 @@
+package fakechain
+
+import (
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+func handleMsgSend(st *state, msg *bank.MsgSend) (sdk.Events, error) {
+	if err := st.sendCoins(msg.FromAddress, msg.ToAddress, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	events := transferEvents(msg.FromAddress, msg.ToAddress, msg.Amount)
+	return append(events, moduleEvent(bank.ModuleName)), nil
+}
+
+func handleMsgMultiSend(st *state, msg *bank.MsgMultiSend) (sdk.Events, error) {
+	var events sdk.Events
+	for _, in := range msg.Inputs {
+		if err := st.subCoins(in.Address, in.Coins); err != nil {
+			return nil, err
+		}
+		events = append(events,
+			sdk.NewEvent("coin_spent",
+				sdk.NewAttribute("spender", in.Address),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, in.Coins.String()),
+			),
+			sdk.NewEvent(sdk.EventTypeMessage,
+				sdk.NewAttribute(sdk.AttributeKeySender, in.Address),
+			),
+		)
+	}
+
+	for _, out := range msg.Outputs {
+		st.addCoins(out.Address, out.Coins)
+		events = append(events,
+			sdk.NewEvent("coin_received",
+				sdk.NewAttribute("receiver", out.Address),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, out.Coins.String()),
+			),
+			sdk.NewEvent("transfer",
+				sdk.NewAttribute("recipient", out.Address),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, out.Coins.String()),
+			),
+		)
+	}
+	return append(events, moduleEvent(bank.ModuleName)), nil
+}
+
+func moduleEvent(module string) sdk.Event {
+	return sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, module))
+}
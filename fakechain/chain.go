@@ -0,0 +1,402 @@
+// Package fakechain is an in-memory IRIS Hub node for unit testing code built on the SDK.
+//
+// The chain keeps accounts, balances, tokens and liquidity pools in memory, executes
+// the transactions of the bank, token and coinswap modules, and serves the tendermint
+// rpc and module grpc queries used by the module clients:
+//
+//	chain := fakechain.New("test")
+//	defer chain.Close()
+//
+//	cfg, _ := chain.Config()
+//	client := sdk.NewIRISHUBClient(cfg)
+//	address, _, _ := client.Key.Add("alice", "password")
+//	chain.Fund(address, types.NewInt64Coin("uiris", 100000000))
+//
+// Every accepted transaction is committed immediately in its own block, whatever the
+// broadcast mode is, and the subscribers are notified before the broadcast returns.
+// The package fakechaintest sets up a chain with a client and funded keys for the tests.
+package fakechain
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/irisnet/irishub-sdk-go/codec"
+	cdctypes "github.com/irisnet/irishub-sdk-go/codec/types"
+	cryptocodec "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	cryptotypes "github.com/irisnet/irishub-sdk-go/crypto/types"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/coinswap"
+	"github.com/irisnet/irishub-sdk-go/modules/gov"
+	"github.com/irisnet/irishub-sdk-go/modules/htlc"
+	"github.com/irisnet/irishub-sdk-go/modules/nft"
+	"github.com/irisnet/irishub-sdk-go/modules/oracle"
+	"github.com/irisnet/irishub-sdk-go/modules/random"
+	"github.com/irisnet/irishub-sdk-go/modules/record"
+	"github.com/irisnet/irishub-sdk-go/modules/service"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+	txtypes "github.com/irisnet/irishub-sdk-go/types/tx"
+)
+
+const (
+	// DefaultPoolFee is the fee rate of the liquidity pools
+	DefaultPoolFee = "0.003"
+
+	blockInterval = 5 * time.Second
+	baseGas       = 50000
+	gasPerMsg     = 20000
+	// gasPerByte is charged for the size of the tx, as TxSizeCostPerByte of the auth module
+	gasPerByte = 10
+)
+
+// NativeToken is the staking token of the chain, it is registered when the chain is created
+var NativeToken = token.Token{
+	Symbol:        "iris",
+	Name:          "IRIS Network",
+	Scale:         6,
+	MinUnit:       sdk.BaseDenom,
+	InitialSupply: 2000000000,
+	MaxSupply:     10000000000,
+	Mintable:      true,
+}
+
+// Chain is an in-memory IRIS Hub node, it is safe for concurrent use
+type Chain struct {
+	chainID        string
+	encodingConfig sdk.EncodingConfig
+	signModes      sdk.SignModeHandler
+	logger         log.Logger
+
+	mu          sync.RWMutex
+	state       state
+	blocks      []*block
+	txs         map[string]*txRecord
+	genesisTime time.Time
+
+	subs             *subscriptions
+	grpc             *grpcServer
+	verifySignatures bool
+}
+
+type account struct {
+	number   uint64
+	sequence uint64
+	pubKey   cryptotypes.PubKey
+}
+
+type state struct {
+	accounts      map[string]account
+	balances      map[string]sdk.Coins
+	tokens        map[string]token.Token
+	pools         map[string]pool
+	nextAccNumber uint64
+}
+
+type block struct {
+	id      tmtypes.BlockID
+	block   *tmtypes.Block
+	results []*txRecord
+}
+
+type txRecord struct {
+	hash   []byte
+	height int64
+	index  uint32
+	tx     tmtypes.Tx
+	result abci.ResponseDeliverTx
+	events map[string][]string
+}
+
+// New creates a chain with the native token registered and no accounts
+func New(chainID string) *Chain {
+	chain := &Chain{
+		chainID:        chainID,
+		encodingConfig: makeEncodingConfig(),
+		signModes:      txtypes.MakeSignModeHandler(txtypes.DefaultSignModes),
+		logger:         log.NewNopLogger(),
+		state: state{
+			accounts: make(map[string]account),
+			balances: make(map[string]sdk.Coins),
+			tokens:   make(map[string]token.Token),
+			pools:    make(map[string]pool),
+		},
+		txs:              make(map[string]*txRecord),
+		genesisTime:      time.Now().UTC().Truncate(time.Second),
+		subs:             newSubscriptions(),
+		verifySignatures: true,
+	}
+	chain.state.tokens[NativeToken.Symbol] = NativeToken
+	chain.commitBlock(nil)
+	chain.grpc = newGRPCServer(chain)
+	return chain
+}
+
+// Config returns a client config connected to the chain, with an in-memory KeyDAO
+func (c *Chain) Config(options ...sdk.Option) (sdk.ClientConfig, error) {
+	options = append([]sdk.Option{
+		sdk.KeyDAOOption(store.NewMemory(nil)),
+		sdk.TmClientOption(c.TmClient()),
+		sdk.GRPCClientOption(c.GRPCClient()),
+		sdk.ModeOption(sdk.Commit),
+	}, options...)
+	return sdk.NewClientConfig("", "", c.chainID, options...)
+}
+
+// TmClient returns the tendermint rpc client of the chain
+func (c *Chain) TmClient() sdk.TmClient {
+	return tmClient{c}
+}
+
+// GRPCClient returns the grpc client of the chain
+func (c *Chain) GRPCClient() sdk.GRPCClient {
+	return c.grpc
+}
+
+// ChainID returns the chain id
+func (c *Chain) ChainID() string {
+	return c.chainID
+}
+
+// Height returns the height of the latest block
+func (c *Chain) Height() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.height()
+}
+
+// SkipSignatureVerification accepts transactions without verifying the signatures,
+// for the tests which sign with keys the chain can't check
+func (c *Chain) SkipSignatureVerification() *Chain {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.verifySignatures = false
+	return c
+}
+
+// Fund mints the coins to the address, the account is created if it doesn't exist
+func (c *Chain) Fund(address string, coins ...sdk.Coin) error {
+	if _, err := sdk.AccAddressFromBech32(address); err != nil {
+		return err
+	}
+
+	amount := sdk.NewCoins(coins...)
+	if !amount.IsValid() {
+		return sdk.Wrapf("invalid coins: %s", amount)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.addCoins(address, amount)
+	return nil
+}
+
+// AddToken registers the token, the initial supply is minted to the owner when set
+func (c *Chain) AddToken(t token.Token) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.state.token(t.Symbol); ok {
+		return sdk.Wrapf("token %s already exists", t.Symbol)
+	}
+	c.state.tokens[t.Symbol] = t
+
+	if len(t.Owner) > 0 && t.InitialSupply > 0 {
+		supply := sdk.NewIntWithDecimal(int64(t.InitialSupply), int(t.Scale))
+		c.state.addCoins(t.Owner, sdk.NewCoins(sdk.NewCoin(t.MinUnit, supply)))
+	}
+	return nil
+}
+
+// Balances returns the balances of the address
+func (c *Chain) Balances(address string) sdk.Coins {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state.balances[address]
+}
+
+// Sequence returns the account number and the sequence of the address
+func (c *Chain) Sequence(address string) (accountNumber, sequence uint64, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	acc, ok := c.state.accounts[address]
+	if !ok {
+		return 0, 0, sdk.Wrapf("account %s not found", address)
+	}
+	return acc.number, acc.sequence, nil
+}
+
+// Close releases the resources of the chain
+func (c *Chain) Close() {
+	c.grpc.stop()
+}
+
+func (c *Chain) height() int64 {
+	return int64(len(c.blocks))
+}
+
+func (c *Chain) latestBlock() *block {
+	return c.blocks[len(c.blocks)-1]
+}
+
+func (c *Chain) blockAt(height *int64) (*block, error) {
+	if height == nil || *height == 0 {
+		return c.latestBlock(), nil
+	}
+	if *height < 1 || *height > c.height() {
+		return nil, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", *height, c.height())
+	}
+	return c.blocks[*height-1], nil
+}
+
+// commitBlock creates a block containing the txs, caller must hold the lock
+func (c *Chain) commitBlock(records []*txRecord) *block {
+	height := c.height() + 1
+
+	var txs tmtypes.Txs
+	for i, r := range records {
+		r.height = height
+		r.index = uint32(i)
+		txs = append(txs, r.tx)
+	}
+
+	b := &block{
+		block: &tmtypes.Block{
+			Header: tmtypes.Header{
+				ChainID: c.chainID,
+				Height:  height,
+				Time:    c.genesisTime.Add(time.Duration(height-1) * blockInterval),
+			},
+			Data:       tmtypes.Data{Txs: txs},
+			LastCommit: &tmtypes.Commit{Height: height - 1},
+		},
+		results: records,
+	}
+	if height > 1 {
+		b.block.LastBlockID = c.latestBlock().id
+		b.block.LastCommit.BlockID = c.latestBlock().id
+	}
+	b.id = tmtypes.BlockID{Hash: b.block.Hash()}
+
+	c.blocks = append(c.blocks, b)
+	for _, r := range records {
+		c.txs[string(r.hash)] = r
+	}
+	return b
+}
+
+func (s state) token(denom string) (token.Token, bool) {
+	if t, ok := s.tokens[denom]; ok {
+		return t, true
+	}
+	for _, t := range s.tokens {
+		if t.MinUnit == denom {
+			return t, true
+		}
+	}
+	return token.Token{}, false
+}
+
+// ensureAccount creates the account if it doesn't exist, like the bank module does for recipients
+func (s *state) ensureAccount(address string) account {
+	acc, ok := s.accounts[address]
+	if !ok {
+		acc = account{number: s.nextAccNumber}
+		s.accounts[address] = acc
+		s.nextAccNumber++
+	}
+	return acc
+}
+
+func (s *state) addCoins(address string, coins sdk.Coins) {
+	s.ensureAccount(address)
+	s.balances[address] = s.balances[address].Add(coins...)
+}
+
+func (s *state) subCoins(address string, coins sdk.Coins) error {
+	balance, negative := s.balances[address].SafeSub(coins)
+	if negative {
+		return newError(codeInsufficientFunds, "%s is smaller than %s: insufficient funds", s.balances[address], coins)
+	}
+	s.balances[address] = balance
+	return nil
+}
+
+func (s *state) sendCoins(from, to string, coins sdk.Coins) error {
+	if err := s.subCoins(from, coins); err != nil {
+		return err
+	}
+	s.addCoins(to, coins)
+	return nil
+}
+
+func (s state) supply() sdk.Coins {
+	supply := sdk.NewCoins()
+	for _, balance := range s.balances {
+		supply = supply.Add(balance...)
+	}
+	return supply
+}
+
+// clone returns a copy of the state, used to discard the changes of a failed transaction
+func (s state) clone() state {
+	cp := state{
+		accounts:      make(map[string]account, len(s.accounts)),
+		balances:      make(map[string]sdk.Coins, len(s.balances)),
+		tokens:        make(map[string]token.Token, len(s.tokens)),
+		pools:         make(map[string]pool, len(s.pools)),
+		nextAccNumber: s.nextAccNumber,
+	}
+	for k, v := range s.accounts {
+		cp.accounts[k] = v
+	}
+	for k, v := range s.balances {
+		cp.balances[k] = v
+	}
+	for k, v := range s.tokens {
+		cp.tokens[k] = v
+	}
+	for k, v := range s.pools {
+		cp.pools[k] = v
+	}
+	return cp
+}
+
+func makeEncodingConfig() sdk.EncodingConfig {
+	amino := codec.NewLegacyAmino()
+	interfaceRegistry := cdctypes.NewInterfaceRegistry()
+	marshaler := codec.NewProtoCodec(interfaceRegistry)
+	txCfg := txtypes.NewTxConfig(marshaler, txtypes.DefaultSignModes)
+
+	interfaceRegistry.RegisterInterface("cosmos.v1beta1.Msg", (*sdk.Msg)(nil))
+	txtypes.RegisterInterfaces(interfaceRegistry)
+	cryptocodec.RegisterInterfaces(interfaceRegistry)
+	cryptocodec.RegisterCrypto(amino)
+
+	bank.RegisterInterfaces(interfaceRegistry)
+	token.RegisterInterfaces(interfaceRegistry)
+	coinswap.RegisterInterfaces(interfaceRegistry)
+	staking.RegisterInterfaces(interfaceRegistry)
+	gov.RegisterInterfaces(interfaceRegistry)
+	service.RegisterInterfaces(interfaceRegistry)
+	record.RegisterInterfaces(interfaceRegistry)
+	nft.RegisterInterfaces(interfaceRegistry)
+	random.RegisterInterfaces(interfaceRegistry)
+	oracle.RegisterInterfaces(interfaceRegistry)
+	htlc.RegisterInterfaces(interfaceRegistry)
+
+	return sdk.EncodingConfig{
+		InterfaceRegistry: interfaceRegistry,
+		Marshaler:         marshaler,
+		TxConfig:          txCfg,
+		Amino:             amino,
+	}
+}
@@ -0,0 +1,257 @@
+package fakechain
+
+import (
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/modules/coinswap"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// error codes of the coinswap codespace
+const (
+	codeReservePoolNotExists uint32 = 2
+	codeInsufficientReserve  uint32 = 3
+	codeInvalidDeadline      uint32 = 5
+	codeConstraintNotMet     uint32 = 6
+	codeInvalidDenom         uint32 = 9
+)
+
+var coinswapModule = sdk.AccAddress(tmcrypto.AddressHash([]byte(coinswap.ModuleName))).String()
+
+// pool is a liquidity pool of the standard denom and a token,
+// the reserves are the balances of the escrow address
+type pool struct {
+	id       string
+	token    string
+	lptDenom string
+	escrow   string
+}
+
+func newPool(tokenDenom string) (pool, error) {
+	lptDenom, err := coinswap.GetLiquidityDenomFrom(tokenDenom)
+	if err != nil {
+		return pool{}, newModuleError(coinswap.ModuleName, codeInvalidDenom, err.Error())
+	}
+	return pool{
+		id:       "pool-" + tokenDenom,
+		token:    tokenDenom,
+		lptDenom: lptDenom,
+		escrow:   sdk.AccAddress(tmcrypto.AddressHash([]byte(lptDenom))).String(),
+	}, nil
+}
+
+// poolInfo returns the pool as it is returned by the grpc queries
+func (s state) poolInfo(p pool) coinswap.PoolInfo {
+	reserves := s.balances[p.escrow]
+	return coinswap.PoolInfo{
+		Id:            p.id,
+		EscrowAddress: p.escrow,
+		Standard:      sdk.NewCoin(sdk.BaseDenom, reserves.AmountOf(sdk.BaseDenom)),
+		Token:         sdk.NewCoin(p.token, reserves.AmountOf(p.token)),
+		Lpt:           sdk.NewCoin(p.lptDenom, s.supply().AmountOf(p.lptDenom)),
+		Fee:           DefaultPoolFee,
+	}
+}
+
+// findPool returns the pool of the token denom or the liquidity denom
+func (s state) findPool(denom string) (pool, error) {
+	if p, ok := s.pools[denom]; ok {
+		return p, nil
+	}
+	for _, p := range s.pools {
+		if p.lptDenom == denom {
+			return p, nil
+		}
+	}
+	return pool{}, newModuleError(coinswap.ModuleName, codeReservePoolNotExists, "liquidity pool for %s not found", denom)
+}
+
+func handleMsgAddLiquidity(st *state, msg *coinswap.MsgAddLiquidity, blockTime int64) (sdk.Events, error) {
+	if msg.Deadline < blockTime {
+		return nil, newModuleError(coinswap.ModuleName, codeInvalidDeadline, "deadline has passed for MsgAddLiquidity")
+	}
+
+	p, err := st.findPool(msg.MaxToken.Denom)
+	if err != nil {
+		if p, err = newPool(msg.MaxToken.Denom); err != nil {
+			return nil, err
+		}
+		st.pools[p.token] = p
+	}
+
+	info := st.poolInfo(p)
+	standardAmt := sdk.NewIntFromBigInt(msg.ExactStandardAmt.BigInt())
+	minLiquidity := sdk.NewIntFromBigInt(msg.MinLiquidity.BigInt())
+
+	depositToken, mintLiquidity := msg.MaxToken.Amount, standardAmt
+	if info.Lpt.Amount.IsPositive() {
+		if info.Standard.Amount.IsZero() {
+			return nil, newModuleError(coinswap.ModuleName, codeInsufficientReserve, "liquidity pool %s has no standard reserve", p.id)
+		}
+		depositToken = standardAmt.Mul(info.Token.Amount).Quo(info.Standard.Amount).AddRaw(1)
+		mintLiquidity = info.Lpt.Amount.Mul(standardAmt).Quo(info.Standard.Amount)
+		if depositToken.GT(msg.MaxToken.Amount) {
+			return nil, newModuleError(coinswap.ModuleName, codeConstraintNotMet, "token amount not met, user expected: no more than %s, actual: %s", msg.MaxToken.Amount, depositToken)
+		}
+		if mintLiquidity.LT(minLiquidity) {
+			return nil, newModuleError(coinswap.ModuleName, codeConstraintNotMet, "liquidity amount not met, user expected: no less than %s, actual: %s", minLiquidity, mintLiquidity)
+		}
+	}
+
+	deposit := sdk.NewCoins(
+		sdk.NewCoin(sdk.BaseDenom, standardAmt),
+		sdk.NewCoin(p.token, depositToken),
+	)
+	if err := st.sendCoins(msg.Sender, p.escrow, deposit); err != nil {
+		return nil, err
+	}
+
+	minted := sdk.NewCoins(sdk.NewCoin(p.lptDenom, mintLiquidity))
+	st.addCoins(msg.Sender, minted)
+
+	events := transferEvents(msg.Sender, p.escrow, deposit)
+	events = append(events, transferEvents(coinswapModule, msg.Sender, minted)...)
+	return append(events, moduleEvent(coinswap.ModuleName)), nil
+}
+
+func handleMsgRemoveLiquidity(st *state, msg *coinswap.MsgRemoveLiquidity, blockTime int64) (sdk.Events, error) {
+	if msg.Deadline < blockTime {
+		return nil, newModuleError(coinswap.ModuleName, codeInvalidDeadline, "deadline has passed for MsgRemoveLiquidity")
+	}
+
+	p, err := st.findPool(msg.WithdrawLiquidity.Denom)
+	if err != nil {
+		return nil, err
+	}
+
+	info := st.poolInfo(p)
+	withdraw := msg.WithdrawLiquidity.Amount
+	if withdraw.GT(info.Lpt.Amount) {
+		return nil, newModuleError(coinswap.ModuleName, codeInsufficientReserve, "insufficient %s funds, user expected: %s, actual: %s", p.lptDenom, withdraw, info.Lpt.Amount)
+	}
+
+	standardAmt := info.Standard.Amount.Mul(withdraw).Quo(info.Lpt.Amount)
+	tokenAmt := info.Token.Amount.Mul(withdraw).Quo(info.Lpt.Amount)
+	if minStandard := sdk.NewIntFromBigInt(msg.MinStandardAmt.BigInt()); standardAmt.LT(minStandard) {
+		return nil, newModuleError(coinswap.ModuleName, codeConstraintNotMet, "iris amount not met, user expected: no less than %s, actual: %s", minStandard, standardAmt)
+	}
+	if minToken := sdk.NewIntFromBigInt(msg.MinToken.BigInt()); tokenAmt.LT(minToken) {
+		return nil, newModuleError(coinswap.ModuleName, codeConstraintNotMet, "token amount not met, user expected: no less than %s, actual: %s", minToken, tokenAmt)
+	}
+
+	burned := sdk.NewCoins(msg.WithdrawLiquidity)
+	if err := st.subCoins(msg.Sender, burned); err != nil {
+		return nil, err
+	}
+
+	withdrawn := sdk.NewCoins(
+		sdk.NewCoin(sdk.BaseDenom, standardAmt),
+		sdk.NewCoin(p.token, tokenAmt),
+	)
+	if err := st.sendCoins(p.escrow, msg.Sender, withdrawn); err != nil {
+		return nil, err
+	}
+
+	events := transferEvents(msg.Sender, coinswapModule, burned)
+	events = append(events, sdk.NewEvent("burn",
+		sdk.NewAttribute("burner", coinswapModule),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, burned.String()),
+	))
+	events = append(events, transferEvents(p.escrow, msg.Sender, withdrawn)...)
+	return append(events, moduleEvent(coinswap.ModuleName)), nil
+}
+
+func handleMsgSwapOrder(st *state, msg *coinswap.MsgSwapOrder, blockTime int64) (sdk.Events, error) {
+	if msg.Deadline < blockTime {
+		return nil, newModuleError(coinswap.ModuleName, codeInvalidDeadline, "deadline has passed for MsgSwapOrder")
+	}
+
+	// the route of the swap, a token to token swap goes through the standard denom
+	route := []string{msg.Input.Coin.Denom, msg.Output.Coin.Denom}
+	if msg.Input.Coin.Denom != sdk.BaseDenom && msg.Output.Coin.Denom != sdk.BaseDenom {
+		route = []string{msg.Input.Coin.Denom, sdk.BaseDenom, msg.Output.Coin.Denom}
+	}
+
+	pools := make([]pool, len(route)-1)
+	for i := range pools {
+		denom := route[i]
+		if denom == sdk.BaseDenom {
+			denom = route[i+1]
+		}
+		p, err := st.findPool(denom)
+		if err != nil {
+			return nil, err
+		}
+		pools[i] = p
+	}
+
+	fee := sdk.MustNewDecFromStr(DefaultPoolFee)
+	amounts := make([]sdk.Int, len(route))
+	if msg.IsBuyOrder {
+		amounts[len(route)-1] = msg.Output.Coin.Amount
+		for i := len(pools) - 1; i >= 0; i-- {
+			reserves := st.balances[pools[i].escrow]
+			inputReserve, outputReserve := reserves.AmountOf(route[i]), reserves.AmountOf(route[i+1])
+			if amounts[i+1].GTE(outputReserve) {
+				return nil, newModuleError(coinswap.ModuleName, codeInsufficientReserve, "insufficient funds in pool %s, actual: %s, expected: %s", pools[i].id, outputReserve, amounts[i+1])
+			}
+			amounts[i] = outputPrice(amounts[i+1], inputReserve, outputReserve, fee)
+		}
+		if amounts[0].GT(msg.Input.Coin.Amount) {
+			return nil, newModuleError(coinswap.ModuleName, codeConstraintNotMet, "insufficient amount of %s, user expected: %s, actual: %s", route[0], msg.Input.Coin.Amount, amounts[0])
+		}
+	} else {
+		amounts[0] = msg.Input.Coin.Amount
+		for i, p := range pools {
+			reserves := st.balances[p.escrow]
+			amounts[i+1] = inputPrice(amounts[i], reserves.AmountOf(route[i]), reserves.AmountOf(route[i+1]), fee)
+		}
+		if last := amounts[len(route)-1]; last.LT(msg.Output.Coin.Amount) {
+			return nil, newModuleError(coinswap.ModuleName, codeConstraintNotMet, "insufficient amount of %s, user expected: %s, actual: %s", route[len(route)-1], msg.Output.Coin.Amount, last)
+		}
+	}
+
+	var events sdk.Events
+	sender := msg.Input.Address
+	for i, p := range pools {
+		in := sdk.NewCoins(sdk.NewCoin(route[i], amounts[i]))
+		if err := st.sendCoins(sender, p.escrow, in); err != nil {
+			return nil, err
+		}
+		events = append(events, transferEvents(sender, p.escrow, in)...)
+		sender = p.escrow
+	}
+
+	out := sdk.NewCoins(sdk.NewCoin(route[len(route)-1], amounts[len(route)-1]))
+	if err := st.sendCoins(sender, msg.Output.Address, out); err != nil {
+		return nil, err
+	}
+	events = append(events, transferEvents(sender, msg.Output.Address, out)...)
+
+	// the amount of the swap event is the calculated side of the order
+	calculated := amounts[len(route)-1]
+	if msg.IsBuyOrder {
+		calculated = amounts[0]
+	}
+	events = append(events, sdk.NewEvent("swap", sdk.NewAttribute(sdk.AttributeKeyAmount, calculated.String())))
+	return append(events, moduleEvent(coinswap.ModuleName)), nil
+}
+
+// inputPrice returns the amount of coins bought given the exact amount being sold,
+// the same calculation as the coinswap module
+func inputPrice(inputAmt, inputReserve, outputReserve sdk.Int, fee sdk.Dec) sdk.Int {
+	deltaFee := sdk.OneDec().Sub(fee)
+	inputAmtWithFee := inputAmt.Mul(sdk.NewIntFromBigInt(deltaFee.BigInt()))
+	numerator := inputAmtWithFee.Mul(outputReserve)
+	denominator := inputReserve.Mul(sdk.NewIntWithDecimal(1, sdk.Precision)).Add(inputAmtWithFee)
+	return numerator.Quo(denominator)
+}
+
+// outputPrice returns the amount of coins sold given the exact amount being bought,
+// the same calculation as the coinswap module
+func outputPrice(outputAmt, inputReserve, outputReserve sdk.Int, fee sdk.Dec) sdk.Int {
+	deltaFee := sdk.OneDec().Sub(fee)
+	numerator := inputReserve.Mul(outputAmt).Mul(sdk.NewIntWithDecimal(1, sdk.Precision))
+	denominator := (outputReserve.Sub(outputAmt)).Mul(sdk.NewIntFromBigInt(deltaFee.BigInt()))
+	return numerator.Quo(denominator).Add(sdk.OneInt())
+}
@@ -0,0 +1,144 @@
+package fakechain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/coinswap"
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestSend(t *testing.T) {
+	chain, client, alice, bob := fakechaintest.Setup(t)
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+
+	var received []types.EventDataTx
+	builder := types.NewEventQueryBuilder().AddCondition(types.NewCond(types.EventTypeMessage, types.AttributeKeyAction).EQ("send"))
+	_, err = client.SubscribeTx(builder, func(tx types.EventDataTx) {
+		received = append(received, tx)
+	})
+	require.NoError(t, err)
+	res, err := client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Hash)
+	require.Equal(t, chain.Height(), res.Height)
+
+	require.Equal(t, "10000000", chain.Balances(bob).AmountOf(types.BaseDenom).String())
+	_, sequence, err := chain.Sequence(alice)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence)
+
+	require.Len(t, received, 1)
+	require.Equal(t, res.Hash, received[0].Hash)
+
+	tx, err := client.QueryTx(res.Hash)
+	require.NoError(t, err)
+	require.Equal(t, res.Height, tx.Height)
+
+	page, size := 1, 10
+	txs, err := client.QueryTxs(types.NewEventQueryBuilder().
+		AddCondition(types.NewCond(types.EventTypeMessage, types.AttributeKeySender).EQ(types.EventValue(alice))), &page, &size)
+	require.NoError(t, err)
+	require.Equal(t, 1, txs.Total)
+
+	// the events without client module are queried as well
+	txs, err = client.QueryTxs(types.NewEventQueryBuilder().
+		AddCondition(types.NewCond("delegate", "validator").EQ(types.EventValue(alice))), &page, &size)
+	require.NoError(t, err)
+	require.Equal(t, 0, txs.Total)
+
+	account, err := client.QueryAccount(alice)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), account.Sequence)
+	require.NotEmpty(t, account.PubKey)
+}
+
+func TestSendFails(t *testing.T) {
+	chain, client, alice, bob := fakechaintest.Setup(t)
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+
+	_, err = client.Bank.Send(alice, amount, fakechaintest.BaseTx("bob"))
+	require.Error(t, err)
+
+	require.NoError(t, chain.Fund(bob, types.NewInt64Coin(types.BaseDenom, 100000000)))
+	accountNumber, _, err := chain.Sequence(bob)
+	require.NoError(t, err)
+	require.NotZero(t, accountNumber)
+
+	tx := fakechaintest.BaseTx("bob")
+	tx.AccountNumber, tx.Sequence = accountNumber, 5
+	_, err = client.Bank.Send(alice, amount, tx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "account sequence mismatch")
+
+	require.Equal(t, "100000000", chain.Balances(bob).AmountOf(types.BaseDenom).String())
+}
+
+func TestToken(t *testing.T) {
+	_, client, alice, _ := fakechaintest.Setup(t)
+
+	var err error
+	_, err = client.Token.IssueToken(token.IssueTokenRequest{
+		Symbol:        "btc",
+		Name:          "Bitcoin",
+		Scale:         8,
+		MinUnit:       "satoshi",
+		InitialSupply: 21000,
+		MaxSupply:     21000000,
+		Mintable:      true,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+
+	btc, err := client.Token.QueryToken("btc")
+	require.NoError(t, err)
+	require.Equal(t, "satoshi", btc.MinUnit)
+	require.Equal(t, alice, btc.Owner)
+}
+
+func TestCoinswap(t *testing.T) {
+	chain, client, alice, _ := fakechaintest.Setup(t)
+
+	require.NoError(t, chain.AddToken(token.Token{
+		Symbol:        "btc",
+		Name:          "Bitcoin",
+		Scale:         8,
+		MinUnit:       "satoshi",
+		InitialSupply: 21000,
+		MaxSupply:     21000000,
+		Mintable:      true,
+		Owner:         alice,
+	}))
+
+	deadline := time.Now().Add(time.Hour).Unix()
+	added, err := client.Swap.AddLiquidity(coinswap.AddLiquidityRequest{
+		MaxToken:     types.NewInt64Coin("satoshi", 1000000000),
+		BaseAmt:      types.NewInt(1000000000),
+		MinLiquidity: types.NewInt(1000000000),
+		Deadline:     deadline,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	require.Equal(t, "1000000000", added.Liquidity.String())
+
+	pool, err := client.Swap.QueryPool("satoshi")
+	require.NoError(t, err)
+	require.Equal(t, "1000000000", pool.Pool.Standard.Amount.String())
+
+	estimated, err := client.Swap.EstimateTokenForSoldBase("satoshi", types.NewInt(1000000))
+	require.NoError(t, err)
+
+	swapped, err := client.Swap.SwapCoin(coinswap.SwapCoinRequest{
+		Input:    types.NewInt64Coin(types.BaseDenom, 1000000),
+		Output:   types.NewCoin("satoshi", estimated),
+		Deadline: deadline,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	require.Equal(t, estimated.String(), swapped.OutputAmt.String())
+}
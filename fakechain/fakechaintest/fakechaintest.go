@@ -0,0 +1,44 @@
+// Package fakechaintest is the fixture of the tests running the SDK clients against a fakechain.Chain:
+//
+//	chain, client, alice, bob := fakechaintest.Setup(t)
+//	_, err := client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+package fakechaintest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/fakechain"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Password is the password of the keys added by Setup
+const Password = "12345678"
+
+// Setup starts a chain closed with the test and returns a client connected to it, configured with the options,
+// with the keys alice, funded with 1000000iris, and bob
+func Setup(t *testing.T, options ...types.Option) (chain *fakechain.Chain, client sdk.IRISHUBClient, alice, bob string) {
+	t.Helper()
+
+	chain = fakechain.New("fakechain-test")
+	t.Cleanup(chain.Close)
+
+	cfg, err := chain.Config(options...)
+	require.NoError(t, err)
+	client = sdk.NewIRISHUBClient(cfg)
+
+	alice, _, err = client.Key.Add("alice", Password)
+	require.NoError(t, err)
+	bob, _, err = client.Key.Add("bob", Password)
+	require.NoError(t, err)
+
+	require.NoError(t, chain.Fund(alice, types.NewInt64Coin(types.BaseDenom, 1000000000000)))
+	return chain, client, alice, bob
+}
+
+// BaseTx returns the BaseTx of the key added by Setup
+func BaseTx(from string) types.BaseTx {
+	return types.BaseTx{From: from, Password: Password, Gas: 200000}
+}
@@ -0,0 +1,298 @@
+package fakechain
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	cdctypes "github.com/irisnet/irishub-sdk-go/codec/types"
+	"github.com/irisnet/irishub-sdk-go/modules/auth"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/coinswap"
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
+)
+
+const bufSize = 1024 * 1024
+
+// grpcServer serves the module queries of the chain over an in-memory listener
+type grpcServer struct {
+	lis    *bufconn.Listener
+	server *grpc.Server
+}
+
+var _ sdk.GRPCClient = (*grpcServer)(nil)
+
+func newGRPCServer(c *Chain) *grpcServer {
+	s := &grpcServer{
+		lis:    bufconn.Listen(bufSize),
+		server: grpc.NewServer(),
+	}
+
+	auth.RegisterQueryServer(s.server, &authServer{c: c})
+	bank.RegisterQueryServer(s.server, &bankServer{c: c})
+	token.RegisterQueryServer(s.server, &tokenServer{c: c})
+	coinswap.RegisterQueryServer(s.server, &coinswapServer{c: c})
+
+	go func() { _ = s.server.Serve(s.lis) }()
+	return s
+}
+
+// GenConn implements sdk.GRPCClient
+func (s *grpcServer) GenConn() (*grpc.ClientConn, error) {
+	return grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return s.lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+}
+
+func (s *grpcServer) stop() {
+	s.server.Stop()
+}
+
+type authServer struct {
+	auth.UnimplementedQueryServer
+	c *Chain
+}
+
+func (s authServer) Account(_ context.Context, req *auth.QueryAccountRequest) (*auth.QueryAccountResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	acc, ok := s.c.state.accounts[req.Address]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "account %s not found", req.Address)
+	}
+
+	account := &auth.BaseAccount{
+		Address:       req.Address,
+		AccountNumber: acc.number,
+		Sequence:      acc.sequence,
+	}
+	if acc.pubKey != nil {
+		pubKey, err := cdctypes.NewAnyWithValue(acc.pubKey)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		account.PubKey = pubKey
+	}
+
+	any, err := cdctypes.NewAnyWithValue(account)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &auth.QueryAccountResponse{Account: any}, nil
+}
+
+type bankServer struct {
+	bank.UnimplementedQueryServer
+	c *Chain
+}
+
+func (s bankServer) Balance(_ context.Context, req *bank.QueryBalanceRequest) (*bank.QueryBalanceResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	balance := sdk.NewCoin(req.Denom, s.c.state.balances[req.Address].AmountOf(req.Denom))
+	return &bank.QueryBalanceResponse{Balance: &balance}, nil
+}
+
+func (s bankServer) AllBalances(_ context.Context, req *bank.QueryAllBalancesRequest) (*bank.QueryAllBalancesResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	balances := s.c.state.balances[req.Address]
+	return &bank.QueryAllBalancesResponse{
+		Balances:   balances,
+		Pagination: &query.PageResponse{Total: uint64(len(balances))},
+	}, nil
+}
+
+func (s bankServer) TotalSupply(context.Context, *bank.QueryTotalSupplyRequest) (*bank.QueryTotalSupplyResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	return &bank.QueryTotalSupplyResponse{Supply: s.c.state.supply()}, nil
+}
+
+func (s bankServer) SupplyOf(_ context.Context, req *bank.QuerySupplyOfRequest) (*bank.QuerySupplyOfResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	return &bank.QuerySupplyOfResponse{
+		Amount: sdk.NewCoin(req.Denom, s.c.state.supply().AmountOf(req.Denom)),
+	}, nil
+}
+
+// Params returns the default params, every denom is sendable
+func (s bankServer) Params(context.Context, *bank.QueryParamsRequest) (*bank.QueryParamsResponse, error) {
+	return &bank.QueryParamsResponse{Params: bank.Params{DefaultSendEnabled: true}}, nil
+}
+
+// DenomMetadata returns the metadata of a token by its min unit
+func (s bankServer) DenomMetadata(_ context.Context, req *bank.QueryDenomMetadataRequest) (*bank.QueryDenomMetadataResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	for _, t := range s.c.state.tokens {
+		if t.MinUnit == req.Denom {
+			return &bank.QueryDenomMetadataResponse{Metadata: denomMetadata(t)}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "client metadata for denom %s", req.Denom)
+}
+
+// DenomsMetadata returns the metadata of the tokens, sorted by min unit
+func (s bankServer) DenomsMetadata(_ context.Context, req *bank.QueryDenomsMetadataRequest) (*bank.QueryDenomsMetadataResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	metadatas := make([]bank.Metadata, 0, len(s.c.state.tokens))
+	for _, t := range s.c.state.tokens {
+		metadatas = append(metadatas, denomMetadata(t))
+	}
+	sort.Slice(metadatas, func(i, j int) bool { return metadatas[i].Base < metadatas[j].Base })
+
+	offset, limit := uint64(0), uint64(len(metadatas))
+	if req.Pagination != nil {
+		offset = req.Pagination.Offset
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	var page []bank.Metadata
+	for i := offset; i < offset+limit && i < uint64(len(metadatas)); i++ {
+		page = append(page, metadatas[i])
+	}
+	return &bank.QueryDenomsMetadataResponse{
+		Metadatas:  page,
+		Pagination: &query.PageResponse{Total: uint64(len(metadatas))},
+	}, nil
+}
+
+// denomMetadata returns the bank metadata of the token, the min unit is the base denom
+func denomMetadata(t token.Token) bank.Metadata {
+	return bank.Metadata{
+		Description: t.Name,
+		DenomUnits: []*bank.DenomUnit{
+			{Denom: t.MinUnit, Exponent: 0},
+			{Denom: t.Symbol, Exponent: t.Scale},
+		},
+		Base:    t.MinUnit,
+		Display: t.Symbol,
+		Name:    t.Name,
+		Symbol:  strings.ToUpper(t.Symbol),
+	}
+}
+
+type tokenServer struct {
+	token.UnimplementedQueryServer
+	c *Chain
+}
+
+func (s tokenServer) Token(_ context.Context, req *token.QueryTokenRequest) (*token.QueryTokenResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	t, ok := s.c.state.token(req.Denom)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "token: %s does not exist", req.Denom)
+	}
+
+	any, err := cdctypes.NewAnyWithValue(&t)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &token.QueryTokenResponse{Token: any}, nil
+}
+
+func (s tokenServer) Tokens(_ context.Context, req *token.QueryTokensRequest) (*token.QueryTokensResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	symbols := make([]string, 0, len(s.c.state.tokens))
+	for symbol, t := range s.c.state.tokens {
+		if len(req.Owner) == 0 || t.Owner == req.Owner {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	tokens := make([]*cdctypes.Any, len(symbols))
+	for i, symbol := range symbols {
+		t := s.c.state.tokens[symbol]
+		any, err := cdctypes.NewAnyWithValue(&t)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		tokens[i] = any
+	}
+	return &token.QueryTokensResponse{Tokens: tokens}, nil
+}
+
+// Fees returns zero fees, the fake chain doesn't charge the token fees
+func (s tokenServer) Fees(_ context.Context, req *token.QueryFeesRequest) (*token.QueryFeesResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	_, exist := s.c.state.tokens[strings.ToLower(req.Symbol)]
+	return &token.QueryFeesResponse{
+		Exist:    exist,
+		IssueFee: sdk.NewCoin(sdk.BaseDenom, sdk.ZeroInt()),
+		MintFee:  sdk.NewCoin(sdk.BaseDenom, sdk.ZeroInt()),
+	}, nil
+}
+
+type coinswapServer struct {
+	coinswap.UnimplementedQueryServer
+	c *Chain
+}
+
+func (s coinswapServer) LiquidityPool(_ context.Context, req *coinswap.QueryLiquidityPoolRequest) (*coinswap.QueryLiquidityPoolResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	p, err := s.c.state.findPool(req.LptDenom)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &coinswap.QueryLiquidityPoolResponse{Pool: s.c.state.poolInfo(p)}, nil
+}
+
+func (s coinswapServer) LiquidityPools(_ context.Context, req *coinswap.QueryLiquidityPoolsRequest) (*coinswap.QueryLiquidityPoolsResponse, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	denoms := make([]string, 0, len(s.c.state.pools))
+	for denom := range s.c.state.pools {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	offset, limit := uint64(0), uint64(len(denoms))
+	if req.Pagination != nil {
+		offset = req.Pagination.Offset
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	var pools []coinswap.PoolInfo
+	for i := offset; i < offset+limit && i < uint64(len(denoms)); i++ {
+		pools = append(pools, s.c.state.poolInfo(s.c.state.pools[denoms[i]]))
+	}
+	return &coinswap.QueryLiquidityPoolsResponse{
+		Pools:      pools,
+		Pagination: &query.PageResponse{Total: uint64(len(denoms))},
+	}, nil
+}
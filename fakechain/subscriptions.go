@@ -0,0 +1,106 @@
+package fakechain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+type subscription struct {
+	query   *tmquery.Query
+	handler sdk.EventHandler
+}
+
+// subscriptions dispatches the events of the committed blocks to the subscribers
+type subscriptions struct {
+	mu      sync.RWMutex
+	subs    map[string]subscription
+	counter uint64
+}
+
+func newSubscriptions() *subscriptions {
+	return &subscriptions{subs: make(map[string]subscription)}
+}
+
+func (s *subscriptions) subscribe(query string, handler sdk.EventHandler) (sdk.Subscription, sdk.Error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return sdk.Subscription{}, sdk.Wrap(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counter++
+	id := fmt.Sprintf("fakechain-subscriber-%d", s.counter)
+	s.subs[id] = subscription{query: q, handler: handler}
+	return sdk.Subscription{
+		Ctx:   context.Background(),
+		Query: query,
+		ID:    id,
+	}, nil
+}
+
+func (s *subscriptions) unsubscribe(subscription sdk.Subscription) sdk.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[subscription.ID]; !ok {
+		return sdk.Wrapf("subscription not found: %s", subscription.ID)
+	}
+	delete(s.subs, subscription.ID)
+	return nil
+}
+
+// dispatch calls synchronously the handlers whose query matches the events
+func (s *subscriptions) dispatch(events map[string][]string, data sdk.EventData) {
+	s.mu.RLock()
+	var handlers []sdk.EventHandler
+	for _, sub := range s.subs {
+		if ok, err := sub.query.Matches(events); err == nil && ok {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(data)
+	}
+}
+
+// publish notifies the subscribers of the block, its header and its txs, it must be called without holding the chain lock
+func (c *Chain) publish(b *block) {
+	c.subs.dispatch(
+		map[string][]string{tmtypes.EventTypeKey: {tmtypes.EventNewBlock}},
+		sdk.EventDataNewBlock{Block: sdk.ParseBlock(c.encodingConfig.Amino, b.block)},
+	)
+	c.subs.dispatch(
+		map[string][]string{tmtypes.EventTypeKey: {tmtypes.EventNewBlockHeader}},
+		sdk.EventDataNewBlockHeader{Header: b.block.Header},
+	)
+
+	for _, r := range b.results {
+		tx, err := c.encodingConfig.TxConfig.TxDecoder()(r.tx)
+		if err != nil {
+			continue
+		}
+		c.subs.dispatch(r.events, sdk.EventDataTx{
+			Hash:   sdk.HexBytes(r.hash).String(),
+			Height: r.height,
+			Index:  r.index,
+			Tx:     tx,
+			Result: sdk.TxResult{
+				Code:      r.result.Code,
+				Log:       r.result.Log,
+				GasWanted: r.result.GasWanted,
+				GasUsed:   r.result.GasUsed,
+				Events:    sdk.StringifyEvents(r.result.Events),
+			},
+		})
+	}
+}
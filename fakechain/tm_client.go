@@ -0,0 +1,380 @@
+package fakechain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/p2p"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/irisnet/irishub-sdk-go/codec"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	simulatePath   = "/app/simulate"
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
+var errNotSupported = errors.New("not supported by the fake chain")
+
+// tmClient implements sdk.TmClient on top of the chain
+type tmClient struct {
+	c *Chain
+}
+
+var _ sdk.TmClient = tmClient{}
+
+// =============================================================================
+// ABCIClient
+
+func (t tmClient) ABCIInfo(context.Context) (*ctypes.ResultABCIInfo, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	latest := t.c.latestBlock()
+	return &ctypes.ResultABCIInfo{
+		Response: abci.ResponseInfo{
+			Data:             "fakechain",
+			LastBlockHeight:  latest.block.Height,
+			LastBlockAppHash: latest.block.AppHash,
+		},
+	}, nil
+}
+
+func (t tmClient) ABCIQuery(ctx context.Context, path string, data tmbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return t.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+func (t tmClient) ABCIQueryWithOptions(_ context.Context, path string, data tmbytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if path != simulatePath {
+		return &ctypes.ResultABCIQuery{
+			Response: abci.ResponseQuery{
+				Code:      codeUnknownRequest,
+				Codespace: sdk.RootCodespace,
+				Log:       fmt.Sprintf("unknown query path %s: unknown request", path),
+			},
+		}, nil
+	}
+
+	res, err := t.c.simulate(data)
+	if err != nil {
+		e := toChainError(err)
+		return &ctypes.ResultABCIQuery{
+			Response: abci.ResponseQuery{Code: e.code, Codespace: e.codespace, Log: e.log},
+		}, nil
+	}
+
+	bz, err := codec.ProtoMarshalJSON(&res)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultABCIQuery{
+		Response: abci.ResponseQuery{Value: bz, Height: t.c.Height()},
+	}, nil
+}
+
+func (t tmClient) BroadcastTxCommit(_ context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	check, record := t.c.broadcast(tx)
+	if record == nil {
+		return &ctypes.ResultBroadcastTxCommit{CheckTx: check, Hash: tx.Hash()}, nil
+	}
+	return &ctypes.ResultBroadcastTxCommit{
+		CheckTx:   check,
+		DeliverTx: record.result,
+		Hash:      record.hash,
+		Height:    record.height,
+	}, nil
+}
+
+func (t tmClient) BroadcastTxAsync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return t.BroadcastTxSync(ctx, tx)
+}
+
+func (t tmClient) BroadcastTxSync(_ context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	check, _ := t.c.broadcast(tx)
+	return &ctypes.ResultBroadcastTx{
+		Code:      check.Code,
+		Log:       check.Log,
+		Codespace: check.Codespace,
+		Hash:      tx.Hash(),
+	}, nil
+}
+
+// =============================================================================
+// SignClient
+
+func (t tmClient) Block(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	b, err := t.c.blockAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlock{BlockID: b.id, Block: b.block}, nil
+}
+
+func (t tmClient) BlockByHash(_ context.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	for _, b := range t.c.blocks {
+		if b.id.Hash.String() == tmbytes.HexBytes(hash).String() {
+			return &ctypes.ResultBlock{BlockID: b.id, Block: b.block}, nil
+		}
+	}
+	return nil, fmt.Errorf("block (%X) not found", hash)
+}
+
+func (t tmClient) BlockResults(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	b, err := t.c.blockAt(height)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*abci.ResponseDeliverTx, len(b.results))
+	for i, r := range b.results {
+		result := r.result
+		results[i] = &result
+	}
+	return &ctypes.ResultBlockResults{
+		Height:     b.block.Height,
+		TxsResults: results,
+	}, nil
+}
+
+func (t tmClient) Commit(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	b, err := t.c.blockAt(height)
+	if err != nil {
+		return nil, err
+	}
+
+	header := b.block.Header
+	commit := &tmtypes.Commit{Height: header.Height, BlockID: b.id}
+	return ctypes.NewResultCommit(&header, commit, true), nil
+}
+
+func (t tmClient) Validators(_ context.Context, height *int64, _, _ *int) (*ctypes.ResultValidators, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	b, err := t.c.blockAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultValidators{BlockHeight: b.block.Height}, nil
+}
+
+func (t tmClient) Tx(_ context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	r, ok := t.c.txs[string(hash)]
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	return r.resultTx(), nil
+}
+
+func (t tmClient) TxSearch(_ context.Context, query string, _ bool, page, perPage *int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	var matched []*txRecord
+	for _, b := range t.c.blocks {
+		for _, r := range b.results {
+			ok, err := q.Matches(r.events)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = append(matched, r)
+			}
+		}
+	}
+
+	switch orderBy {
+	case "desc":
+		sort.SliceStable(matched, func(i, j int) bool { return matched[i].height > matched[j].height })
+	case "asc", "":
+	default:
+		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	}
+
+	skip, limit, err := paginate(len(matched), page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ctypes.ResultTxSearch{TotalCount: len(matched)}
+	for i := skip; i < skip+limit && i < len(matched); i++ {
+		res.Txs = append(res.Txs, matched[i].resultTx())
+	}
+	return res, nil
+}
+
+func (t tmClient) BlockSearch(context.Context, string, *int, *int, string) (*ctypes.ResultBlockSearch, error) {
+	return nil, errNotSupported
+}
+
+// =============================================================================
+// StatusClient
+
+func (t tmClient) Status(context.Context) (*ctypes.ResultStatus, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	earliest, latest := t.c.blocks[0], t.c.latestBlock()
+	return &ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{
+			Network: t.c.chainID,
+			Moniker: "fakechain",
+		},
+		SyncInfo: ctypes.SyncInfo{
+			LatestBlockHash:     latest.id.Hash,
+			LatestBlockHeight:   latest.block.Height,
+			LatestBlockTime:     latest.block.Time,
+			EarliestBlockHash:   earliest.id.Hash,
+			EarliestBlockHeight: earliest.block.Height,
+			EarliestBlockTime:   earliest.block.Time,
+		},
+	}, nil
+}
+
+// =============================================================================
+// NetworkClient
+
+func (t tmClient) NetInfo(context.Context) (*ctypes.ResultNetInfo, error) {
+	return &ctypes.ResultNetInfo{Listening: true}, nil
+}
+
+func (t tmClient) DumpConsensusState(context.Context) (*ctypes.ResultDumpConsensusState, error) {
+	return nil, errNotSupported
+}
+
+func (t tmClient) ConsensusState(context.Context) (*ctypes.ResultConsensusState, error) {
+	return nil, errNotSupported
+}
+
+func (t tmClient) ConsensusParams(_ context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+	t.c.mu.RLock()
+	defer t.c.mu.RUnlock()
+
+	b, err := t.c.blockAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultConsensusParams{
+		BlockHeight:     b.block.Height,
+		ConsensusParams: *tmtypes.DefaultConsensusParams(),
+	}, nil
+}
+
+func (t tmClient) Health(context.Context) (*ctypes.ResultHealth, error) {
+	return &ctypes.ResultHealth{}, nil
+}
+
+// =============================================================================
+// WSClient
+
+func (t tmClient) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(tmtypes.EventNewBlock))
+	if err := builder.Validate(); err != nil {
+		return sdk.Subscription{}, sdk.Wrap(err)
+	}
+
+	return t.c.subs.subscribe(builder.Build(), func(data sdk.EventData) {
+		handler(data.(sdk.EventDataNewBlock))
+	})
+}
+
+func (t tmClient) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue))
+	if err := builder.Validate(); err != nil {
+		return sdk.Subscription{}, sdk.Wrap(err)
+	}
+
+	return t.c.subs.subscribe(builder.Build(), func(data sdk.EventData) {
+		handler(data.(sdk.EventDataTx))
+	})
+}
+
+func (t tmClient) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	query := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	return t.c.subs.subscribe(query, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataNewBlockHeader))
+	})
+}
+
+// SubscribeValidatorSetUpdates never notifies, the validator set of the chain is empty
+func (t tmClient) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	query := tmtypes.QueryForEvent(tmtypes.EventValidatorSetUpdates).String()
+	return t.c.subs.subscribe(query, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataValidatorSetUpdates))
+	})
+}
+
+func (t tmClient) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	return t.c.subs.unsubscribe(subscription)
+}
+
+func (r *txRecord) resultTx() *ctypes.ResultTx {
+	return &ctypes.ResultTx{
+		Hash:     r.hash,
+		Height:   r.height,
+		Index:    r.index,
+		TxResult: r.result,
+		Tx:       r.tx,
+	}
+}
+
+// paginate returns the offset and the limit of the page, with the same rules as the tendermint rpc
+func paginate(total int, page, perPage *int) (skip, limit int, err error) {
+	limit = defaultPerPage
+	if perPage != nil && *perPage > 0 {
+		limit = *perPage
+	}
+	if limit > maxPerPage {
+		limit = maxPerPage
+	}
+
+	pages := (total + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+
+	current := 1
+	if page != nil {
+		current = *page
+	}
+	if current <= 0 || current > pages {
+		return 0, 0, fmt.Errorf("page should be within [1, %d] range, given %d", pages, current)
+	}
+	return (current - 1) * limit, limit, nil
+}
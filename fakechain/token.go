@@ -0,0 +1,161 @@
+package fakechain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// error codes of the token codespace
+const (
+	codeSymbolAlreadyExists  uint32 = 8
+	codeMinUnitAlreadyExists uint32 = 9
+	codeTokenNotExists       uint32 = 10
+	codeInvalidOwner         uint32 = 12
+	codeNotMintable          uint32 = 13
+	codeInvalidMaxSupply     uint32 = 6
+)
+
+func handleMsgIssueToken(st *state, msg *token.MsgIssueToken) (sdk.Events, error) {
+	symbol := strings.ToLower(msg.Symbol)
+	if _, ok := st.token(symbol); ok {
+		return nil, newModuleError(token.ModuleName, codeSymbolAlreadyExists, "symbol already exists: %s", symbol)
+	}
+	if _, ok := st.token(msg.MinUnit); ok {
+		return nil, newModuleError(token.ModuleName, codeMinUnitAlreadyExists, "min-unit already exists: %s", msg.MinUnit)
+	}
+	if msg.MaxSupply > 0 && msg.InitialSupply > msg.MaxSupply {
+		return nil, newModuleError(token.ModuleName, codeInvalidMaxSupply, "invalid token max supply %d, only accepts value [%d, %d]", msg.MaxSupply, msg.InitialSupply, msg.MaxSupply)
+	}
+
+	st.tokens[symbol] = token.Token{
+		Symbol:        symbol,
+		Name:          msg.Name,
+		Scale:         msg.Scale,
+		MinUnit:       strings.ToLower(msg.MinUnit),
+		InitialSupply: msg.InitialSupply,
+		MaxSupply:     msg.MaxSupply,
+		Mintable:      msg.Mintable,
+		Owner:         msg.Owner,
+	}
+
+	events := sdk.Events{
+		sdk.NewEvent(token.EventTypeIssueToken,
+			sdk.NewAttribute(token.AttributeKeySymbol, symbol),
+			sdk.NewAttribute(token.AttributeKeyCreator, msg.Owner),
+		),
+	}
+
+	if msg.InitialSupply > 0 {
+		supply := sdk.NewCoins(sdk.NewCoin(strings.ToLower(msg.MinUnit), sdk.NewIntWithDecimal(int64(msg.InitialSupply), int(msg.Scale))))
+		st.addCoins(msg.Owner, supply)
+		events = append(events, sdk.NewEvent("coin_received",
+			sdk.NewAttribute("receiver", msg.Owner),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, supply.String()),
+		))
+	}
+	return append(events, moduleEvent(token.ModuleName)), nil
+}
+
+func handleMsgEditToken(st *state, msg *token.MsgEditToken) (sdk.Events, error) {
+	t, err := st.ownedToken(msg.Symbol, msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msg.Name) > 0 {
+		t.Name = msg.Name
+	}
+
+	if msg.MaxSupply > 0 {
+		issued := st.supply().AmountOf(t.MinUnit)
+		if sdk.NewIntWithDecimal(int64(msg.MaxSupply), int(t.Scale)).LT(issued) {
+			return nil, newModuleError(token.ModuleName, codeInvalidMaxSupply, "max supply must not be less than %s", issued)
+		}
+		t.MaxSupply = msg.MaxSupply
+	}
+
+	if len(msg.Mintable) > 0 {
+		t.Mintable = msg.Mintable.ToBool()
+	}
+	st.tokens[t.Symbol] = t
+
+	return sdk.Events{
+		sdk.NewEvent(token.EventTypeEditToken,
+			sdk.NewAttribute(token.AttributeKeySymbol, t.Symbol),
+			sdk.NewAttribute(token.AttributeKeyOwner, msg.Owner),
+		),
+		moduleEvent(token.ModuleName),
+	}, nil
+}
+
+func handleMsgMintToken(st *state, msg *token.MsgMintToken) (sdk.Events, error) {
+	t, err := st.ownedToken(msg.Symbol, msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Mintable {
+		return nil, newModuleError(token.ModuleName, codeNotMintable, "token %s is not mintable", t.Symbol)
+	}
+
+	amount := sdk.NewIntWithDecimal(int64(msg.Amount), int(t.Scale))
+	maxSupply := sdk.NewIntWithDecimal(int64(t.MaxSupply), int(t.Scale))
+	if issued := st.supply().AmountOf(t.MinUnit); issued.Add(amount).GT(maxSupply) {
+		return nil, newModuleError(token.ModuleName, codeInvalidMaxSupply, "the amount exceeds the mintable token amount; expected (0, %s], got %s", maxSupply.Sub(issued), amount)
+	}
+
+	recipient := msg.To
+	if len(recipient) == 0 {
+		recipient = msg.Owner
+	}
+
+	minted := sdk.NewCoins(sdk.NewCoin(t.MinUnit, amount))
+	st.addCoins(recipient, minted)
+
+	return sdk.Events{
+		sdk.NewEvent("coin_received",
+			sdk.NewAttribute("receiver", recipient),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, minted.String()),
+		),
+		sdk.NewEvent(token.EventTypeMintToken,
+			sdk.NewAttribute(token.AttributeKeySymbol, t.Symbol),
+			sdk.NewAttribute(token.AttributeKeyAmount, fmt.Sprintf("%d", msg.Amount)),
+			sdk.NewAttribute(token.AttributeKeyRecipient, recipient),
+		),
+		moduleEvent(token.ModuleName),
+	}, nil
+}
+
+func handleMsgTransferTokenOwner(st *state, msg *token.MsgTransferTokenOwner) (sdk.Events, error) {
+	t, err := st.ownedToken(msg.Symbol, msg.SrcOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Owner = msg.DstOwner
+	st.tokens[t.Symbol] = t
+
+	return sdk.Events{
+		sdk.NewEvent(token.EventTypeTransferTokenOwner,
+			sdk.NewAttribute(token.AttributeKeySymbol, t.Symbol),
+			sdk.NewAttribute(token.AttributeKeyOwner, msg.SrcOwner),
+			sdk.NewAttribute(token.AttributeKeyDstOwner, msg.DstOwner),
+		),
+		moduleEvent(token.ModuleName),
+	}, nil
+}
+
+// ownedToken returns the token of the symbol, it fails if the owner doesn't own the token
+func (s state) ownedToken(symbol, owner string) (token.Token, error) {
+	t, ok := s.tokens[strings.ToLower(symbol)]
+	if !ok {
+		return token.Token{}, newModuleError(token.ModuleName, codeTokenNotExists, "token: %s does not exist", symbol)
+	}
+	if t.Owner != owner {
+		return token.Token{}, newModuleError(token.ModuleName, codeInvalidOwner, "the address %s is not the owner of the token %s", owner, t.Symbol)
+	}
+	return t, nil
+}
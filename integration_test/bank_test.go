@@ -58,9 +58,10 @@ func send(s IntegrationTestSuite) {
 	to := s.GetRandAccount().Address.String()
 
 	ch := make(chan int)
-	s.Bank.SubscribeSendTx(s.Account().Address.String(), to, func(send bank.EventDataMsgSend) {
+	_, err = s.Bank.SubscribeSendTx(s.Account().Address.String(), to, func(send bank.EventDataMsgSend) {
 		ch <- 1
 	})
+	s.NoError(err)
 
 	baseTx := types.BaseTx{
 		From:     s.Account().Name,
@@ -1,50 +1,125 @@
 package keystore
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 
 	tmcrypto "github.com/tendermint/tendermint/crypto"
-	"github.com/tendermint/tendermint/crypto/secp256k1"
 
 	"github.com/irisnet/irishub-sdk-go/crypto"
 	sdksecp256k1 "github.com/irisnet/irishub-sdk-go/crypto/keys/secp256k1"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/bech32"
+	"github.com/irisnet/irishub-sdk-go/utils/uuid"
 )
 
 // RecoveryAndExportPrivKeyArmor return the new private key armor(after IrisHubV1.0) from a old keystoreFile(before IrisHubV0.16)
 func RecoveryAndExportPrivKeyArmor(keystore []byte, password string) (armor string, err error) {
-	priv, err := recoveryFromKeyStore(keystore, password)
+	priv, err := DecryptKey(keystore, password)
 	if err != nil {
 		return "", err
 	}
-	return exportPrivKeyArmor(priv, password)
+	return crypto.EncryptArmorPrivKey(priv, password, "secp256k1"), nil
 }
 
-func recoveryFromKeyStore(keystore []byte, password string) (tmcrypto.PrivKey, error) {
+// EncryptKey exports the secp256k1 private key as the version 3 Web3 Secret Storage JSON, encrypted
+// with the password by the kdf, Scrypt{} or PBKDF2{}
+func EncryptKey(privKey tmcrypto.PrivKey, password string, kdf KDF) ([]byte, error) {
 	if password == "" {
 		return nil, fmt.Errorf("Password is missing ")
 	}
+	if _, ok := privKey.(*sdksecp256k1.PrivKey); !ok {
+		return nil, fmt.Errorf("unsupported key type %s, the keystore only holds secp256k1 keys", privKey.Type())
+	}
 
-	var encryptedKey EncryptedKeyJSON
-	if err := json.Unmarshal(keystore, &encryptedKey); err != nil {
+	cryptoJSON, err := encryptKey(privKey.Bytes(), password, kdf)
+	if err != nil {
 		return nil, err
 	}
 
-	keyBytes, err := decryptKey(&encryptedKey, password)
+	id, err := uuid.NewV4()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(keyBytes) != 32 {
+	return json.Marshal(EncryptedKeyJSON{
+		Address: sdk.AccAddress(privKey.PubKey().Address()).String(),
+		Crypto:  cryptoJSON,
+		ID:      id.String(),
+		Version: json.Number(fmt.Sprint(Version3)),
+	})
+}
+
+// DecryptKey returns the secp256k1 private key of the keystore JSON: the Web3 Secret Storage encrypted by scrypt
+// or pbkdf2, the keystore of IRISHub before v0.16, or the PlainKeyJSON. The bech32 address of the keystore,
+// when present, must be the address of the key.
+func DecryptKey(keystore []byte, password string) (tmcrypto.PrivKey, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(keystore, &fields); err != nil {
+		return nil, err
+	}
+
+	var (
+		keyBytes []byte
+		address  string
+	)
+	if _, ok := fields["crypto"]; ok {
+		if password == "" {
+			return nil, fmt.Errorf("Password is missing ")
+		}
+
+		var encryptedKey EncryptedKeyJSON
+		if err := json.Unmarshal(keystore, &encryptedKey); err != nil {
+			return nil, err
+		}
+
+		bz, err := decryptKey(&encryptedKey, password)
+		if err != nil {
+			return nil, err
+		}
+		keyBytes, address = bz, encryptedKey.Address
+	} else {
+		var plainKey PlainKeyJSON
+		if err := json.Unmarshal(keystore, &plainKey); err != nil {
+			return nil, err
+		}
+		if plainKey.PrivateKey == "" {
+			return nil, fmt.Errorf("invalid keystore, neither crypto nor privatekey found")
+		}
+
+		bz, err := hex.DecodeString(plainKey.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		keyBytes, address = bz, plainKey.Address
+	}
+
+	if len(keyBytes) != keyLen {
 		return nil, fmt.Errorf("Len of Keyby tes is not equal to 32 ")
 	}
+	privKey := &sdksecp256k1.PrivKey{Key: keyBytes}
 
-	return secp256k1.PrivKey(keyBytes), nil
+	// the addresses of the other chains, e.g. the hex addresses of Ethereum, can't be checked
+	if _, addr, err := bech32.DecodeAndConvert(address); err == nil && !bytes.Equal(addr, privKey.PubKey().Address()) {
+		return nil, fmt.Errorf("the key doesn't match the address %s of the keystore", address)
+	}
+	return privKey, nil
 }
 
-func exportPrivKeyArmor(privKey tmcrypto.PrivKey, password string) (armor string, err error) {
-	priv := sdksecp256k1.PrivKey{
-		Key: privKey.Bytes(),
+// EncryptData encrypts the data with the password by the kdf, in the crypto format of the Web3 Secret Storage
+func EncryptData(data []byte, password string, kdf KDF) (CryptoJSON, error) {
+	if password == "" {
+		return CryptoJSON{}, fmt.Errorf("Password is missing ")
+	}
+	return encryptKey(data, password, kdf)
+}
+
+// DecryptData decrypts the data encrypted by EncryptData, the MAC detects a wrong password or a modified ciphertext
+func DecryptData(cryptoJSON CryptoJSON, password string) ([]byte, error) {
+	if password == "" {
+		return nil, fmt.Errorf("Password is missing ")
 	}
-	return crypto.EncryptArmorPrivKey(&priv, password, "secp256k1"), nil
+	return decryptKey(&EncryptedKeyJSON{Crypto: cryptoJSON, Version: json.Number(fmt.Sprint(Version3))}, password)
 }
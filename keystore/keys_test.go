@@ -1,9 +1,17 @@
 package keystore
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/crypto/keys/ed25519"
+	"github.com/irisnet/irishub-sdk-go/crypto/keys/secp256k1"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/uuid"
 )
 
 func TestRecoveryAndExportPrivKeyArmor(t *testing.T) {
@@ -13,3 +21,147 @@ func TestRecoveryAndExportPrivKeyArmor(t *testing.T) {
 
 	t.Log(armor)
 }
+
+// test vectors of the Web3 Secret Storage Definition
+func TestDecryptKeyWeb3Vectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		keystore string
+	}{
+		{"pbkdf2", `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`},
+		{"scrypt", `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privKey, err := DecryptKey([]byte(tt.keystore), "testpassword")
+			require.NoError(t, err)
+			require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(privKey.Bytes()))
+
+			_, err = DecryptKey([]byte(tt.keystore), "wrongpassword")
+			require.Equal(t, errDecrypt, err)
+		})
+	}
+}
+
+func TestEncryptKey(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	address := sdk.AccAddress(privKey.PubKey().Address()).String()
+
+	for _, kdf := range []KDF{Scrypt{N: LightScryptN, P: LightScryptP}, PBKDF2{C: 1024}} {
+		t.Run(kdf.name(), func(t *testing.T) {
+			bz, err := EncryptKey(privKey, "12345678", kdf)
+			require.NoError(t, err)
+
+			var keyJSON EncryptedKeyJSON
+			require.NoError(t, json.Unmarshal(bz, &keyJSON))
+			require.Equal(t, "3", keyJSON.Version.String())
+			require.Equal(t, address, keyJSON.Address)
+			require.Equal(t, kdf.name(), keyJSON.Crypto.KDF)
+			_, err = uuid.FromString(keyJSON.ID)
+			require.NoError(t, err)
+
+			decrypted, err := DecryptKey(bz, "12345678")
+			require.NoError(t, err)
+			require.True(t, privKey.Equals(decrypted))
+
+			_, err = DecryptKey(bz, "87654321")
+			require.Equal(t, errDecrypt, err)
+
+			// the MAC protects the ciphertext
+			keyJSON.Crypto.CipherText = "00" + keyJSON.Crypto.CipherText[2:]
+			bz, err = json.Marshal(keyJSON)
+			require.NoError(t, err)
+			_, err = DecryptKey(bz, "12345678")
+			require.Equal(t, errDecrypt, err)
+		})
+	}
+
+	_, err := EncryptKey(ed25519.GenPrivKey(), "12345678", Scrypt{})
+	require.Error(t, err)
+}
+
+func TestDecryptKDFParamsBounds(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	for _, kdf := range []KDF{Scrypt{N: LightScryptN, P: LightScryptP}, PBKDF2{C: 1024}} {
+		bz, err := EncryptKey(privKey, "12345678", kdf)
+		require.NoError(t, err)
+
+		var params map[string]interface{}
+		switch kdf.(type) {
+		case Scrypt:
+			params = map[string]interface{}{
+				"n too large":      float64(MaxScryptN * 2),
+				"n not power of 2": float64(LightScryptN + 1),
+				"n overflow":       1e300,
+				"n not integer":    4096.5,
+				"r too large":      float64(1 << 20),
+				"p zero":           float64(0),
+				"dklen too large":  float64(1 << 30),
+			}
+		case PBKDF2:
+			params = map[string]interface{}{
+				"c too large":     float64(MaxPBKDF2C + 1),
+				"c negative":      float64(-1),
+				"dklen too large": float64(1 << 30),
+			}
+		}
+
+		for name, value := range params {
+			var keyJSON EncryptedKeyJSON
+			require.NoError(t, json.Unmarshal(bz, &keyJSON))
+			param := strings.Fields(name)[0]
+			keyJSON.Crypto.KDFParams[param] = value
+
+			tampered, err := json.Marshal(keyJSON)
+			require.NoError(t, err)
+			_, err = DecryptKey(tampered, "12345678")
+			require.Error(t, err, name)
+			require.NotEqual(t, errDecrypt, err, name)
+		}
+	}
+
+	_, err := EncryptKey(privKey, "12345678", Scrypt{N: MaxScryptN * 2})
+	require.Error(t, err)
+}
+
+func TestEncryptData(t *testing.T) {
+	data := []byte(`{"keys":[]}`)
+	cryptoJSON, err := EncryptData(data, "12345678", Scrypt{N: LightScryptN, P: LightScryptP})
+	require.NoError(t, err)
+
+	// the kdf params are decoded as float64 from the JSON
+	bz, err := json.Marshal(cryptoJSON)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &cryptoJSON))
+
+	decrypted, err := DecryptData(cryptoJSON, "12345678")
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	_, err = DecryptData(cryptoJSON, "87654321")
+	require.Equal(t, errDecrypt, err)
+}
+
+func TestDecryptPlainKey(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+
+	bz, err := json.Marshal(PlainKeyJSON{
+		Address:    sdk.AccAddress(privKey.PubKey().Address()).String(),
+		PrivateKey: hex.EncodeToString(privKey.Bytes()),
+		Version:    1,
+	})
+	require.NoError(t, err)
+
+	decrypted, err := DecryptKey(bz, "")
+	require.NoError(t, err)
+	require.True(t, privKey.Equals(decrypted))
+
+	// the address must match the key
+	bz, err = json.Marshal(PlainKeyJSON{
+		Address:    sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String(),
+		PrivateKey: hex.EncodeToString(privKey.Bytes()),
+	})
+	require.NoError(t, err)
+	_, err = DecryptKey(bz, "")
+	require.Error(t, err)
+}
@@ -1,15 +1,56 @@
 package keystore
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// Version3 is the version of the Web3 Secret Storage
+	Version3 = 3
+
+	cipherAES128CTR = "aes-128-ctr"
+	kdfScrypt       = "scrypt"
+	kdfPBKDF2       = "pbkdf2"
+	prfHmacSHA256   = "hmac-sha256"
+
+	keyLen   = 32
+	saltLen  = 32
+	dkLen    = 32
+	ivLength = aes.BlockSize
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters of the wallets, using 256MB of memory
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	// LightScryptN and LightScryptP are the scrypt parameters using 4MB of memory
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR = 8
+
+	// DefaultPBKDF2C is the default iteration count of PBKDF2
+	DefaultPBKDF2C = 262144
+
+	// Upper bounds of the KDF parameters, the parameters of an imported keystore are checked before
+	// deriving the key, so that a crafted file can't exhaust the memory or the CPU
+	MaxScryptN      = 1 << 20
+	MaxScryptRP     = 1 << 6
+	MaxScryptMemory = 1 << 30 // 128 * n * r bytes
+	MaxPBKDF2C      = 1 << 22
+	maxDKLen        = 64
 )
 
 var (
@@ -29,7 +70,8 @@ type EncryptedKeyJSON struct {
 	Address string     `json:"address"`
 	Crypto  CryptoJSON `json:"crypto"`
 	ID      string     `json:"id"`
-	Version string     `json:"version"`
+	// Version is 3 for the Web3 Secret Storage, the keystores of IRISHub before v0.16 are version "1"
+	Version json.Number `json:"version"`
 }
 
 // CryptoJSON define a struct
@@ -46,7 +88,126 @@ type cipherparamsJSON struct {
 	IV string `json:"iv"`
 }
 
+// KDF derives the encryption key of a keystore from the password, Scrypt or PBKDF2
+type KDF interface {
+	name() string
+	params(salt []byte) map[string]interface{}
+	deriveKey(password, salt []byte) ([]byte, error)
+}
+
+var (
+	_ KDF = Scrypt{}
+	_ KDF = PBKDF2{}
+)
+
+// Scrypt is the scrypt KDF, the zero value uses the standard parameters
+type Scrypt struct {
+	N int
+	P int
+}
+
+func (s Scrypt) name() string {
+	return kdfScrypt
+}
+
+func (s Scrypt) params(salt []byte) map[string]interface{} {
+	n, p := s.values()
+	return map[string]interface{}{
+		"n":     n,
+		"r":     scryptR,
+		"p":     p,
+		"dklen": dkLen,
+		"salt":  hex.EncodeToString(salt),
+	}
+}
+
+func (s Scrypt) deriveKey(password, salt []byte) ([]byte, error) {
+	n, p := s.values()
+	if err := checkScryptParams(n, scryptR, p); err != nil {
+		return nil, err
+	}
+	return scrypt.Key(password, salt, n, scryptR, p, dkLen)
+}
+
+func (s Scrypt) values() (n, p int) {
+	n, p = s.N, s.P
+	if n == 0 {
+		n = StandardScryptN
+	}
+	if p == 0 {
+		p = StandardScryptP
+	}
+	return n, p
+}
+
+// PBKDF2 is the PBKDF2 KDF with HMAC-SHA256, the zero value uses DefaultPBKDF2C iterations
+type PBKDF2 struct {
+	C int
+}
+
+func (k PBKDF2) name() string {
+	return kdfPBKDF2
+}
+
+func (k PBKDF2) params(salt []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"c":     k.iterations(),
+		"prf":   prfHmacSHA256,
+		"dklen": dkLen,
+		"salt":  hex.EncodeToString(salt),
+	}
+}
+
+func (k PBKDF2) deriveKey(password, salt []byte) ([]byte, error) {
+	if err := checkPBKDF2C(k.iterations()); err != nil {
+		return nil, err
+	}
+	return pbkdf2.Key(password, salt, k.iterations(), dkLen, sha256.New), nil
+}
+
+func (k PBKDF2) iterations() int {
+	if k.C == 0 {
+		return DefaultPBKDF2C
+	}
+	return k.C
+}
+
+// encryptKey encrypts the key bytes as the version 3 Web3 Secret Storage
+func encryptKey(key []byte, password string, kdf KDF) (CryptoJSON, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return CryptoJSON{}, err
+	}
+	iv := make([]byte, ivLength)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return CryptoJSON{}, err
+	}
+
+	derivedKey, err := kdf.deriveKey([]byte(password), salt)
+	if err != nil {
+		return CryptoJSON{}, err
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], key, iv)
+	if err != nil {
+		return CryptoJSON{}, err
+	}
+
+	return CryptoJSON{
+		Cipher:       cipherAES128CTR,
+		CipherText:   hex.EncodeToString(cipherText),
+		CipherParams: cipherparamsJSON{IV: hex.EncodeToString(iv)},
+		KDF:          kdf.name(),
+		KDFParams:    kdf.params(salt),
+		MAC:          hex.EncodeToString(keccak256(derivedKey[16:32], cipherText)),
+	}, nil
+}
+
 func decryptKey(keyProtected *EncryptedKeyJSON, password string) ([]byte, error) {
+	if keyProtected.Crypto.Cipher != cipherAES128CTR {
+		return nil, fmt.Errorf("Unsupported cipher: %s", keyProtected.Crypto.Cipher)
+	}
+
 	mac, err := hex.DecodeString(keyProtected.Crypto.MAC)
 	if err != nil {
 		return nil, err
@@ -56,6 +217,9 @@ func decryptKey(keyProtected *EncryptedKeyJSON, password string) ([]byte, error)
 	if err != nil {
 		return nil, err
 	}
+	if len(iv) != ivLength {
+		return nil, fmt.Errorf("invalid iv length %d", len(iv))
+	}
 
 	cipherText, err := hex.DecodeString(keyProtected.Crypto.CipherText)
 	if err != nil {
@@ -67,11 +231,15 @@ func decryptKey(keyProtected *EncryptedKeyJSON, password string) ([]byte, error)
 		return nil, err
 	}
 
-	bufferValue := make([]byte, len(cipherText)+16)
-	copy(bufferValue[0:16], derivedKey[16:32])
-	copy(bufferValue[16:], cipherText[:])
-	calculatedMAC := sha256.Sum256([]byte((bufferValue)))
-	if !bytes.Equal(calculatedMAC[:], mac) {
+	// the Web3 Secret Storage hashes the MAC with Keccak-256, the keystores of IRISHub before v0.16 with SHA-256
+	var calculatedMAC []byte
+	if keyProtected.Version.String() == fmt.Sprint(Version3) {
+		calculatedMAC = keccak256(derivedKey[16:32], cipherText)
+	} else {
+		sum := sha256.Sum256(append(append([]byte{}, derivedKey[16:32]...), cipherText...))
+		calculatedMAC = sum[:]
+	}
+	if subtle.ConstantTimeCompare(calculatedMAC, mac) != 1 {
 		return nil, errDecrypt
 	}
 
@@ -84,34 +252,104 @@ func decryptKey(keyProtected *EncryptedKeyJSON, password string) ([]byte, error)
 
 func getKDFKey(cryptoJSON CryptoJSON, password string) ([]byte, error) {
 	authArray := []byte(password)
-	if cryptoJSON.KDFParams["salt"] == nil || cryptoJSON.KDFParams["dklen"] == nil ||
-		cryptoJSON.KDFParams["c"] == nil || cryptoJSON.KDFParams["prf"] == nil {
-		return nil, errors.New("invalid KDF params, must contains c, dklen, prf and salt")
+	if cryptoJSON.KDFParams["salt"] == nil || cryptoJSON.KDFParams["dklen"] == nil {
+		return nil, errors.New("invalid KDF params, must contains dklen and salt")
+	}
+	saltHex, ok := cryptoJSON.KDFParams["salt"].(string)
+	if !ok {
+		return nil, errors.New("invalid KDF params, salt must be a hex string")
 	}
-	salt, err := hex.DecodeString(cryptoJSON.KDFParams["salt"].(string))
+	salt, err := hex.DecodeString(saltHex)
 	if err != nil {
 		return nil, err
 	}
-	dkLen := ensureInt(cryptoJSON.KDFParams["dklen"])
+	length, err := ensureInt(cryptoJSON.KDFParams["dklen"])
+	if err != nil {
+		return nil, err
+	}
+	if length < dkLen || length > maxDKLen {
+		return nil, fmt.Errorf("invalid KDF params, dklen %d is out of [%d, %d]", length, dkLen, maxDKLen)
+	}
 
-	if cryptoJSON.KDF == "pbkdf2" {
-		c := ensureInt(cryptoJSON.KDFParams["c"])
-		prf := cryptoJSON.KDFParams["prf"].(string)
-		if prf != "hmac-sha256" {
-			return nil, fmt.Errorf("Unsupported PBKDF2 PRF: %s", prf)
+	switch cryptoJSON.KDF {
+	case kdfScrypt:
+		if cryptoJSON.KDFParams["n"] == nil || cryptoJSON.KDFParams["r"] == nil || cryptoJSON.KDFParams["p"] == nil {
+			return nil, errors.New("invalid KDF params, must contains n, r, p, dklen and salt")
 		}
-		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
+		n, err := ensureInt(cryptoJSON.KDFParams["n"])
+		if err != nil {
+			return nil, err
+		}
+		r, err := ensureInt(cryptoJSON.KDFParams["r"])
+		if err != nil {
+			return nil, err
+		}
+		p, err := ensureInt(cryptoJSON.KDFParams["p"])
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScryptParams(n, r, p); err != nil {
+			return nil, err
+		}
+		return scrypt.Key(authArray, salt, n, r, p, length)
+	case kdfPBKDF2:
+		if cryptoJSON.KDFParams["c"] == nil || cryptoJSON.KDFParams["prf"] == nil {
+			return nil, errors.New("invalid KDF params, must contains c, dklen, prf and salt")
+		}
+		c, err := ensureInt(cryptoJSON.KDFParams["c"])
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPBKDF2C(c); err != nil {
+			return nil, err
+		}
+		prf, ok := cryptoJSON.KDFParams["prf"].(string)
+		if !ok || prf != prfHmacSHA256 {
+			return nil, fmt.Errorf("Unsupported PBKDF2 PRF: %v", cryptoJSON.KDFParams["prf"])
+		}
+		key := pbkdf2.Key(authArray, salt, c, length, sha256.New)
 		return key, nil
+	default:
+		return nil, fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
 	}
-	return nil, fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
 }
 
-func ensureInt(x interface{}) int {
-	res, ok := x.(int)
-	if !ok {
-		res = int(x.(float64))
+// checkScryptParams rejects the scrypt parameters above the bounds, n must be a power of 2
+func checkScryptParams(n, r, p int) error {
+	if n <= 1 || n > MaxScryptN || n&(n-1) != 0 {
+		return fmt.Errorf("invalid KDF params, scrypt n %d must be a power of 2 in (1, %d]", n, MaxScryptN)
+	}
+	if r <= 0 || p <= 0 || r > MaxScryptRP || p > MaxScryptRP || r*p > MaxScryptRP {
+		return fmt.Errorf("invalid KDF params, scrypt r %d and p %d must be positive and r * p at most %d", r, p, MaxScryptRP)
+	}
+	if 128*n*r > MaxScryptMemory {
+		return fmt.Errorf("invalid KDF params, scrypt n %d and r %d need more than %d bytes", n, r, MaxScryptMemory)
+	}
+	return nil
+}
+
+// checkPBKDF2C rejects the iteration count of PBKDF2 above the bound
+func checkPBKDF2C(c int) error {
+	if c <= 0 || c > MaxPBKDF2C {
+		return fmt.Errorf("invalid KDF params, pbkdf2 c %d must be in [1, %d]", c, MaxPBKDF2C)
+	}
+	return nil
+}
+
+// ensureInt returns the integer of the KDF param, decoded as float64 from the JSON
+func ensureInt(x interface{}) (int, error) {
+	switch v := x.(type) {
+	case int:
+		return v, nil
+	case float64:
+		// the out of range floats don't convert to int
+		if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+			return 0, fmt.Errorf("invalid KDF param %v, must be an integer", x)
+		}
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("invalid KDF param %v, must be a number", x)
 	}
-	return res
 }
 
 // algorithm: AES-128
@@ -127,3 +365,11 @@ func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
 	stream.XORKeyStream(outText, inText)
 	return outText, err
 }
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		_, _ = h.Write(b)
+	}
+	return h.Sum(nil)
+}
@@ -6,12 +6,15 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/irisnet/irishub-sdk-go/codec"
 	"github.com/irisnet/irishub-sdk-go/modules/auth"
 	"github.com/irisnet/irishub-sdk-go/modules/bank"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/cache"
+	"github.com/irisnet/irishub-sdk-go/utils/metrics"
+	"github.com/irisnet/irishub-sdk-go/utils/tracing"
 )
 
 // Must be used with locker, otherwise there are thread safety issues
@@ -23,12 +26,22 @@ type accountQuery struct {
 	cdc        codec.Marshaler
 	km         sdk.KeyManager
 	expiration time.Duration
+	metrics    *metrics.Metrics
+	tracer     tracing.Tracer
 }
 
 func (a accountQuery) QueryAndRefreshAccount(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := a.Get(a.prefixKey(address))
-	if err != nil {
-		return a.refresh(address)
+	return a.queryAndRefreshAccount(context.Background(), address)
+}
+
+func (a accountQuery) queryAndRefreshAccount(ctx context.Context, address string) (_ sdk.BaseAccount, err sdk.Error) {
+	ctx, span := a.tracer.Start(ctx, "QueryAndRefreshAccount", attribute.String(tracing.AttributeKeyAccountAddress, address))
+	defer func() { tracing.End(span, err) }()
+
+	account, cacheErr := a.Get(a.prefixKey(address))
+	a.metrics.CacheAccess(metrics.CacheAccount, cacheErr == nil)
+	if cacheErr != nil {
+		return a.refresh(ctx, address)
 	}
 
 	acc := account.(accountInfo)
@@ -44,6 +57,10 @@ func (a accountQuery) QueryAndRefreshAccount(address string) (sdk.BaseAccount, s
 }
 
 func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
+	return a.queryAccount(context.Background(), address)
+}
+
+func (a accountQuery) queryAccount(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
 	conn, err := a.GenConn()
 	defer func() { _ = conn.Close() }()
 	if err != nil {
@@ -54,7 +71,7 @@ func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
 		Address: address,
 	}
 
-	response, err := auth.NewQueryClient(conn).Account(context.Background(), request)
+	response, err := auth.NewQueryClient(conn).Account(ctx, request)
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
@@ -70,7 +87,7 @@ func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
 		Address:    address,
 		Pagination: nil,
 	}
-	balances, err := bank.NewQueryClient(conn).AllBalances(context.Background(), breq)
+	balances, err := bank.NewQueryClient(conn).AllBalances(ctx, breq)
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
@@ -108,8 +125,8 @@ func (a accountQuery) removeCache(address string) bool {
 	return a.Remove(a.prefixKey(address))
 }
 
-func (a accountQuery) refresh(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := a.QueryAccount(address)
+func (a accountQuery) refresh(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	account, err := a.queryAccount(ctx, address)
 	if err != nil {
 		a.Error("update cache failed", "address", address, "errMsg", err.Error())
 		return sdk.BaseAccount{}, sdk.Wrap(err)
@@ -0,0 +1,603 @@
+package bank
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	defaultAirdropBatchSize = 100
+	defaultAirdropMaxGas    = 2000000
+	// defaultAirdropMaxTxBytes is the default max_tx_bytes of the mempool of tendermint
+	defaultAirdropMaxTxBytes = 1024 * 1024
+)
+
+// airdropCSVHeader is the header of AirdropReport.WriteCSV
+var airdropCSVHeader = []string{"address", "amount", "status", "batch", "hash", "height", "error"}
+
+// ParseAirdropCSV parses the receipts of the lines `address,amount`, the amount is in main unit, e.g. 10.5iris.
+// The first line is skipped when it is the header `address,amount`.
+func ParseAirdropCSV(r io.Reader) ([]Receipt, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var receipts []Receipt
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return receipts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+
+		amount, err := sdk.ParseDecCoins(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %s: %w", line, record[1], err)
+		}
+		receipts = append(receipts, Receipt{Address: strings.TrimSpace(record[0]), Amount: amount})
+	}
+}
+
+// ParseAirdropJSON parses the receipts of a JSON array of {"address": ..., "amount": ...},
+// the amount is in main unit as in the CSV, e.g. "10.5iris"
+func ParseAirdropJSON(r io.Reader) ([]Receipt, error) {
+	var items []struct {
+		Address string `json:"address"`
+		Amount  string `json:"amount"`
+	}
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, err
+	}
+
+	receipts := make([]Receipt, len(items))
+	for i, item := range items {
+		amount, err := sdk.ParseDecCoins(strings.TrimSpace(item.Amount))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: invalid amount %s: %w", i, item.Amount, err)
+		}
+		receipts[i] = Receipt{Address: strings.TrimSpace(item.Address), Amount: amount}
+	}
+	return receipts, nil
+}
+
+func (b bankClient) Airdrop(receipts []Receipt, baseTx sdk.BaseTx, opts AirdropOptions) (AirdropReport, sdk.Error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultAirdropBatchSize
+	}
+	if opts.MaxGas == 0 {
+		opts.MaxGas = defaultAirdropMaxGas
+	}
+
+	sender, err := b.QueryAddress(baseTx.From, baseTx.Password)
+	if err != nil {
+		return AirdropReport{}, sdk.Wrapf("%s not found", baseTx.From)
+	}
+
+	report, e := b.planAirdrop(sender.String(), receipts)
+	if e != nil {
+		return AirdropReport{}, sdk.Wrap(e)
+	}
+
+	nextBatch, e := b.resumeAirdrop(&report, opts)
+	if e != nil {
+		return report.reconcile(), sdk.Wrap(e)
+	}
+
+	var (
+		pending []int
+		unpaid  = sdk.NewCoins()
+	)
+	for i, result := range report.Results {
+		if result.Status == AirdropPending {
+			pending = append(pending, i)
+			unpaid = unpaid.Add(result.Amount...)
+		}
+	}
+	if len(pending) == 0 {
+		return report.reconcile(), nil
+	}
+
+	fee := baseTx.Fee
+	if fee.Empty() {
+		fee = b.TxDefaults().Fee
+	}
+	minFee, err := b.ToMinCoin(fee...)
+	if err != nil {
+		return report.reconcile(), err
+	}
+	account, err := b.QueryAccount(sender.String())
+	if err != nil {
+		return report.reconcile(), sdk.Wrap(err)
+	}
+
+	// the funds are checked before anything is sent, first for the fewest batches since the batches are
+	// simulated against the balance, then for the batches sized by their gas and bytes
+	if e := checkAirdropFunds(account, unpaid, minFee, (len(pending)+opts.BatchSize-1)/opts.BatchSize); e != nil {
+		return report.reconcile(), sdk.Wrap(e)
+	}
+	batches, e := b.sizeAirdropBatches(&report, pending, sender, account, baseTx, opts)
+	if e != nil {
+		return report.reconcile(), sdk.Wrap(e)
+	}
+	if e := checkAirdropFunds(account, unpaid, minFee, len(batches)); e != nil {
+		return report.reconcile(), sdk.Wrap(e)
+	}
+
+	for _, batch := range batches {
+		if opts.DryRun {
+			for _, i := range batch {
+				report.Results[i].Batch = nextBatch
+			}
+		} else if e := b.sendAirdropBatch(&report, batch, nextBatch, sender, &account, baseTx, opts); e != nil {
+			return report.reconcile(), sdk.Wrap(e)
+		}
+		nextBatch++
+	}
+	return report.reconcile(), nil
+}
+
+// planAirdrop validates every receipt, so that no invalid receipt is found after the first batch is sent
+func (b bankClient) planAirdrop(sender string, receipts []Receipt) (AirdropReport, error) {
+	if len(receipts) == 0 {
+		return AirdropReport{}, errors.New("no receipt")
+	}
+
+	var (
+		problems []string
+		seen     = make(map[string]bool, len(receipts))
+		report   = AirdropReport{
+			Sender:  sender,
+			Total:   sdk.NewCoins(),
+			Results: make([]AirdropResult, 0, len(receipts)),
+		}
+	)
+	for i, receipt := range receipts {
+		addr, err := sdk.AccAddressFromBech32(strings.TrimSpace(receipt.Address))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("#%d: invalid address %s", i+1, strings.TrimSpace(receipt.Address)))
+			continue
+		}
+		// the chain accepts the upper case addresses too, so the addresses are compared and journaled in lower case
+		address := addr.String()
+		if seen[address] {
+			problems = append(problems, fmt.Sprintf("#%d: duplicated address %s", i+1, address))
+			continue
+		}
+		seen[address] = true
+
+		if receipt.Amount.Empty() || !receipt.Amount.IsValid() {
+			problems = append(problems, fmt.Sprintf("#%d: invalid amount %s", i+1, receipt.Amount))
+			continue
+		}
+		amount, err := b.ToMinCoin(receipt.Amount...)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("#%d: invalid amount %s: %s", i+1, receipt.Amount, err.Error()))
+			continue
+		}
+		if amount.Empty() || !amount.IsAllPositive() {
+			problems = append(problems, fmt.Sprintf("#%d: amount %s is below the min unit", i+1, receipt.Amount))
+			continue
+		}
+
+		report.Total = report.Total.Add(amount...)
+		report.Results = append(report.Results, AirdropResult{
+			Address: address,
+			Amount:  amount,
+			Status:  AirdropPending,
+		})
+	}
+	if len(problems) > 0 {
+		return AirdropReport{}, fmt.Errorf("invalid receipts: %s", strings.Join(problems, "; "))
+	}
+	return report, nil
+}
+
+// resumeAirdrop marks the recipients paid by the batches of the journal, the batches whose outcome is unknown
+// are resolved first. It returns the number of the next batch.
+func (b bankClient) resumeAirdrop(report *AirdropReport, opts AirdropOptions) (int, error) {
+	if opts.Journal == nil {
+		return 1, nil
+	}
+	entries, err := opts.Journal.Entries()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		batches   = make(map[int]AirdropJournalEntry)
+		order     []int
+		nextBatch = 1
+	)
+	for _, entry := range entries {
+		if _, ok := batches[entry.Batch]; !ok {
+			order = append(order, entry.Batch)
+		}
+		batches[entry.Batch] = entry
+		if entry.Batch >= nextBatch {
+			nextBatch = entry.Batch + 1
+		}
+	}
+
+	// the addresses of the report are normalized by planAirdrop
+	index := make(map[string]int, len(report.Results))
+	for i, result := range report.Results {
+		index[result.Address] = i
+	}
+
+	for _, batch := range order {
+		entry := batches[batch]
+		if entry.Status == AirdropSigned {
+			if entry, err = b.resolveAirdropBatch(entry, opts.DryRun); err != nil {
+				return 0, err
+			}
+			if entry.Status != AirdropSigned && !opts.DryRun {
+				if err := opts.Journal.Append(entry); err != nil {
+					return 0, err
+				}
+			}
+		}
+		if entry.Status != AirdropPaid && entry.Status != AirdropSigned {
+			continue
+		}
+
+		for _, address := range entry.Recipients {
+			i, ok := index[normalizeAddress(address)]
+			if !ok {
+				continue
+			}
+			status := AirdropSkipped
+			if entry.Status == AirdropSigned {
+				status = AirdropSigned
+			}
+			report.Results[i].Status = status
+			report.Results[i].Batch = entry.Batch
+			report.Results[i].Hash = entry.Hash
+			report.Results[i].Height = entry.Height
+		}
+	}
+	return nextBatch, nil
+}
+
+// resolveAirdropBatch returns the batch paid or failed according to its tx, which is broadcast again when
+// it isn't found. In dry run the batch stays AirdropSigned when its tx isn't found.
+func (b bankClient) resolveAirdropBatch(entry AirdropJournalEntry, dryRun bool) (AirdropJournalEntry, error) {
+	if res, err := b.QueryTx(entry.Hash); err == nil {
+		return settleAirdropBatch(entry, res), nil
+	}
+	if dryRun {
+		return entry, nil
+	}
+
+	height, rejected, err := b.broadcastAirdropBatch(entry.Tx)
+	if err == nil {
+		entry.Status, entry.Height, entry.Tx = AirdropPaid, height, nil
+		return entry, nil
+	}
+	if res, err := b.QueryTx(entry.Hash); err == nil {
+		return settleAirdropBatch(entry, res), nil
+	}
+	if !rejected {
+		return entry, fmt.Errorf("the outcome of batch %d, tx %s, is unknown: %w", entry.Batch, entry.Hash, err)
+	}
+	entry.Status, entry.Height, entry.Tx, entry.Error = AirdropFailed, height, nil, err.Error()
+	return entry, nil
+}
+
+// normalizeAddress returns the address in lower case when valid, e.g. journaled by a previous version as written
+func normalizeAddress(address string) string {
+	if addr, err := sdk.AccAddressFromBech32(address); err == nil {
+		return addr.String()
+	}
+	return address
+}
+
+func settleAirdropBatch(entry AirdropJournalEntry, res sdk.ResultQueryTx) AirdropJournalEntry {
+	entry.Height, entry.Tx = res.Height, nil
+	if res.Result.Code == 0 {
+		entry.Status = AirdropPaid
+	} else {
+		entry.Status, entry.Error = AirdropFailed, res.Result.Log
+	}
+	return entry
+}
+
+func checkAirdropFunds(account sdk.BaseAccount, unpaid, fee sdk.Coins, batches int) error {
+	required := unpaid
+	for i := 0; i < batches; i++ {
+		required = required.Add(fee...)
+	}
+	if !account.Coins.IsAllGTE(required) {
+		return fmt.Errorf("insufficient funds: %s required, %s available", required, account.Coins)
+	}
+	return nil
+}
+
+// sizeAirdropBatches splits the pending recipients into batches of at most opts.BatchSize recipients, a batch
+// is halved until its simulated gas fits opts.MaxGas and its tx fits the MaxTxBytes of the config, capped by the
+// max_tx_bytes of the mempool. Every batch is simulated at the current sequence of the account.
+func (b bankClient) sizeAirdropBatches(report *AirdropReport, pending []int, sender sdk.AccAddress,
+	account sdk.BaseAccount, baseTx sdk.BaseTx, opts AirdropOptions) ([][]int, error) {
+	maxTxBytes := b.TxDefaults().MaxTxBytes
+	if maxTxBytes == 0 || maxTxBytes > defaultAirdropMaxTxBytes {
+		maxTxBytes = defaultAirdropMaxTxBytes
+	}
+
+	baseTx.AccountNumber, baseTx.Sequence = account.AccountNumber, account.Sequence
+
+	var (
+		batches [][]int
+		size    = opts.BatchSize
+	)
+	for start := 0; start < len(pending); {
+		if start+size > len(pending) {
+			size = len(pending) - start
+		}
+		recipients := pending[start : start+size]
+
+		txBytes, gas, err := b.simulateAirdropBatch(report, recipients, sender, baseTx, opts.MaxGas)
+		if err != nil {
+			return nil, err
+		}
+		if gas > opts.MaxGas || uint64(len(txBytes)) > maxTxBytes {
+			if size == 1 {
+				return nil, fmt.Errorf("the tx of recipient %s needs %d gas and %d bytes, more than the max %d gas or %d bytes",
+					report.Results[recipients[0]].Address, gas, len(txBytes), opts.MaxGas, maxTxBytes)
+			}
+			size /= 2
+			continue
+		}
+		batches = append(batches, recipients)
+		start += size
+	}
+	return batches, nil
+}
+
+// simulateAirdropBatch returns the tx of the recipients signed with the max gas, so that the tx signed with
+// the simulated gas isn't larger, and its simulated gas
+func (b bankClient) simulateAirdropBatch(report *AirdropReport, recipients []int, sender sdk.AccAddress,
+	baseTx sdk.BaseTx, maxGas uint64) ([]byte, uint64, error) {
+	baseTx.Gas = maxGas
+	txBytes, _, err := b.buildAirdropBatch(report, recipients, sender, baseTx)
+	if err != nil {
+		return nil, 0, err
+	}
+	gas, err := b.EstimateTxGas(txBytes)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to simulate the batch of %d recipients: %w", len(recipients), err)
+	}
+	return txBytes, gas, nil
+}
+
+// buildAirdropBatch returns the signed tx paying the recipients with a MsgMultiSend of a single input
+func (b bankClient) buildAirdropBatch(report *AirdropReport, recipients []int, sender sdk.AccAddress,
+	baseTx sdk.BaseTx) ([]byte, []string, error) {
+	var (
+		total     = sdk.NewCoins()
+		outputs   = make([]Output, len(recipients))
+		addresses = make([]string, len(recipients))
+	)
+	for j, i := range recipients {
+		result := report.Results[i]
+		address, err := sdk.AccAddressFromBech32(result.Address)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs[j] = NewOutput(address, result.Amount)
+		addresses[j] = result.Address
+		total = total.Add(result.Amount...)
+	}
+	msg := NewMsgMultiSend([]Input{NewInput(sender, total)}, outputs)
+
+	txBytes, err := b.BuildSignedTx([]sdk.Msg{msg}, baseTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txBytes, addresses, nil
+}
+
+// sendAirdropBatch pays the recipients with the gas simulated at the sequence of the tx, the signed tx is
+// journaled before it is broadcast so that its outcome can be resolved by the next run
+func (b bankClient) sendAirdropBatch(report *AirdropReport, recipients []int, batch int, sender sdk.AccAddress,
+	account *sdk.BaseAccount, baseTx sdk.BaseTx, opts AirdropOptions) error {
+	journal := opts.Journal
+	baseTx.AccountNumber, baseTx.Sequence = account.AccountNumber, account.Sequence
+	_, gas, err := b.simulateAirdropBatch(report, recipients, sender, baseTx, opts.MaxGas)
+	if err != nil {
+		return err
+	}
+
+	baseTx.Gas = gas
+	txBytes, addresses, err := b.buildAirdropBatch(report, recipients, sender, baseTx)
+	if err != nil {
+		return err
+	}
+
+	entry := AirdropJournalEntry{
+		Batch:      batch,
+		Status:     AirdropSigned,
+		Recipients: addresses,
+		Hash:       strings.ToUpper(hex.EncodeToString(tmhash.Sum(txBytes))),
+		Sequence:   account.Sequence,
+		Tx:         txBytes,
+	}
+	if journal != nil {
+		if err := journal.Append(entry); err != nil {
+			return err
+		}
+	}
+
+	height, rejected, err := b.broadcastAirdropBatch(txBytes)
+	entry.Height, entry.Tx = height, nil
+	switch {
+	case err == nil:
+		entry.Status = AirdropPaid
+		account.Sequence++
+	case rejected:
+		entry.Status, entry.Error = AirdropFailed, err.Error()
+	default:
+		// the outcome is unknown, it is resolved by the next run
+		for _, i := range recipients {
+			report.Results[i].Status, report.Results[i].Batch, report.Results[i].Hash = AirdropSigned, batch, entry.Hash
+		}
+		return fmt.Errorf("the outcome of batch %d, tx %s, is unknown: %w", batch, entry.Hash, err)
+	}
+
+	if journal != nil {
+		if err := journal.Append(entry); err != nil {
+			return err
+		}
+	}
+	for _, i := range recipients {
+		result := &report.Results[i]
+		result.Status, result.Batch, result.Hash, result.Height, result.Error = entry.Status, batch, entry.Hash, entry.Height, entry.Error
+	}
+	if rejected {
+		return fmt.Errorf("batch %d failed: %w", batch, err)
+	}
+	return nil
+}
+
+// broadcastAirdropBatch broadcasts the signed tx and waits for its commit, rejected is true when the chain
+// rejected the tx, so that its recipients weren't paid
+func (b bankClient) broadcastAirdropBatch(tx []byte) (height int64, rejected bool, err error) {
+	res, err := b.BroadcastTxCommit(context.Background(), tx)
+	if err != nil {
+		return 0, false, err
+	}
+	if !res.CheckTx.IsOK() {
+		return 0, true, sdk.GetError(res.CheckTx.Codespace, res.CheckTx.Code, res.CheckTx.Log)
+	}
+	if !res.DeliverTx.IsOK() {
+		return res.Height, true, sdk.GetError(res.DeliverTx.Codespace, res.DeliverTx.Code, res.DeliverTx.Log)
+	}
+	return res.Height, false, nil
+}
+
+// reconcile sums the amounts paid by this run and the previous ones
+func (r AirdropReport) reconcile() AirdropReport {
+	r.Paid = sdk.NewCoins()
+	for _, result := range r.Results {
+		if result.Status == AirdropPaid || result.Status == AirdropSkipped {
+			r.Paid = r.Paid.Add(result.Amount...)
+		}
+	}
+	r.Unpaid = r.Total.Sub(r.Paid)
+	return r
+}
+
+// Count returns the number of recipients of the status
+func (r AirdropReport) Count(status AirdropStatus) int {
+	var count int
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteCSV writes the results as CSV, with a header line
+func (r AirdropReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(airdropCSVHeader); err != nil {
+		return err
+	}
+	for _, result := range r.Results {
+		err := writer.Write([]string{
+			result.Address,
+			result.Amount.String(),
+			string(result.Status),
+			strconv.Itoa(result.Batch),
+			result.Hash,
+			strconv.FormatInt(result.Height, 10),
+			result.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+var _ AirdropJournal = FileAirdropJournal{}
+
+// FileAirdropJournal appends the entries as JSON lines to a file, every entry is synced to the disk
+type FileAirdropJournal struct {
+	filename string
+}
+
+func NewFileAirdropJournal(filename string) FileAirdropJournal {
+	return FileAirdropJournal{filename: filename}
+}
+
+// Entries returns the entries of the file, an incomplete last line is ignored: the process stopped while
+// writing it, so its batch wasn't broadcast or is resolved from its previous entry
+func (j FileAirdropJournal) Entries() ([]AirdropJournalEntry, error) {
+	file, err := os.Open(j.filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []AirdropJournalEntry
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		bz, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var entry AirdropJournalEntry
+		if err := json.Unmarshal(bytes.TrimSpace(bz), &entry); err != nil {
+			return nil, fmt.Errorf("invalid journal entry at line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func (j FileAirdropJournal) Append(entry AirdropJournalEntry) error {
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(bz, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
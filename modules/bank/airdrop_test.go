@@ -0,0 +1,125 @@
+package bank_test
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// failingJournal writes the entries and fails once the entry number fail is written
+type failingJournal struct {
+	bank.FileAirdropJournal
+	count, fail int
+}
+
+func (j *failingJournal) Append(entry bank.AirdropJournalEntry) error {
+	if err := j.FileAirdropJournal.Append(entry); err != nil {
+		return err
+	}
+	if j.count++; j.count == j.fail {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
+func TestAirdrop(t *testing.T) {
+	chain, client, _, _ := fakechaintest.Setup(t)
+
+	var csv strings.Builder
+	csv.WriteString("address,amount\n")
+	recipients := make([]string, 7)
+	for i := range recipients {
+		recipients[i] = types.AccAddress(bytes.Repeat([]byte{byte(i + 1)}, 20)).String()
+		csv.WriteString(recipients[i] + "," + strconv.Itoa(i+1) + ".5iris\n")
+	}
+	receipts, err := bank.ParseAirdropCSV(strings.NewReader(csv.String()))
+	require.NoError(t, err)
+	require.Len(t, receipts, 7)
+
+	fromJSON, err := bank.ParseAirdropJSON(strings.NewReader(`[{"address": "` + recipients[0] + `", "amount": "1.5iris"}]`))
+	require.NoError(t, err)
+	require.Equal(t, receipts[:1], fromJSON)
+
+	// the receipts are validated up front
+	upper := bank.Receipt{Address: strings.ToUpper(recipients[2]), Amount: receipts[2].Amount}
+	_, sdkErr := client.Bank.Airdrop(append(receipts, bank.Receipt{Address: "invalid", Amount: receipts[0].Amount}, receipts[1], upper), fakechaintest.BaseTx("alice"), bank.AirdropOptions{})
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "#8: invalid address invalid")
+	require.Contains(t, sdkErr.Error(), "#9: duplicated address "+recipients[1])
+	require.Contains(t, sdkErr.Error(), "#10: duplicated address "+recipients[2])
+
+	height := chain.Height()
+	_, sdkErr = client.Bank.Airdrop([]bank.Receipt{{Address: recipients[0], Amount: types.NewDecCoins(types.NewInt64DecCoin("iris", 2000000))}}, fakechaintest.BaseTx("alice"), bank.AirdropOptions{})
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "insufficient funds")
+
+	report, sdkErr := client.Bank.Airdrop(receipts, fakechaintest.BaseTx("alice"), bank.AirdropOptions{BatchSize: 3, DryRun: true})
+	require.NoError(t, sdkErr)
+	require.Equal(t, 7, report.Count(bank.AirdropPending))
+	require.Equal(t, 3, report.Results[6].Batch)
+	require.Equal(t, "31500000uiris", report.Total.String())
+	require.True(t, report.Paid.Empty())
+	require.Equal(t, height, chain.Height())
+
+	// the batches are halved until their simulated gas fits MaxGas
+	report, sdkErr = client.Bank.Airdrop(receipts, fakechaintest.BaseTx("alice"), bank.AirdropOptions{MaxGas: 75000, DryRun: true})
+	require.NoError(t, sdkErr)
+	require.Equal(t, 2, report.Results[3].Batch)
+	require.Equal(t, 3, report.Results[6].Batch)
+
+	_, sdkErr = client.Bank.Airdrop(receipts, fakechaintest.BaseTx("alice"), bank.AirdropOptions{MaxGas: 70000, DryRun: true})
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "the tx of recipient "+recipients[0]+" needs")
+
+	// the default fee of the config is required when baseTx has none
+	_, sdkErr = client.Bank.Airdrop([]bank.Receipt{{Address: recipients[0], Amount: types.NewDecCoins(types.NewInt64DecCoin("iris", 999997))}}, fakechaintest.BaseTx("alice"), bank.AirdropOptions{DryRun: true})
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "insufficient funds: 1000001000000uiris required")
+
+	// the process stops after the second batch is journaled, before it is broadcast
+	filename := filepath.Join(t.TempDir(), "airdrop.journal")
+	journal := &failingJournal{FileAirdropJournal: bank.NewFileAirdropJournal(filename), fail: 3}
+	report, sdkErr = client.Bank.Airdrop(receipts, fakechaintest.BaseTx("alice"), bank.AirdropOptions{BatchSize: 3, Journal: journal})
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "disk full")
+	require.Equal(t, 3, report.Count(bank.AirdropPaid))
+	require.Equal(t, height+1, chain.Height())
+
+	// the rerun broadcasts the journaled tx of the second batch and pays the rest
+	report, sdkErr = client.Bank.Airdrop(receipts, fakechaintest.BaseTx("alice"), bank.AirdropOptions{BatchSize: 3, Journal: bank.NewFileAirdropJournal(filename)})
+	require.NoError(t, sdkErr)
+	require.Equal(t, 6, report.Count(bank.AirdropSkipped))
+	require.Equal(t, 1, report.Count(bank.AirdropPaid))
+	require.Equal(t, 3, report.Results[6].Batch)
+	require.Equal(t, report.Total, report.Paid)
+	require.True(t, report.Unpaid.Empty())
+	require.Equal(t, height+3, chain.Height())
+	for i, recipient := range recipients {
+		require.Equal(t, strconv.Itoa(i+1)+"500000", chain.Balances(recipient).AmountOf(types.BaseDenom).String())
+	}
+
+	// nothing is paid twice, even to the addresses spelled in upper case
+	for i := range receipts {
+		receipts[i].Address = strings.ToUpper(receipts[i].Address)
+	}
+	report, sdkErr = client.Bank.Airdrop(receipts, fakechaintest.BaseTx("alice"), bank.AirdropOptions{BatchSize: 3, Journal: bank.NewFileAirdropJournal(filename)})
+	require.NoError(t, sdkErr)
+	require.Equal(t, 7, report.Count(bank.AirdropSkipped))
+	require.Equal(t, height+3, chain.Height())
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteCSV(&out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 8)
+	require.Equal(t, "address,amount,status,batch,hash,height,error", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], recipients[0]+",1500000uiris,skipped,1,"), lines[1])
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/codec"
 	"github.com/irisnet/irishub-sdk-go/codec/types"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
 )
 
 type bankClient struct {
@@ -60,6 +61,164 @@ func (b bankClient) TotalSupply() (sdk.Coins, sdk.Error) {
 	return resp.Supply, nil
 }
 
+// IterateBalances returns an iterator over all the balances of the address, the items are sdk.Coin
+func (b bankClient) IterateBalances(address string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := b.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).AllBalances(
+			context.Background(),
+			&QueryAllBalancesRequest{
+				Address:    address,
+				Pagination: pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.Balances))
+		for i, balance := range resp.Balances {
+			items[i] = balance
+		}
+		return items, resp.Pagination, nil
+	})
+}
+
+// QueryBalance queries the balance of a single coin for a single account
+func (b bankClient) QueryBalance(address, denom string) (sdk.DecCoin, sdk.Error) {
+	conn, err := b.GenConn()
+	defer func() { _ = conn.Close() }()
+	if err != nil {
+		return sdk.DecCoin{}, sdk.Wrap(err)
+	}
+
+	resp, err := NewQueryClient(conn).Balance(
+		context.Background(),
+		&QueryBalanceRequest{
+			Address: address,
+			Denom:   denom,
+		},
+	)
+	if err != nil {
+		return sdk.DecCoin{}, sdk.Wrap(err)
+	}
+
+	balance := sdk.NewCoin(denom, sdk.ZeroInt())
+	if resp.Balance != nil {
+		balance = *resp.Balance
+	}
+	return b.toMainCoin(balance)
+}
+
+// QuerySupplyOf queries the supply of a single coin
+func (b bankClient) QuerySupplyOf(denom string) (sdk.DecCoin, sdk.Error) {
+	conn, err := b.GenConn()
+	defer func() { _ = conn.Close() }()
+	if err != nil {
+		return sdk.DecCoin{}, sdk.Wrap(err)
+	}
+
+	resp, err := NewQueryClient(conn).SupplyOf(
+		context.Background(),
+		&QuerySupplyOfRequest{Denom: denom},
+	)
+	if err != nil {
+		return sdk.DecCoin{}, sdk.Wrap(err)
+	}
+	return b.toMainCoin(resp.Amount)
+}
+
+// QueryParams queries the parameters of the bank module
+func (b bankClient) QueryParams() (QueryParamsResp, sdk.Error) {
+	conn, err := b.GenConn()
+	defer func() { _ = conn.Close() }()
+	if err != nil {
+		return QueryParamsResp{}, sdk.Wrap(err)
+	}
+
+	resp, err := NewQueryClient(conn).Params(
+		context.Background(),
+		&QueryParamsRequest{},
+	)
+	if err != nil {
+		return QueryParamsResp{}, sdk.Wrap(err)
+	}
+	return resp.Convert().(QueryParamsResp), nil
+}
+
+// QueryDenomMetadata queries the client metadata of a given coin denomination
+func (b bankClient) QueryDenomMetadata(denom string) (Metadata, sdk.Error) {
+	conn, err := b.GenConn()
+	defer func() { _ = conn.Close() }()
+	if err != nil {
+		return Metadata{}, sdk.Wrap(err)
+	}
+
+	resp, err := NewQueryClient(conn).DenomMetadata(
+		context.Background(),
+		&QueryDenomMetadataRequest{Denom: denom},
+	)
+	if err != nil {
+		return Metadata{}, sdk.Wrap(err)
+	}
+	return resp.Metadata, nil
+}
+
+// QueryDenomsMetadata queries the client metadata of all the registered coin denominations
+func (b bankClient) QueryDenomsMetadata() ([]Metadata, sdk.Error) {
+	var metadatas []Metadata
+	err := b.IterateDenomsMetadata().ForEach(func(item interface{}) bool {
+		metadatas = append(metadatas, item.(Metadata))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metadatas, nil
+}
+
+// IterateDenomsMetadata returns an iterator over the metadata of every denom, the items are Metadata
+func (b bankClient) IterateDenomsMetadata() *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := b.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).DenomsMetadata(
+			context.Background(),
+			&QueryDenomsMetadataRequest{Pagination: pageReq},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.Metadatas))
+		for i, metadata := range resp.Metadatas {
+			items[i] = metadata
+		}
+		return items, resp.Pagination, nil
+	})
+}
+
+// toMainCoin converts the coin of the min denom to the main unit of its token
+func (b bankClient) toMainCoin(coin sdk.Coin) (sdk.DecCoin, sdk.Error) {
+	coins, err := b.ToMainCoin(coin)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	if len(coins) != 1 {
+		return sdk.DecCoin{}, sdk.Wrapf("failed to convert %s to main unit", coin)
+	}
+	return coins[0], nil
+}
+
 // Send is responsible for transferring tokens from `From` to `to` account
 func (b bankClient) Send(to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := b.QueryAddress(baseTx.From, baseTx.Password)
@@ -166,8 +325,8 @@ func (b bankClient) SendBatch(sender sdk.AccAddress,
 	return b.BaseClient.SendBatch(msgs, baseTx)
 }
 
-// SubscribeSendTx Subscribe MsgSend event and return subscription
-func (b bankClient) SubscribeSendTx(from, to string, callback EventMsgSendCallback) sdk.Subscription {
+// SubscribeSendTx Subscribe MsgSend and MsgMultiSend events and return subscription
+func (b bankClient) SubscribeSendTx(from, to string, callback EventMsgSendCallback) (sdk.Subscription, sdk.Error) {
 	var builder = sdk.NewEventQueryBuilder()
 
 	from = strings.TrimSpace(from)
@@ -181,18 +340,20 @@ func (b bankClient) SubscribeSendTx(from, to string, callback EventMsgSendCallba
 		builder.AddCondition(sdk.Cond("transfer.recipient").EQ(sdk.EventValue(to)))
 	}
 
-	subscription, _ := b.SubscribeTx(builder, func(data sdk.EventDataTx) {
+	return b.SubscribeTx(builder, func(data sdk.EventDataTx) {
 		for _, msg := range data.Tx.GetMsgs() {
-			if value, ok := msg.(*MsgSend); ok {
+			for _, output := range sendOutputs(msg) {
+				if len(to) != 0 && output.to != to {
+					continue
+				}
 				callback(EventDataMsgSend{
 					Height: data.Height,
 					Hash:   data.Hash,
-					From:   value.FromAddress,
-					To:     value.ToAddress,
-					Amount: value.Amount,
+					From:   output.from,
+					To:     output.to,
+					Amount: output.amount,
 				})
 			}
 		}
 	})
-	return subscription
 }
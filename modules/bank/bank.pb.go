@@ -327,6 +327,11 @@ type Metadata struct {
 	// display indicates the suggested denom that should be
 	// displayed in clients.
 	Display string `protobuf:"bytes,4,opt,name=display,proto3" json:"display,omitempty"`
+	// name defines the name of the token (eg: Cosmos Atom)
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// symbol is the token symbol usually shown on exchanges (eg: ATOM). This can
+	// be the same as the display.
+	Symbol string `protobuf:"bytes,6,opt,name=symbol,proto3" json:"symbol,omitempty"`
 }
 
 func (m *Metadata) Reset()         { *m = Metadata{} }
@@ -390,6 +395,20 @@ func (m *Metadata) GetDisplay() string {
 	return ""
 }
 
+func (m *Metadata) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Metadata) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "cosmos.bank.v1beta1.Params")
 	proto.RegisterType((*SendEnabled)(nil), "cosmos.bank.v1beta1.SendEnabled")
@@ -403,44 +422,45 @@ func init() {
 func init() { proto.RegisterFile("cosmos/bank/v1beta1/bank.proto", fileDescriptor_dd052eee12edf988) }
 
 var fileDescriptor_dd052eee12edf988 = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0x3f, 0x8b, 0x13, 0x4f,
-	0x18, 0xde, 0xb9, 0xdc, 0xe5, 0x97, 0x9b, 0xfc, 0x6c, 0xd6, 0x14, 0x7b, 0x01, 0x77, 0xd7, 0x05,
-	0x21, 0x8a, 0xd9, 0x70, 0x8a, 0x4d, 0x1a, 0x25, 0x2a, 0x7a, 0x85, 0x78, 0xec, 0x21, 0x82, 0x16,
-	0x61, 0x36, 0x33, 0xc9, 0x0d, 0xb7, 0x3b, 0xb3, 0xec, 0xcc, 0x8a, 0xf9, 0x06, 0x96, 0x82, 0x16,
-	0x82, 0xcd, 0xd5, 0xd6, 0x7e, 0x02, 0xab, 0x2b, 0x0f, 0x6d, 0xac, 0xa2, 0x24, 0x8d, 0xf5, 0x7d,
-	0x02, 0x99, 0x99, 0xcd, 0x9f, 0x83, 0x88, 0x58, 0x08, 0x56, 0x3b, 0xef, 0xbc, 0xcf, 0xfb, 0x3c,
-	0x0f, 0xef, 0xfb, 0xce, 0x42, 0x77, 0xc0, 0x45, 0xca, 0x45, 0x27, 0x46, 0xec, 0xa8, 0xf3, 0x62,
-	0x37, 0x26, 0x12, 0xed, 0xea, 0x20, 0xcc, 0x72, 0x2e, 0xb9, 0x7d, 0xd1, 0xe4, 0x43, 0x7d, 0x55,
-	0xe6, 0x9b, 0x8d, 0x11, 0x1f, 0x71, 0x9d, 0xef, 0xa8, 0x93, 0x81, 0x36, 0x77, 0x0c, 0xb4, 0x6f,
-	0x12, 0x65, 0x9d, 0x49, 0x2d, 0x55, 0x04, 0x59, 0xa8, 0x0c, 0x38, 0x65, 0x26, 0x1f, 0x7c, 0x01,
-	0xb0, 0xba, 0x8f, 0x72, 0x94, 0x0a, 0x7b, 0x08, 0xff, 0x17, 0x84, 0xe1, 0x3e, 0x61, 0x28, 0x4e,
-	0x08, 0x76, 0x80, 0x5f, 0x69, 0xd5, 0x6f, 0xf8, 0xe1, 0x1a, 0x1f, 0xe1, 0x01, 0x61, 0xf8, 0xbe,
-	0xc1, 0xf5, 0x2e, 0x9f, 0x4d, 0xbc, 0x4b, 0x63, 0x94, 0x26, 0xdd, 0x60, 0xb5, 0xfe, 0x3a, 0x4f,
-	0xa9, 0x24, 0x69, 0x26, 0xc7, 0x41, 0x54, 0x17, 0x4b, 0xbc, 0xfd, 0x1c, 0x36, 0x30, 0x19, 0xa2,
-	0x22, 0x91, 0xfd, 0x73, 0x7a, 0x1b, 0x3e, 0x68, 0xd5, 0x7a, 0x57, 0xcf, 0x26, 0xde, 0x15, 0xc3,
-	0xb6, 0x0e, 0xb5, 0xca, 0x6a, 0x97, 0x80, 0x15, 0x33, 0xdd, 0xcd, 0x77, 0xc7, 0x9e, 0x15, 0x3c,
-	0x80, 0xf5, 0x95, 0x4b, 0xbb, 0x01, 0xb7, 0x30, 0x61, 0x3c, 0x75, 0x80, 0x0f, 0x5a, 0xdb, 0x91,
-	0x09, 0x6c, 0x07, 0xfe, 0x77, 0x4e, 0x3a, 0x9a, 0x87, 0xdd, 0x9a, 0x22, 0xf9, 0x71, 0xec, 0x81,
-	0xe0, 0x0d, 0x80, 0x5b, 0x7b, 0x2c, 0x2b, 0xa4, 0x42, 0x23, 0x8c, 0x73, 0x22, 0x44, 0xc9, 0x32,
-	0x0f, 0xed, 0x21, 0xdc, 0x52, 0x0d, 0x15, 0xce, 0x86, 0x6e, 0xd8, 0xce, 0xb2, 0x61, 0x82, 0x2c,
-	0x1a, 0x76, 0x97, 0x53, 0xd6, 0xbb, 0x75, 0x32, 0xf1, 0xac, 0x0f, 0xdf, 0xbc, 0xf6, 0x88, 0xca,
-	0xc3, 0x22, 0x0e, 0x07, 0x3c, 0xed, 0xd0, 0x9c, 0x0a, 0x46, 0xa4, 0xfe, 0x1e, 0x16, 0x71, 0x5b,
-	0xe0, 0xa3, 0xf6, 0x88, 0x77, 0xe4, 0x38, 0x23, 0x42, 0x57, 0x89, 0xc8, 0xd0, 0x77, 0x6b, 0xaf,
-	0x8c, 0x2b, 0x2b, 0x78, 0x0b, 0x60, 0xf5, 0x71, 0x21, 0xff, 0x35, 0x5b, 0x9f, 0x00, 0xac, 0x1e,
-	0x14, 0x59, 0x96, 0x8c, 0x95, 0xb8, 0xe4, 0x12, 0x25, 0xe5, 0x12, 0xfd, 0x05, 0x71, 0x4d, 0xdf,
-	0xdd, 0x57, 0xe2, 0xf3, 0x69, 0x7d, 0xfe, 0xd8, 0xbe, 0x73, 0xed, 0xf7, 0x34, 0x29, 0xc7, 0x45,
-	0x42, 0xca, 0x87, 0x47, 0x5e, 0x66, 0x3c, 0x97, 0x04, 0x87, 0xc6, 0xf8, 0x5e, 0xf0, 0x14, 0x6e,
-	0xdf, 0x53, 0xeb, 0xf1, 0x84, 0x51, 0xf9, 0x8b, 0xc5, 0x69, 0xc2, 0x9a, 0x2a, 0x63, 0x84, 0x49,
-	0xbd, 0x39, 0x17, 0xa2, 0x45, 0xac, 0xe7, 0x91, 0x50, 0x24, 0x88, 0x70, 0x2a, 0x7e, 0x45, 0xcf,
-	0xc3, 0x84, 0xc1, 0x7b, 0x00, 0x6b, 0x8f, 0x88, 0x44, 0x18, 0x49, 0x64, 0xfb, 0xb0, 0x8e, 0x89,
-	0x18, 0xe4, 0x34, 0x93, 0x94, 0xb3, 0x92, 0x7e, 0xf5, 0xca, 0xbe, 0xad, 0x10, 0x8c, 0xa7, 0xfd,
-	0x82, 0x51, 0x39, 0x1f, 0xa2, 0xbb, 0xf6, 0x31, 0x2e, 0xfc, 0x46, 0x10, 0xcf, 0x8f, 0xc2, 0xb6,
-	0xe1, 0xa6, 0xea, 0xb6, 0x53, 0xd1, 0xdc, 0xfa, 0xac, 0xdc, 0x61, 0x2a, 0xb2, 0x04, 0x8d, 0x9d,
-	0x4d, 0xb3, 0x2d, 0x65, 0xd8, 0x7b, 0x78, 0x32, 0x75, 0xc1, 0xe9, 0xd4, 0x05, 0xdf, 0xa7, 0x2e,
-	0x78, 0x3d, 0x73, 0xad, 0xd3, 0x99, 0x6b, 0x7d, 0x9d, 0xb9, 0xd6, 0xb3, 0xf0, 0xcf, 0x3a, 0x1a,
-	0x57, 0xf5, 0x8f, 0xe5, 0xe6, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x87, 0x74, 0xf2, 0x72, 0xe0,
-	0x04, 0x00, 0x00,
+	// 594 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0x31, 0x6b, 0x14, 0x41,
+	0x14, 0xbe, 0xc9, 0xe5, 0xd6, 0xcb, 0x9c, 0x36, 0x63, 0x90, 0x4d, 0xc0, 0xdd, 0x75, 0x41, 0x88,
+	0xe2, 0xed, 0x11, 0xc5, 0xe6, 0x1a, 0xe5, 0x54, 0x34, 0x85, 0x18, 0x36, 0x88, 0xa0, 0xc5, 0x31,
+	0x7b, 0x33, 0xb9, 0x0c, 0xd9, 0x9d, 0x59, 0x76, 0x66, 0xc5, 0xfd, 0x07, 0x96, 0x82, 0x16, 0x96,
+	0xa9, 0xad, 0xfd, 0x05, 0x36, 0xa6, 0x0c, 0xda, 0x58, 0x45, 0x49, 0x1a, 0xeb, 0xfc, 0x02, 0x99,
+	0x99, 0xbd, 0xcb, 0x05, 0x4e, 0xc4, 0x42, 0xb0, 0xda, 0xf7, 0xbd, 0xf7, 0xbd, 0xef, 0x3d, 0xde,
+	0x7b, 0xb3, 0xd0, 0x1b, 0x09, 0x99, 0x09, 0xd9, 0x4b, 0x30, 0xdf, 0xed, 0xbd, 0x5c, 0x4f, 0xa8,
+	0xc2, 0xeb, 0x06, 0x44, 0x79, 0x21, 0x94, 0x40, 0x17, 0x6d, 0x3c, 0x32, 0xae, 0x3a, 0xbe, 0xba,
+	0x3c, 0x16, 0x63, 0x61, 0xe2, 0x3d, 0x6d, 0x59, 0xea, 0xea, 0x8a, 0xa5, 0x0e, 0x6d, 0xa0, 0xce,
+	0xb3, 0xa1, 0xd3, 0x2a, 0x92, 0x4e, 0xab, 0x8c, 0x04, 0xe3, 0x36, 0x1e, 0x7e, 0x05, 0xd0, 0xd9,
+	0xc4, 0x05, 0xce, 0x24, 0xda, 0x86, 0xe7, 0x25, 0xe5, 0x64, 0x48, 0x39, 0x4e, 0x52, 0x4a, 0x5c,
+	0x10, 0x34, 0xd7, 0x3a, 0x37, 0x83, 0x68, 0x4e, 0x1f, 0xd1, 0x16, 0xe5, 0xe4, 0x81, 0xe5, 0x0d,
+	0xae, 0x9c, 0x1c, 0xfa, 0x97, 0x2b, 0x9c, 0xa5, 0xfd, 0x70, 0x36, 0xff, 0x86, 0xc8, 0x98, 0xa2,
+	0x59, 0xae, 0xaa, 0x30, 0xee, 0xc8, 0x53, 0x3e, 0x7a, 0x01, 0x97, 0x09, 0xdd, 0xc6, 0x65, 0xaa,
+	0x86, 0x67, 0xea, 0x2d, 0x04, 0x60, 0xad, 0x3d, 0xb8, 0x76, 0x72, 0xe8, 0x5f, 0xb5, 0x6a, 0xf3,
+	0x58, 0xb3, 0xaa, 0xa8, 0x26, 0xcc, 0x34, 0xd3, 0x5f, 0x7c, 0xbf, 0xe7, 0x37, 0xc2, 0x87, 0xb0,
+	0x33, 0xe3, 0x44, 0xcb, 0xb0, 0x45, 0x28, 0x17, 0x99, 0x0b, 0x02, 0xb0, 0xb6, 0x14, 0x5b, 0x80,
+	0x5c, 0x78, 0xee, 0x4c, 0xe9, 0x78, 0x02, 0xfb, 0x6d, 0x2d, 0xf2, 0x73, 0xcf, 0x07, 0xe1, 0x5b,
+	0x00, 0x5b, 0x1b, 0x3c, 0x2f, 0x95, 0x66, 0x63, 0x42, 0x0a, 0x2a, 0x65, 0xad, 0x32, 0x81, 0x68,
+	0x1b, 0xb6, 0xf4, 0x40, 0xa5, 0xbb, 0x60, 0x06, 0xb6, 0x72, 0x3a, 0x30, 0x49, 0xa7, 0x03, 0xbb,
+	0x27, 0x18, 0x1f, 0xdc, 0xde, 0x3f, 0xf4, 0x1b, 0x1f, 0xbe, 0xfb, 0xdd, 0x31, 0x53, 0x3b, 0x65,
+	0x12, 0x8d, 0x44, 0xd6, 0x63, 0x05, 0x93, 0x9c, 0x2a, 0xf3, 0xdd, 0x29, 0x93, 0xae, 0x24, 0xbb,
+	0xdd, 0xb1, 0xe8, 0xa9, 0x2a, 0xa7, 0xd2, 0x64, 0xc9, 0xd8, 0xca, 0xf7, 0xdb, 0xaf, 0x6d, 0x57,
+	0x8d, 0xf0, 0x1d, 0x80, 0xce, 0x93, 0x52, 0xfd, 0x6f, 0x6d, 0x7d, 0x02, 0xd0, 0xd9, 0x2a, 0xf3,
+	0x3c, 0xad, 0x74, 0x71, 0x25, 0x14, 0x4e, 0x5d, 0xf0, 0xaf, 0x8a, 0x1b, 0xf9, 0xfe, 0xa6, 0x2e,
+	0x3e, 0xd9, 0xd6, 0x97, 0x8f, 0xdd, 0xbb, 0xd7, 0xff, 0x2c, 0x93, 0x09, 0x52, 0xa6, 0xb4, 0x7e,
+	0x78, 0xf4, 0x55, 0x2e, 0x0a, 0x45, 0x49, 0x64, 0x1b, 0xdf, 0x08, 0x9f, 0xc1, 0xa5, 0xfb, 0xfa,
+	0x3c, 0x9e, 0x72, 0xa6, 0x7e, 0x73, 0x38, 0xab, 0xb0, 0xad, 0xd3, 0x38, 0xe5, 0xca, 0x5c, 0xce,
+	0x85, 0x78, 0x8a, 0xcd, 0x3e, 0x52, 0x86, 0x25, 0x95, 0x6e, 0x33, 0x68, 0x9a, 0x7d, 0x58, 0x18,
+	0x7e, 0x06, 0xb0, 0xfd, 0x98, 0x2a, 0x4c, 0xb0, 0xc2, 0x28, 0x80, 0x1d, 0x42, 0xe5, 0xa8, 0x60,
+	0xb9, 0x62, 0x82, 0xd7, 0xf2, 0xb3, 0x2e, 0x74, 0x47, 0x33, 0xb8, 0xc8, 0x86, 0x25, 0x67, 0x6a,
+	0xb2, 0x44, 0x6f, 0xee, 0x63, 0x9c, 0xf6, 0x1b, 0x43, 0x32, 0x31, 0x25, 0x42, 0x70, 0x51, 0x4f,
+	0xdb, 0x6d, 0x1a, 0x6d, 0x63, 0xeb, 0xee, 0x08, 0x93, 0x79, 0x8a, 0x2b, 0x77, 0xd1, 0x5e, 0x4b,
+	0x0d, 0x35, 0x9b, 0xe3, 0x8c, 0xba, 0x2d, 0xcb, 0xd6, 0x36, 0xba, 0x04, 0x1d, 0x59, 0x65, 0x89,
+	0x48, 0x5d, 0xc7, 0x78, 0x6b, 0x34, 0x78, 0xb4, 0x7f, 0xe4, 0x81, 0x83, 0x23, 0x0f, 0xfc, 0x38,
+	0xf2, 0xc0, 0x9b, 0x63, 0xaf, 0x71, 0x70, 0xec, 0x35, 0xbe, 0x1d, 0x7b, 0x8d, 0xe7, 0xd1, 0xdf,
+	0x4d, 0x3f, 0x71, 0xcc, 0x4f, 0xe8, 0xd6, 0xaf, 0x01, 0x00, 0xef, 0xf0, 0x6d, 0x8b, 0x0c, 0x05,
+	0x00, 0x00,
 }
 
 func (this *SendEnabled) Equal(that interface{}) bool {
@@ -775,6 +795,20 @@ func (m *Metadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Symbol) > 0 {
+		i -= len(m.Symbol)
+		copy(dAtA[i:], m.Symbol)
+		i = encodeVarintBank(dAtA, i, uint64(len(m.Symbol)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintBank(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Display) > 0 {
 		i -= len(m.Display)
 		copy(dAtA[i:], m.Display)
@@ -957,6 +991,14 @@ func (m *Metadata) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovBank(uint64(l))
 	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovBank(uint64(l))
+	}
+	l = len(m.Symbol)
+	if l > 0 {
+		n += 1 + l + sovBank(uint64(l))
+	}
 	return n
 }
 
@@ -1780,6 +1822,70 @@ func (m *Metadata) Unmarshal(dAtA []byte) error {
 			}
 			m.Display = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBank
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBank
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBank
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Symbol", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBank
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBank
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBank
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Symbol = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBank(dAtA[iNdEx:])
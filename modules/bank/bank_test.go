@@ -0,0 +1,47 @@
+package bank_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestBankQueries(t *testing.T) {
+	_, client, alice, bob := fakechaintest.Setup(t)
+
+	balance, sdkErr := client.Bank.QueryBalance(alice, types.BaseDenom)
+	require.NoError(t, sdkErr)
+	require.Equal(t, "iris", balance.Denom)
+	require.Equal(t, "1000000.000000000000000000", balance.Amount.String())
+
+	balance, sdkErr = client.Bank.QueryBalance(bob, types.BaseDenom)
+	require.NoError(t, sdkErr)
+	require.True(t, balance.IsZero())
+
+	supply, sdkErr := client.Bank.QuerySupplyOf(types.BaseDenom)
+	require.NoError(t, sdkErr)
+	require.Equal(t, "iris", supply.Denom)
+	require.True(t, supply.Amount.GTE(types.NewDec(1000000)))
+
+	params, sdkErr := client.Bank.QueryParams()
+	require.NoError(t, sdkErr)
+	require.True(t, params.DefaultSendEnabled)
+
+	metadata, sdkErr := client.Bank.QueryDenomMetadata(types.BaseDenom)
+	require.NoError(t, sdkErr)
+	require.Equal(t, types.BaseDenom, metadata.Base)
+	require.Equal(t, "iris", metadata.Display)
+	require.Len(t, metadata.DenomUnits, 2)
+	require.Equal(t, uint32(6), metadata.DenomUnits[1].Exponent)
+
+	_, sdkErr = client.Bank.QueryDenomMetadata("unknown")
+	require.Error(t, sdkErr)
+
+	metadatas, sdkErr := client.Bank.QueryDenomsMetadata()
+	require.NoError(t, sdkErr)
+	require.Len(t, metadatas, 1)
+	require.Equal(t, metadata, metadatas[0])
+}
@@ -0,0 +1,382 @@
+package bank
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/fileutil"
+)
+
+// blocksPerScan is the number of heights searched at once, the progress is saved after every scan
+const blocksPerScan = 1000
+
+// DepositWatcher reports the coins received by the watched addresses from the MsgSend, the MsgMultiSend outputs
+// and the transfer events of any other msg, once they have the required confirmations. The progress is saved
+// after the deposits of every height are handled, so that nothing is missed across restarts. A deposit can be
+// reported twice when the process stops between its handling and the save, its Hash, MsgIndex and To identify it.
+type DepositWatcher struct {
+	bank      bankClient
+	opts      DepositWatcherOptions
+	addresses map[string]bool
+
+	mu         sync.Mutex
+	lastHeight int64
+}
+
+func (b bankClient) NewDepositWatcher(opts DepositWatcherOptions) (*DepositWatcher, sdk.Error) {
+	var (
+		addresses = make(map[string]bool, len(opts.Addresses))
+		watched   = make([]string, 0, len(opts.Addresses))
+	)
+	for _, address := range opts.Addresses {
+		address = strings.TrimSpace(address)
+		if _, err := sdk.AccAddressFromBech32(address); err != nil {
+			return nil, sdk.Wrapf("%s invalid address", address)
+		}
+		if !addresses[address] {
+			addresses[address] = true
+			watched = append(watched, address)
+		}
+	}
+	if len(watched) == 0 {
+		return nil, sdk.Wrapf("no address to watch")
+	}
+	opts.Addresses = watched
+
+	if opts.Confirmations <= 0 {
+		opts.Confirmations = 1
+	}
+	if opts.StartHeight <= 0 {
+		opts.StartHeight = 1
+	}
+	if opts.Attribute == nil {
+		opts.Attribute = func(_, memo string) string {
+			return strings.TrimSpace(memo)
+		}
+	}
+
+	lastHeight := opts.StartHeight - 1
+	if opts.Store != nil {
+		height, err := opts.Store.LastHeight()
+		if err != nil {
+			return nil, sdk.WrapWithMessage(err, "failed to load the deposit progress")
+		}
+		if height > 0 {
+			lastHeight = height
+		}
+	}
+
+	return &DepositWatcher{
+		bank:       b,
+		opts:       opts,
+		addresses:  addresses,
+		lastHeight: lastHeight,
+	}, nil
+}
+
+// LastHeight returns the last height whose deposits were all handled
+func (w *DepositWatcher) LastHeight() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastHeight
+}
+
+// Poll reports the deposits confirmed since the last poll to the handler and returns the last height handled.
+// When the handler fails, the poll stops and the deposits from the failed one are reported again by the next poll.
+func (w *DepositWatcher) Poll(handler DepositHandler) (int64, sdk.Error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status, err := w.bank.Status(context.Background())
+	if err != nil {
+		return w.lastHeight, sdk.Wrap(err)
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+	confirmed := latest - w.opts.Confirmations + 1
+
+	for w.lastHeight < confirmed {
+		from, to := w.lastHeight+1, w.lastHeight+blocksPerScan
+		if to > confirmed {
+			to = confirmed
+		}
+
+		deposits, err := w.scan(from, to, latest)
+		if err != nil {
+			return w.lastHeight, err
+		}
+		for _, deposit := range deposits {
+			if err := handler(deposit); err != nil {
+				if err := w.save(deposit.Height - 1); err != nil {
+					return w.lastHeight, err
+				}
+				return w.lastHeight, sdk.WrapWithMessage(err, "failed to handle the deposit of tx %s", deposit.Hash)
+			}
+		}
+		if err := w.save(to); err != nil {
+			return w.lastHeight, err
+		}
+	}
+	return w.lastHeight, nil
+}
+
+// Run polls every interval until the context is done, a failed poll is logged and retried at the next interval
+func (w *DepositWatcher) Run(ctx context.Context, interval time.Duration, handler DepositHandler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if height, err := w.Poll(handler); err != nil {
+			w.bank.Logger().Error("failed to poll the deposits", "height", height, "err", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// save saves the progress when the height is above the last height
+func (w *DepositWatcher) save(height int64) sdk.Error {
+	if height <= w.lastHeight {
+		return nil
+	}
+	if w.opts.Store != nil {
+		if err := w.opts.Store.SaveLastHeight(height); err != nil {
+			return sdk.WrapWithMessage(err, "failed to save the deposit progress")
+		}
+	}
+	w.lastHeight = height
+	return nil
+}
+
+// scan returns the deposits of the heights from `from` to `to`, in the order of the heights
+func (w *DepositWatcher) scan(from, to, latest int64) ([]Deposit, sdk.Error) {
+	var (
+		seen = make(map[string]bool)
+		txs  []sdk.ResultQueryTx
+	)
+	for _, address := range w.opts.Addresses {
+		builder := sdk.NewEventQueryBuilder().
+			AddCondition(sdk.NewCond("transfer", "recipient").EQ(sdk.EventValue(address))).
+			AddCondition(sdk.NewCond("tx", "height").GTE(from)).
+			AddCondition(sdk.NewCond("tx", "height").LTE(to))
+
+		err := w.bank.IterateTxs(builder).ForEach(func(item interface{}) bool {
+			tx := item.(sdk.ResultQueryTx)
+			if !seen[tx.Hash] {
+				seen[tx.Hash] = true
+				txs = append(txs, tx)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortTxs(txs)
+
+	var deposits []Deposit
+	for _, tx := range txs {
+		deposits = append(deposits, w.deposits(tx, latest)...)
+	}
+	return deposits, nil
+}
+
+// deposits returns the deposits of the tx to the watched addresses, the transfer events of the MsgSend
+// and the MsgMultiSend outputs aren't reported twice
+func (w *DepositWatcher) deposits(tx sdk.ResultQueryTx, latest int64) []Deposit {
+	if tx.Result.Code != 0 {
+		return nil
+	}
+
+	transfers := parseTransfers(tx.Result.Events)
+	matched := make([]bool, len(transfers))
+	match := func(to string, amount sdk.Coins) {
+		for i, t := range transfers {
+			if !matched[i] && t.recipient == to && t.amount.String() == amount.String() {
+				matched[i] = true
+				return
+			}
+		}
+	}
+
+	var deposits []Deposit
+	for i, msg := range tx.Tx.GetMsgs() {
+		for _, output := range sendOutputs(msg) {
+			if !w.addresses[output.to] {
+				continue
+			}
+			match(output.to, output.amount)
+			deposits = append(deposits, Deposit{
+				MsgIndex: i,
+				Source:   output.source,
+				From:     output.from,
+				To:       output.to,
+				Amount:   output.amount,
+			})
+		}
+	}
+	for i, t := range transfers {
+		if matched[i] || !w.addresses[t.recipient] || t.amount.Empty() {
+			continue
+		}
+		deposits = append(deposits, Deposit{
+			MsgIndex: -1,
+			Source:   DepositTransfer,
+			From:     t.sender,
+			To:       t.recipient,
+			Amount:   t.amount,
+		})
+	}
+
+	var memo string
+	if tx, ok := tx.Tx.(sdk.TxWithMemo); ok {
+		memo = tx.GetMemo()
+	}
+	for i := range deposits {
+		deposits[i].Height = tx.Height
+		deposits[i].Hash = tx.Hash
+		deposits[i].Memo = memo
+		deposits[i].Account = w.opts.Attribute(deposits[i].To, memo)
+		deposits[i].Confirmations = latest - tx.Height + 1
+		deposits[i].Timestamp = tx.Timestamp
+	}
+	return deposits
+}
+
+// sortTxs sorts the txs merged from several queries in the order of the chain, by height and index in the block
+func sortTxs(txs []sdk.ResultQueryTx) {
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].Height != txs[j].Height {
+			return txs[i].Height < txs[j].Height
+		}
+		return txs[i].Index < txs[j].Index
+	})
+}
+
+// sendOutput is the coins sent to an address by a MsgSend or an output of a MsgMultiSend
+type sendOutput struct {
+	source DepositSource
+	// from is empty when the inputs of the MsgMultiSend have several addresses
+	from   string
+	to     string
+	amount sdk.Coins
+}
+
+func sendOutputs(msg sdk.Msg) []sendOutput {
+	switch msg := msg.(type) {
+	case *MsgSend:
+		return []sendOutput{{
+			source: DepositMsgSend,
+			from:   msg.FromAddress,
+			to:     msg.ToAddress,
+			amount: msg.Amount,
+		}}
+	case *MsgMultiSend:
+		var from string
+		for i, input := range msg.Inputs {
+			if i == 0 {
+				from = input.Address
+			} else if input.Address != from {
+				from = ""
+				break
+			}
+		}
+		outputs := make([]sendOutput, len(msg.Outputs))
+		for i, output := range msg.Outputs {
+			outputs[i] = sendOutput{
+				source: DepositMsgMultiSend,
+				from:   from,
+				to:     output.Address,
+				amount: output.Coins,
+			}
+		}
+		return outputs
+	}
+	return nil
+}
+
+// transfer is a transfer of a transfer event, the sender is empty for the outputs of a MsgMultiSend
+type transfer struct {
+	recipient string
+	sender    string
+	amount    sdk.Coins
+}
+
+// parseTransfers returns the transfers of the events, the attributes of the transfer events are flattened
+// into a single event, in the order recipient, sender and amount of every transfer
+func parseTransfers(events sdk.StringEvents) []transfer {
+	var transfers []transfer
+	for _, event := range events {
+		if event.Type != "transfer" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "recipient" {
+				transfers = append(transfers, transfer{recipient: attr.Value})
+				continue
+			}
+			if len(transfers) == 0 {
+				continue
+			}
+			last := &transfers[len(transfers)-1]
+			switch attr.Key {
+			case sdk.AttributeKeySender:
+				last.sender = attr.Value
+			case sdk.AttributeKeyAmount:
+				if amount, err := sdk.ParseCoins(attr.Value); err == nil {
+					last.amount = amount
+				}
+			}
+		}
+	}
+	return transfers
+}
+
+var _ DepositStore = FileDepositStore{}
+
+// FileDepositStore saves the progress of a DepositWatcher to a JSON file, which is replaced atomically
+type FileDepositStore struct {
+	filename string
+}
+
+func NewFileDepositStore(filename string) FileDepositStore {
+	return FileDepositStore{filename: filename}
+}
+
+type depositProgress struct {
+	LastHeight int64 `json:"last_height"`
+}
+
+func (s FileDepositStore) LastHeight() (int64, error) {
+	bz, err := ioutil.ReadFile(s.filename)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var progress depositProgress
+	if err := json.Unmarshal(bz, &progress); err != nil {
+		return 0, err
+	}
+	return progress.LastHeight, nil
+}
+
+func (s FileDepositStore) SaveLastHeight(height int64) error {
+	bz, err := json.Marshal(depositProgress{LastHeight: height})
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(s.filename, bz, 0600)
+}
@@ -0,0 +1,156 @@
+package bank_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/coinswap"
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestDepositWatcher(t *testing.T) {
+	chain, client, alice, bob := fakechaintest.Setup(t)
+	carol, _, sdkErr := client.Key.Add("carol", fakechaintest.Password)
+	require.NoError(t, sdkErr)
+
+	var subscribed []bank.EventDataMsgSend
+	_, sdkErr = client.Bank.SubscribeSendTx("", carol, func(send bank.EventDataMsgSend) {
+		subscribed = append(subscribed, send)
+	})
+	require.NoError(t, sdkErr)
+
+	store := bank.NewFileDepositStore(filepath.Join(t.TempDir(), "deposits.json"))
+	opts := bank.DepositWatcherOptions{
+		Addresses:     []string{bob, carol},
+		Confirmations: 2,
+		Store:         store,
+	}
+	watcher, sdkErr := client.Bank.NewDepositWatcher(opts)
+	require.NoError(t, sdkErr)
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	tx := fakechaintest.BaseTx("alice")
+	tx.Memo = " user-1 "
+	sent, sdkErr := client.Bank.Send(bob, amount, tx)
+	require.NoError(t, sdkErr)
+
+	tx.Memo = "user-2"
+	multiSent, sdkErr := client.Bank.MultiSend(bank.MultiSendRequest{Receipts: []bank.Receipt{
+		{Address: bob, Amount: types.NewDecCoins(types.NewInt64DecCoin("iris", 1))},
+		{Address: carol, Amount: types.NewDecCoins(types.NewInt64DecCoin("iris", 2))},
+	}}, tx)
+	require.NoError(t, sdkErr)
+	require.Len(t, multiSent, 1)
+
+	require.Len(t, subscribed, 1)
+	require.Equal(t, bank.EventDataMsgSend{
+		Height: multiSent[0].Height,
+		Hash:   multiSent[0].Hash,
+		From:   alice,
+		To:     carol,
+		Amount: types.NewCoins(types.NewInt64Coin(types.BaseDenom, 2000000)),
+	}, subscribed[0])
+
+	require.NoError(t, chain.AddToken(token.Token{
+		Symbol:        "btc",
+		Name:          "Bitcoin",
+		Scale:         8,
+		MinUnit:       "satoshi",
+		InitialSupply: 21000,
+		MaxSupply:     21000000,
+		Mintable:      true,
+		Owner:         alice,
+	}))
+	deadline := time.Now().Add(time.Hour).Unix()
+	_, err = client.Swap.AddLiquidity(coinswap.AddLiquidityRequest{
+		MaxToken:     types.NewInt64Coin("satoshi", 1000000000),
+		BaseAmt:      types.NewInt(1000000000),
+		MinLiquidity: types.NewInt(1000000000),
+		Deadline:     deadline,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	swapped, err := client.Swap.SwapCoin(coinswap.SwapCoinRequest{
+		Input:    types.NewInt64Coin(types.BaseDenom, 1000000),
+		Output:   types.NewInt64Coin("satoshi", 1),
+		Receiver: carol,
+		Deadline: deadline,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+
+	// the swap has a single confirmation
+	var deposits []bank.Deposit
+	handle := func(deposit bank.Deposit) error {
+		deposits = append(deposits, deposit)
+		return nil
+	}
+	height, sdkErr := watcher.Poll(handle)
+	require.NoError(t, sdkErr)
+	require.Equal(t, chain.Height()-1, height)
+	require.Len(t, deposits, 3)
+
+	require.Equal(t, bank.Deposit{
+		Height:        sent.Height,
+		Hash:          sent.Hash,
+		MsgIndex:      0,
+		Source:        bank.DepositMsgSend,
+		From:          alice,
+		To:            bob,
+		Amount:        types.NewCoins(types.NewInt64Coin(types.BaseDenom, 10000000)),
+		Memo:          " user-1 ",
+		Account:       "user-1",
+		Confirmations: chain.Height() - sent.Height + 1,
+		Timestamp:     deposits[0].Timestamp,
+	}, deposits[0])
+	require.NotEmpty(t, deposits[0].Timestamp)
+	for i, to := range []string{bob, carol} {
+		deposit := deposits[i+1]
+		require.Equal(t, multiSent[0].Hash, deposit.Hash)
+		require.Equal(t, bank.DepositMsgMultiSend, deposit.Source)
+		require.Equal(t, alice, deposit.From)
+		require.Equal(t, to, deposit.To)
+		require.Equal(t, "user-2", deposit.Account)
+	}
+
+	// the progress is saved
+	saved, err := store.LastHeight()
+	require.NoError(t, err)
+	require.Equal(t, height, saved)
+	_, sdkErr = client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, sdkErr)
+
+	// a failed deposit is reported again after a restart
+	watcher, sdkErr = client.Bank.NewDepositWatcher(opts)
+	require.NoError(t, sdkErr)
+	require.Equal(t, height, watcher.LastHeight())
+	_, sdkErr = watcher.Poll(func(bank.Deposit) error {
+		return errors.New("database unavailable")
+	})
+	require.Error(t, sdkErr)
+	require.Equal(t, height, watcher.LastHeight())
+
+	watcher, sdkErr = client.Bank.NewDepositWatcher(opts)
+	require.NoError(t, sdkErr)
+	deposits = nil
+	_, sdkErr = watcher.Poll(handle)
+	require.NoError(t, sdkErr)
+	require.Len(t, deposits, 1)
+	require.Equal(t, swapped.TxHash, deposits[0].Hash)
+	require.Equal(t, bank.DepositTransfer, deposits[0].Source)
+	require.Equal(t, -1, deposits[0].MsgIndex)
+	require.Equal(t, carol, deposits[0].To)
+	require.Equal(t, swapped.OutputAmt.String(), deposits[0].Amount.AmountOf("satoshi").String())
+	require.NotEmpty(t, deposits[0].From)
+	require.NotEqual(t, alice, deposits[0].From)
+	require.Equal(t, int64(2), deposits[0].Confirmations)
+
+	_, sdkErr = client.Bank.NewDepositWatcher(bank.DepositWatcherOptions{Addresses: []string{"invalid"}})
+	require.Error(t, sdkErr)
+}
@@ -1,6 +1,8 @@
 package bank
 
 import (
+	"time"
+
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 )
 
@@ -11,10 +13,39 @@ type Client interface {
 	Send(to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	SendWitchSpecAccountInfo(to string, sequence, accountNumber uint64, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	MultiSend(receipts MultiSendRequest, baseTx sdk.BaseTx) ([]sdk.ResultTx, sdk.Error)
-	SubscribeSendTx(from, to string, callback EventMsgSendCallback) sdk.Subscription
+	// SubscribeSendTx notifies the coins sent by the MsgSend and the MsgMultiSend outputs from `from` to `to`,
+	// either of them can be empty to match any address
+	SubscribeSendTx(from, to string, callback EventMsgSendCallback) (sdk.Subscription, sdk.Error)
+	// NewDepositWatcher returns the watcher of the coins received by the addresses, see DepositWatcher
+	NewDepositWatcher(opts DepositWatcherOptions) (*DepositWatcher, sdk.Error)
+	// QueryLedger returns the chronological balance changes of the address, from the txs it sent or received coins in
+	QueryLedger(address string, opts LedgerOptions) (Ledger, sdk.Error)
+	// CheckLedger compares the closing balances of the ledger to the balances of the account, the ledger
+	// must run up to the latest height
+	CheckLedger(ledger Ledger) ([]BalanceCheck, sdk.Error)
+	// Airdrop sends the amounts of the receipts from baseTx.From in MsgMultiSend txs, skipping the receipts
+	// already paid according to the journal, see AirdropOptions
+	Airdrop(receipts []Receipt, baseTx sdk.BaseTx, opts AirdropOptions) (AirdropReport, sdk.Error)
 
 	QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
+	// QueryBalance returns the balance of the min denom, e.g. uiris, of the address, in main unit
+	QueryBalance(address, denom string) (sdk.DecCoin, sdk.Error)
 	TotalSupply() (sdk.Coins, sdk.Error)
+	// QuerySupplyOf returns the supply of the min denom, in main unit
+	QuerySupplyOf(denom string) (sdk.DecCoin, sdk.Error)
+	QueryParams() (QueryParamsResp, sdk.Error)
+	// QueryDenomMetadata returns the metadata of the base denom, e.g. uiris
+	QueryDenomMetadata(denom string) (Metadata, sdk.Error)
+	QueryDenomsMetadata() ([]Metadata, sdk.Error)
+	IterateBalances(address string) *sdk.Iterator
+	// IterateDenomsMetadata returns an iterator over the metadata of every denom, the items are Metadata
+	IterateDenomsMetadata() *sdk.Iterator
+}
+
+type QueryParamsResp struct {
+	// SendEnabled are the denoms whose sending isn't DefaultSendEnabled
+	SendEnabled        []SendEnabled `json:"send_enabled"`
+	DefaultSendEnabled bool          `json:"default_send_enabled"`
 }
 
 type Receipt struct {
@@ -35,11 +66,178 @@ func (msr MultiSendRequest) Sub(begin, end int) sdk.SplitAble {
 }
 
 type EventDataMsgSend struct {
-	Height int64      `json:"height"`
-	Hash   string     `json:"hash"`
+	Height int64  `json:"height"`
+	Hash   string `json:"hash"`
+	// From is empty when the inputs of the MsgMultiSend have several addresses
 	From   string     `json:"from"`
 	To     string     `json:"to"`
 	Amount []sdk.Coin `json:"amount"`
 }
 
 type EventMsgSendCallback func(EventDataMsgSend)
+
+// DepositSource is how the coins of a deposit were received
+type DepositSource string
+
+const (
+	DepositMsgSend      DepositSource = "send"
+	DepositMsgMultiSend DepositSource = "multi_send"
+	// DepositTransfer is a transfer event emitted by any other msg, e.g. an ibc transfer or a swap
+	DepositTransfer DepositSource = "transfer"
+)
+
+// Deposit is the coins received by a watched address in a tx
+type Deposit struct {
+	Height int64  `json:"height"`
+	Hash   string `json:"hash"`
+	// MsgIndex is the index of the MsgSend or MsgMultiSend in the tx, -1 for a DepositTransfer
+	MsgIndex int           `json:"msg_index"`
+	Source   DepositSource `json:"source"`
+	// From is the sender, empty when the inputs of the MsgMultiSend have several addresses
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Amount sdk.Coins `json:"amount"`
+	Memo   string    `json:"memo"`
+	// Account is the account the deposit is attributed to, from the memo, empty when unattributed
+	Account       string `json:"account"`
+	Confirmations int64  `json:"confirmations"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// DepositHandler handles the deposits in the order of the chain, a deposit whose handling fails
+// is reported again by the next poll
+type DepositHandler func(deposit Deposit) error
+
+// DepositStore saves the progress of a DepositWatcher
+type DepositStore interface {
+	// LastHeight returns the last height whose deposits were all handled, 0 when none
+	LastHeight() (int64, error)
+	SaveLastHeight(height int64) error
+}
+
+type DepositWatcherOptions struct {
+	// Addresses are the watched addresses
+	Addresses []string
+	// Confirmations is the number of blocks, including the block of the tx, before a deposit is reported, 1 by default
+	Confirmations int64
+	// Store saves the progress, the progress is only kept in memory when nil
+	Store DepositStore
+	// StartHeight is the first height scanned when the store has no progress, 1 by default
+	StartHeight int64
+	// Attribute returns the account of a deposit to the address with the memo, empty when unattributed.
+	// By default the account is the trimmed memo.
+	Attribute func(address, memo string) string
+}
+
+type LedgerOptions struct {
+	// FromHeight and ToHeight bound the heights of the txs, 0 for no bound
+	FromHeight int64
+	ToHeight   int64
+	// OpeningBalances are the balances before FromHeight, the running balances start from them
+	OpeningBalances sdk.Coins
+}
+
+// Ledger is the history of the balance changes of an address
+type Ledger struct {
+	Address         string        `json:"address"`
+	OpeningBalances sdk.Coins     `json:"opening_balances"`
+	Entries         []LedgerEntry `json:"entries"`
+}
+
+// LedgerEntry is the change of the balance of a denom by a transfer, or by the fee paid
+type LedgerEntry struct {
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	Hash   string    `json:"hash"`
+	// MsgType is the type url of the msg of the transfer, empty for the fee
+	MsgType string `json:"msg_type"`
+	// Counterparty is the sender of the coins received or the recipient of the coins sent,
+	// empty when a MsgMultiSend has several
+	Counterparty string `json:"counterparty"`
+	Denom        string `json:"denom"`
+	// Amount is positive for the coins received, negative for the coins sent
+	Amount sdk.Int `json:"amount"`
+	// Fee is true for the fee paid by the address
+	Fee bool `json:"fee"`
+	// Balance is the running balance of the denom after the entry
+	Balance sdk.Int `json:"balance"`
+}
+
+// BalanceCheck is the closing balance of a denom of a ledger against the balance of the account
+type BalanceCheck struct {
+	Denom   string  `json:"denom"`
+	Ledger  sdk.Int `json:"ledger"`
+	Account sdk.Int `json:"account"`
+}
+
+func (c BalanceCheck) Matches() bool {
+	return c.Ledger.Equal(c.Account)
+}
+
+// AirdropStatus is the status of a recipient of an airdrop, or of a batch in the journal
+type AirdropStatus string
+
+const (
+	// AirdropPending isn't sent yet
+	AirdropPending AirdropStatus = "pending"
+	// AirdropSigned is journaled before the tx is broadcast, its outcome is resolved by the next run
+	AirdropSigned AirdropStatus = "signed"
+	AirdropPaid   AirdropStatus = "paid"
+	AirdropFailed AirdropStatus = "failed"
+	// AirdropSkipped was paid by a previous run
+	AirdropSkipped AirdropStatus = "skipped"
+)
+
+type AirdropOptions struct {
+	// BatchSize is the maximum number of recipients of a MsgMultiSend tx, 100 by default. A batch is split
+	// further until its simulated gas fits MaxGas and its size fits the max tx bytes.
+	BatchSize int
+	// MaxGas is the maximum gas of a batch tx, 2000000 by default. The gas of every batch is its simulated gas,
+	// baseTx.Gas is ignored.
+	MaxGas uint64
+	// Journal records the txs of the batches, a rerun with the same journal skips the recipients already paid
+	Journal AirdropJournal
+	// DryRun validates the receipts and plans the batches without sending anything
+	DryRun bool
+}
+
+// AirdropJournalEntry is the state of a batch, the last entry of a batch is its current state
+type AirdropJournalEntry struct {
+	Batch      int           `json:"batch"`
+	Status     AirdropStatus `json:"status"`
+	Recipients []string      `json:"recipients"`
+	Hash       string        `json:"hash"`
+	Height     int64         `json:"height,omitempty"`
+	Sequence   uint64        `json:"sequence"`
+	// Tx is the signed tx, broadcast again when the outcome of a AirdropSigned batch is unknown
+	Tx    []byte `json:"tx,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// AirdropJournal records the batches of an airdrop
+type AirdropJournal interface {
+	Entries() ([]AirdropJournalEntry, error)
+	// Append must persist the entry before returning
+	Append(entry AirdropJournalEntry) error
+}
+
+// AirdropResult is the outcome of a recipient, the amount is in min unit
+type AirdropResult struct {
+	Address string        `json:"address"`
+	Amount  sdk.Coins     `json:"amount"`
+	Status  AirdropStatus `json:"status"`
+	Batch   int           `json:"batch"`
+	Hash    string        `json:"hash"`
+	Height  int64         `json:"height"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// AirdropReport reconciles the receipts of an airdrop with the txs sent
+type AirdropReport struct {
+	Sender string `json:"sender"`
+	// Total is the amount of all the receipts, Paid the amount paid by this run and the previous ones
+	Total   sdk.Coins       `json:"total"`
+	Paid    sdk.Coins       `json:"paid"`
+	Unpaid  sdk.Coins       `json:"unpaid"`
+	Results []AirdropResult `json:"results"`
+}
@@ -0,0 +1,245 @@
+package bank
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// feeCollector is the module account receiving the fees
+var feeCollector = sdk.AccAddress(tmcrypto.AddressHash([]byte("fee_collector"))).String()
+
+// ledgerCSVHeader is the header of Ledger.WriteCSV
+var ledgerCSVHeader = []string{"height", "time", "hash", "msg_type", "counterparty", "denom", "amount", "fee", "balance"}
+
+func (b bankClient) QueryLedger(address string, opts LedgerOptions) (Ledger, sdk.Error) {
+	if _, err := sdk.AccAddressFromBech32(address); err != nil {
+		return Ledger{}, sdk.Wrapf("%s invalid address", address)
+	}
+
+	// the inputs of a MsgMultiSend have no transfer event, their sender is the message sender
+	keys := [][2]string{
+		{"transfer", sdk.AttributeKeySender},
+		{"transfer", "recipient"},
+		{sdk.EventTypeMessage, sdk.AttributeKeySender},
+	}
+
+	var (
+		seen = make(map[string]bool)
+		txs  []sdk.ResultQueryTx
+	)
+	for _, key := range keys {
+		builder := sdk.NewEventQueryBuilder().AddCondition(sdk.NewCond(key[0], key[1]).EQ(sdk.EventValue(address)))
+		if opts.FromHeight > 0 {
+			builder.AddCondition(sdk.NewCond("tx", "height").GTE(opts.FromHeight))
+		}
+		if opts.ToHeight > 0 {
+			builder.AddCondition(sdk.NewCond("tx", "height").LTE(opts.ToHeight))
+		}
+
+		err := b.IterateTxs(builder).ForEach(func(item interface{}) bool {
+			tx := item.(sdk.ResultQueryTx)
+			if !seen[tx.Hash] {
+				seen[tx.Hash] = true
+				txs = append(txs, tx)
+			}
+			return true
+		})
+		if err != nil {
+			return Ledger{}, err
+		}
+	}
+	sortTxs(txs)
+
+	balances := make(map[string]sdk.Int, len(opts.OpeningBalances))
+	for _, coin := range opts.OpeningBalances {
+		balances[coin.Denom] = coin.Amount
+	}
+
+	ledger := Ledger{
+		Address:         address,
+		OpeningBalances: opts.OpeningBalances,
+		Entries:         []LedgerEntry{},
+	}
+	for _, tx := range txs {
+		entries, err := ledgerEntries(address, tx)
+		if err != nil {
+			return Ledger{}, sdk.WrapWithMessage(err, "failed to parse tx %s", tx.Hash)
+		}
+		for _, entry := range entries {
+			balance, ok := balances[entry.Denom]
+			if !ok {
+				balance = sdk.ZeroInt()
+			}
+			entry.Balance = balance.Add(entry.Amount)
+			balances[entry.Denom] = entry.Balance
+			ledger.Entries = append(ledger.Entries, entry)
+		}
+	}
+	return ledger, nil
+}
+
+func (b bankClient) CheckLedger(ledger Ledger) ([]BalanceCheck, sdk.Error) {
+	account, err := b.QueryAccount(ledger.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	closing := ledger.ClosingBalances()
+	for _, coin := range account.Coins {
+		if _, ok := closing[coin.Denom]; !ok {
+			closing[coin.Denom] = sdk.ZeroInt()
+		}
+	}
+
+	checks := make([]BalanceCheck, 0, len(closing))
+	for denom, balance := range closing {
+		checks = append(checks, BalanceCheck{
+			Denom:   denom,
+			Ledger:  balance,
+			Account: account.Coins.AmountOf(denom),
+		})
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Denom < checks[j].Denom
+	})
+	return checks, nil
+}
+
+// ClosingBalances returns the balances after the last entry, by denom
+func (l Ledger) ClosingBalances() map[string]sdk.Int {
+	balances := make(map[string]sdk.Int, len(l.OpeningBalances))
+	for _, coin := range l.OpeningBalances {
+		balances[coin.Denom] = coin.Amount
+	}
+	for _, entry := range l.Entries {
+		balances[entry.Denom] = entry.Balance
+	}
+	return balances
+}
+
+// WriteCSV writes the entries as CSV, with a header line
+func (l Ledger) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ledgerCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range l.Entries {
+		err := writer.Write([]string{
+			strconv.FormatInt(entry.Height, 10),
+			entry.Time.Format(time.RFC3339),
+			entry.Hash,
+			entry.MsgType,
+			entry.Counterparty,
+			entry.Denom,
+			entry.Amount.String(),
+			strconv.FormatBool(entry.Fee),
+			entry.Balance.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the ledger as indented JSON
+func (l Ledger) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(l)
+}
+
+// ledgerEntries returns the balance changes of the address in the tx, without their running balance.
+// The fee is paid even when the tx failed, the transfers of the msgs are parsed from the log of every msg.
+func ledgerEntries(address string, tx sdk.ResultQueryTx) ([]LedgerEntry, error) {
+	blockTime, err := time.Parse(time.RFC3339, tx.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LedgerEntry
+	add := func(msgType, counterparty string, coins sdk.Coins, sent, fee bool) {
+		for _, coin := range coins {
+			amount := coin.Amount
+			if sent {
+				amount = amount.Neg()
+			}
+			entries = append(entries, LedgerEntry{
+				Height:       tx.Height,
+				Time:         blockTime,
+				Hash:         tx.Hash,
+				MsgType:      msgType,
+				Counterparty: counterparty,
+				Denom:        coin.Denom,
+				Amount:       amount,
+				Fee:          fee,
+			})
+		}
+	}
+
+	if feeTx, ok := tx.Tx.(sdk.FeeTx); ok && feeTx.FeePayer().String() == address {
+		add("", feeCollector, feeTx.GetFee(), true, true)
+	}
+	if tx.Result.Code != 0 {
+		return entries, nil
+	}
+
+	msgs := tx.Tx.GetMsgs()
+	logs, err := sdk.ParseABCILogs(tx.Result.Log)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log: %w", err)
+	}
+	for _, log := range logs {
+		if int(log.MsgIndex) >= len(msgs) {
+			return nil, fmt.Errorf("invalid log: msg index %d out of range", log.MsgIndex)
+		}
+		msg := msgs[log.MsgIndex]
+		msgType := "/" + proto.MessageName(msg)
+
+		var multiSendFrom string
+		if multiSend, ok := msg.(*MsgMultiSend); ok {
+			var to string
+			for i, output := range multiSend.Outputs {
+				if i == 0 {
+					to = output.Address
+				} else if output.Address != to {
+					to = ""
+					break
+				}
+			}
+			for _, input := range multiSend.Inputs {
+				if input.Address == address {
+					add(msgType, to, input.Coins, true, false)
+				}
+			}
+			if outputs := sendOutputs(msg); len(outputs) > 0 {
+				multiSendFrom = outputs[0].from
+			}
+		}
+
+		for _, t := range parseTransfers(log.Events) {
+			if t.sender == address {
+				add(msgType, t.recipient, t.amount, true, false)
+			}
+			if t.recipient == address {
+				from := t.sender
+				if from == "" {
+					from = multiSendFrom
+				}
+				add(msgType, from, t.amount, false, false)
+			}
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,26 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestSortTxs(t *testing.T) {
+	// the txs of the queries of the sender and of the recipient, merged
+	txs := []sdk.ResultQueryTx{
+		{Hash: "c", Height: 2, Index: 1},
+		{Hash: "d", Height: 3, Index: 0},
+		{Hash: "b", Height: 2, Index: 0},
+		{Hash: "a", Height: 1, Index: 4},
+	}
+	sortTxs(txs)
+
+	hashes := make([]string, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash
+	}
+	require.Equal(t, []string{"a", "b", "c", "d"}, hashes)
+}
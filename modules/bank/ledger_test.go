@@ -0,0 +1,120 @@
+package bank_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestLedger(t *testing.T) {
+	chain, client, alice, bob := fakechaintest.Setup(t)
+	carol, _, sdkErr := client.Key.Add("carol", fakechaintest.Password)
+	require.NoError(t, sdkErr)
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	sent, sdkErr := client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, sdkErr)
+	_, sdkErr = client.Bank.MultiSend(bank.MultiSendRequest{Receipts: []bank.Receipt{
+		{Address: bob, Amount: types.NewDecCoins(types.NewInt64DecCoin("iris", 1))},
+		{Address: carol, Amount: types.NewDecCoins(types.NewInt64DecCoin("iris", 2))},
+	}}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, sdkErr)
+	amount, err = types.ParseDecCoins("3iris")
+	require.NoError(t, err)
+	_, sdkErr = client.Bank.Send(alice, amount, fakechaintest.BaseTx("bob"))
+	require.NoError(t, sdkErr)
+
+	opening := types.NewCoins(types.NewInt64Coin(types.BaseDenom, 1000000000000))
+	ledger, sdkErr := client.Bank.QueryLedger(alice, bank.LedgerOptions{OpeningBalances: opening})
+	require.NoError(t, sdkErr)
+	require.Equal(t, alice, ledger.Address)
+
+	var received, spent []bank.LedgerEntry
+	var fees int
+	for i, entry := range ledger.Entries {
+		require.Equal(t, types.BaseDenom, entry.Denom)
+		if i > 0 {
+			require.True(t, ledger.Entries[i-1].Height <= entry.Height)
+			require.Equal(t, ledger.Entries[i-1].Balance.Add(entry.Amount).String(), entry.Balance.String())
+		}
+		switch {
+		case entry.Fee:
+			fees++
+			require.True(t, entry.Amount.IsNegative())
+			require.Empty(t, entry.MsgType)
+		case entry.Amount.IsPositive():
+			received = append(received, entry)
+		default:
+			spent = append(spent, entry)
+		}
+	}
+	require.Equal(t, 2, fees)
+	require.Len(t, received, 1)
+	require.Equal(t, bob, received[0].Counterparty)
+	require.Equal(t, "3000000", received[0].Amount.String())
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", received[0].MsgType)
+
+	require.Len(t, spent, 3)
+	require.Equal(t, sent.Hash, spent[0].Hash)
+	require.Equal(t, sent.Height, spent[0].Height)
+	require.False(t, spent[0].Time.IsZero())
+	require.Equal(t, bob, spent[0].Counterparty)
+	require.Equal(t, "-10000000", spent[0].Amount.String())
+	for _, entry := range spent[1:] {
+		require.Equal(t, "/cosmos.bank.v1beta1.MsgMultiSend", entry.MsgType)
+		require.Empty(t, entry.Counterparty)
+	}
+
+	checks, sdkErr := client.Bank.CheckLedger(ledger)
+	require.NoError(t, sdkErr)
+	require.Len(t, checks, 1)
+	require.True(t, checks[0].Matches(), checks[0])
+	require.Equal(t, chain.Balances(alice).AmountOf(types.BaseDenom).String(), checks[0].Ledger.String())
+
+	// the recipient of a MsgMultiSend output has the sender as counterparty
+	ledger, sdkErr = client.Bank.QueryLedger(carol, bank.LedgerOptions{})
+	require.NoError(t, sdkErr)
+	require.Len(t, ledger.Entries, 1)
+	require.Equal(t, alice, ledger.Entries[0].Counterparty)
+	require.Equal(t, "2000000", ledger.Entries[0].Balance.String())
+
+	ledger, sdkErr = client.Bank.QueryLedger(bob, bank.LedgerOptions{})
+	require.NoError(t, sdkErr)
+	checks, sdkErr = client.Bank.CheckLedger(ledger)
+	require.NoError(t, sdkErr)
+	require.Len(t, checks, 1)
+	require.True(t, checks[0].Matches(), checks[0])
+
+	// the balances before the ledger are missing without the opening balances
+	ledger, sdkErr = client.Bank.QueryLedger(alice, bank.LedgerOptions{ToHeight: sent.Height})
+	require.NoError(t, sdkErr)
+	require.Len(t, ledger.Entries, 2)
+	checks, sdkErr = client.Bank.CheckLedger(ledger)
+	require.NoError(t, sdkErr)
+	require.False(t, checks[0].Matches())
+
+	var csv bytes.Buffer
+	require.NoError(t, ledger.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "height,time,hash,msg_type,counterparty,denom,amount,fee,balance", lines[0])
+	require.True(t, strings.HasSuffix(lines[2], ",uiris,-10000000,false,"+ledger.Entries[1].Balance.String()), lines[2])
+
+	var bz bytes.Buffer
+	require.NoError(t, ledger.WriteJSON(&bz))
+	var decoded bank.Ledger
+	require.NoError(t, json.Unmarshal(bz.Bytes(), &decoded))
+	require.Len(t, decoded.Entries, 2)
+	require.Equal(t, ledger.Entries[1].Amount, decoded.Entries[1].Amount)
+
+	_, sdkErr = client.Bank.QueryLedger("invalid", bank.LedgerOptions{})
+	require.Error(t, sdkErr)
+}
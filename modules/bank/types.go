@@ -217,3 +217,14 @@ func ValidateInputsOutputs(inputs []Input, outputs []Output) error {
 
 	return nil
 }
+
+func (q QueryParamsResponse) Convert() interface{} {
+	sendEnabled := make([]SendEnabled, len(q.Params.SendEnabled))
+	for i, se := range q.Params.SendEnabled {
+		sendEnabled[i] = *se
+	}
+	return QueryParamsResp{
+		SendEnabled:        sendEnabled,
+		DefaultSendEnabled: q.Params.DefaultSendEnabled,
+	}
+}
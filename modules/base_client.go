@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
 
 	clienttx "github.com/irisnet/irishub-sdk-go/client/tx"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/utils"
 	"github.com/irisnet/irishub-sdk-go/utils/cache"
 	sdklog "github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/tracing"
 )
 
 const (
@@ -46,6 +49,8 @@ type baseClient struct {
 	encodingConfig sdk.EncodingConfig
 	l              *locker
 
+	tracer tracing.Tracer
+
 	accountQuery
 	tokenQuery
 }
@@ -61,17 +66,34 @@ func NewBaseClient(cfg sdk.ClientConfig, encodingConfig sdk.EncodingConfig, logg
 	}
 
 	base := baseClient{
-		TmClient:       NewRPCClient(cfg.NodeURI, encodingConfig.Amino, encodingConfig.TxConfig.TxDecoder(), logger, cfg.Timeout),
-		GRPCClient:     NewGRPCClient(cfg.GRPCAddr),
+		TmClient:       cfg.TmClient,
+		GRPCClient:     cfg.GRPCClient,
 		logger:         logger,
 		cfg:            &cfg,
 		encodingConfig: encodingConfig,
 		l:              NewLocker(concurrency),
+		tracer:         tracing.NewTracer(cfg.TracerProvider, cfg.Propagator),
+	}
+
+	if base.TmClient == nil {
+		base.TmClient = NewRPCClient(cfg.NodeURI, encodingConfig.Amino, encodingConfig.TxConfig.TxDecoder(), logger, cfg.Timeout)
+	}
+
+	if base.GRPCClient == nil {
+		base.GRPCClient = NewGRPCClient(cfg.GRPCAddr, cfg.GRPCTLSConfig, grpc.WithChainUnaryInterceptor(
+			base.tracer.UnaryClientInterceptor(),
+			cfg.Metrics.UnaryClientInterceptor(),
+		))
 	}
 
-	base.KeyManager = keyManager{
-		keyDAO: cfg.KeyDAO,
-		algo:   cfg.Algo,
+	if cfg.Metrics != nil {
+		base.TmClient = newMetricsTmClient(base.TmClient, cfg.Metrics)
+	}
+	base.TmClient = newTracingTmClient(base.TmClient, base.tracer)
+
+	base.KeyManager = NewKeyManager(cfg.KeyDAO, cfg.Algo)
+	if cfg.KeyManager != nil {
+		base.KeyManager = cfg.KeyManager
 	}
 
 	c := cache.NewCache(cacheCapacity, cfg.Cached)
@@ -83,6 +105,8 @@ func NewBaseClient(cfg sdk.ClientConfig, encodingConfig sdk.EncodingConfig, logg
 		cdc:        encodingConfig.Marshaler,
 		km:         base.KeyManager,
 		expiration: cacheExpirePeriod,
+		metrics:    cfg.Metrics,
+		tracer:     base.tracer,
 	}
 
 	base.tokenQuery = tokenQuery{
@@ -91,6 +115,9 @@ func NewBaseClient(cfg sdk.ClientConfig, encodingConfig sdk.EncodingConfig, logg
 		cdc:        encodingConfig.Marshaler,
 		Logger:     base.Logger(),
 		Cache:      c,
+		registry:   cfg.TokenRegistry,
+		metrics:    cfg.Metrics,
+		tracer:     base.tracer,
 	}
 
 	return &base
@@ -104,21 +131,38 @@ func (base *baseClient) SetLogger(logger log.Logger) {
 	base.logger = logger
 }
 
+// Rename renames the key, the address cached for the old name is dropped
+func (base *baseClient) Rename(name, newName, password string) error {
+	if err := base.KeyManager.Rename(name, newName, password); err != nil {
+		return err
+	}
+	_ = base.accountQuery.removeCache(name)
+	return nil
+}
+
 // Codec returns codec.
 func (base *baseClient) Marshaler() codec.Marshaler {
 	return base.encodingConfig.Marshaler
 }
 
 func (base *baseClient) BuildTxHash(msg []sdk.Msg, baseTx sdk.BaseTx) (string, sdk.Error) {
-	txByte, _, err := base.buildTx(msg, baseTx)
+	txByte, _, err := base.buildTx(context.Background(), msg, baseTx)
 	if err != nil {
 		return "", sdk.Wrap(err)
 	}
 	return strings.ToUpper(hex.EncodeToString(tmhash.Sum(txByte))), nil
 }
 
+func (base *baseClient) BuildSignedTx(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
+	txByte, _, err := base.buildTx(context.Background(), msg, baseTx)
+	if err != nil {
+		return nil, err
+	}
+	return txByte, nil
+}
+
 func (base *baseClient) BuildAndSign(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
-	builder, err := base.prepare(baseTx)
+	builder, err := base.prepare(context.Background(), baseTx)
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
@@ -132,8 +176,32 @@ func (base *baseClient) BuildAndSign(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte,
 	return txByte, nil
 }
 
-func (base *baseClient) BuildAndSend(msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
-	txByte, ctx, err := base.buildTx(msg, baseTx)
+func (base *baseClient) BuildUnsignedTx(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
+	builder, err := base.prepare(context.Background(), baseTx)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	tx, err := builder.BuildUnsignedTx(msg)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	txByte, err := base.encodingConfig.TxConfig.TxJSONEncoder()(tx.GetTx())
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return txByte, nil
+}
+
+func (base *baseClient) BuildAndSend(msg []sdk.Msg, baseTx sdk.BaseTx) (res sdk.ResultTx, err sdk.Error) {
+	goCtx, span := base.tracer.Start(context.Background(), "BuildAndSend",
+		tracing.MsgTypes(msgTypes(msg)),
+		attribute.String(tracing.AttributeKeyAccountName, baseTx.From),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	txByte, ctx, err := base.buildTx(goCtx, msg, baseTx)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
@@ -142,7 +210,7 @@ func (base *baseClient) BuildAndSend(msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.Resu
 		return sdk.ResultTx{}, err
 	}
 
-	res, err := base.broadcastTx(txByte, ctx.Mode(), baseTx.Simulate)
+	res, err = base.broadcastTx(goCtx, txByte, ctx.Mode(), baseTx.Simulate)
 	if err != nil {
 		if base.cfg.Cached {
 			_ = base.removeCache(ctx.Address())
@@ -154,8 +222,16 @@ func (base *baseClient) BuildAndSend(msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.Resu
 	return res, nil
 }
 
-func (base *baseClient) BuildAndSendWithAccount(addr string, accountNumber, sequence uint64, msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
-	txByte, ctx, err := base.buildTxWithAccount(addr, accountNumber, sequence, msg, baseTx)
+func (base *baseClient) BuildAndSendWithAccount(addr string, accountNumber, sequence uint64, msg []sdk.Msg, baseTx sdk.BaseTx) (res sdk.ResultTx, err sdk.Error) {
+	goCtx, span := base.tracer.Start(context.Background(), "BuildAndSendWithAccount",
+		tracing.MsgTypes(msgTypes(msg)),
+		attribute.String(tracing.AttributeKeyAccountAddress, addr),
+		attribute.Int64(tracing.AttributeKeyAccountNumber, int64(accountNumber)),
+		attribute.Int64(tracing.AttributeKeyAccountSequence, int64(sequence)),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	txByte, ctx, err := base.buildTxWithAccount(goCtx, addr, accountNumber, sequence, msg, baseTx)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
@@ -163,7 +239,7 @@ func (base *baseClient) BuildAndSendWithAccount(addr string, accountNumber, sequ
 	if err := base.ValidateTxSize(len(txByte), msg); err != nil {
 		return sdk.ResultTx{}, err
 	}
-	return base.broadcastTx(txByte, ctx.Mode(), baseTx.Simulate)
+	return base.broadcastTx(goCtx, txByte, ctx.Mode(), baseTx.Simulate)
 }
 
 func (base *baseClient) SendBatch(msgs sdk.Msgs, baseTx sdk.BaseTx) (rs []sdk.ResultTx, err sdk.Error) {
@@ -182,6 +258,11 @@ func (base *baseClient) SendBatch(msgs sdk.Msgs, baseTx sdk.BaseTx) (rs []sdk.Re
 	}
 	base.Logger().Debug("validate msg success")
 
+	goCtx, span := base.tracer.Start(context.Background(), "SendBatch",
+		attribute.String(tracing.AttributeKeyAccountName, baseTx.From),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// lock the account
 	base.l.Lock(baseTx.From)
 	defer base.l.Unlock(baseTx.From)
@@ -194,7 +275,7 @@ resize:
 		mss := ms.(sdk.Msgs)
 
 	retry:
-		txByte, ctx, err := base.buildTx(mss, baseTx)
+		txByte, ctx, err := base.buildTx(goCtx, mss, baseTx)
 		if err != nil {
 			return rs, err
 		}
@@ -210,7 +291,7 @@ resize:
 			goto resize
 		}
 
-		res, err := base.broadcastTx(txByte, ctx.Mode(), baseTx.Simulate)
+		res, err := base.broadcastTx(goCtx, txByte, ctx.Mode(), baseTx.Simulate)
 		if err != nil {
 			if base.cfg.Cached {
 				base.Logger().Debug("something wrong,retrying ...", "address", ctx.Address(), "tryCnt", tryCnt)
@@ -219,6 +300,9 @@ resize:
 				if tryCnt++; tryCnt >= tryThreshold {
 					return rs, err
 				}
+				if isSequenceMismatch(err) {
+					base.cfg.Metrics.SequenceMismatchRetry()
+				}
 				goto retry
 			}
 
@@ -291,7 +375,7 @@ func (base baseClient) QueryStore(key sdk.HexBytes, storeName string, height int
 	return resp, nil
 }
 
-func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
+func (base *baseClient) prepare(goCtx context.Context, baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 	factory := clienttx.NewFactory().
 		WithChainID(base.cfg.ChainID).
 		WithKeyManager(base.KeyManager).
@@ -301,7 +385,9 @@ func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 		WithSignModeHandler(tx.MakeSignModeHandler(tx.DefaultSignModes)).
 		WithTxConfig(base.encodingConfig.TxConfig)
 
+	_, span := base.tracer.Start(goCtx, "QueryAddress", attribute.String(tracing.AttributeKeyAccountName, baseTx.From))
 	addr, err := base.QueryAddress(baseTx.From, baseTx.Password)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +398,7 @@ func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 			WithSequence(baseTx.Sequence).
 			WithPassword(baseTx.Password)
 	} else {
-		account, err := base.QueryAndRefreshAccount(addr.String())
+		account, err := base.queryAndRefreshAccount(goCtx, addr.String())
 		if err != nil {
 			return nil, err
 		}
@@ -322,15 +408,15 @@ func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 	}
 
 	if !baseTx.Fee.Empty() && baseTx.Fee.IsValid() {
-		fees, err := base.ToMinCoin(baseTx.Fee...)
+		fees, err := base.toMinCoin(goCtx, baseTx.Fee...)
 		if err != nil {
 			return nil, err
 		}
 		factory.WithFee(fees)
 	} else {
-		fees, err := base.ToMinCoin(base.cfg.Fee...)
+		fees, err := base.toMinCoin(goCtx, base.cfg.Fee...)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		factory.WithFee(fees)
 	}
@@ -350,7 +436,7 @@ func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 }
 
 // TODO
-func (base *baseClient) prepareTemp(addr string, accountNumber, sequence uint64, baseTx sdk.BaseTx) (*clienttx.Factory, error) {
+func (base *baseClient) prepareTemp(goCtx context.Context, addr string, accountNumber, sequence uint64, baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 	factory := clienttx.NewFactory().
 		WithChainID(base.cfg.ChainID).
 		WithKeyManager(base.KeyManager).
@@ -366,15 +452,15 @@ func (base *baseClient) prepareTemp(addr string, accountNumber, sequence uint64,
 		WithPassword(baseTx.Password)
 
 	if !baseTx.Fee.Empty() && baseTx.Fee.IsValid() {
-		fees, err := base.ToMinCoin(baseTx.Fee...)
+		fees, err := base.toMinCoin(goCtx, baseTx.Fee...)
 		if err != nil {
 			return nil, err
 		}
 		factory.WithFee(fees)
 	} else {
-		fees, err := base.ToMinCoin(base.cfg.Fee...)
+		fees, err := base.toMinCoin(goCtx, base.cfg.Fee...)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		factory.WithFee(fees)
 	}
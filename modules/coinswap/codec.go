@@ -15,6 +15,7 @@ var (
 func init() {
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
+	sdk.RegisterEventAttributes(eventTypeSwap, attributeKeyAmount)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -389,3 +389,28 @@ func getOutputPrice(outputAmt, inputReserve, outputReserve sdk.Int, fee sdk.Dec)
 	denominator := (outputReserve.Sub(outputAmt)).Mul(sdk.NewIntFromBigInt(deltaFee.BigInt()))
 	return numerator.Quo(denominator).Add(sdk.OneInt())
 }
+
+// IteratePools returns an iterator over all the liquidity pools, the items are sdk.PoolInfo
+func (swap coinswapClient) IteratePools() *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := swap.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).LiquidityPools(
+			context.Background(),
+			&QueryLiquidityPoolsRequest{Pagination: pageReq},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.Pools))
+		for i, pool := range resp.Pools {
+			items[i] = _loadPoolInfo(pool)
+		}
+		return items, resp.Pagination, nil
+	})
+}
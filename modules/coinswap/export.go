@@ -26,6 +26,7 @@ type Client interface {
 
 	QueryPool(lptDenom string) (*QueryPoolResponse, error)
 	QueryAllPools(pageReq sdk.PageRequest) (*QueryAllPoolsResponse, error)
+	IteratePools() *sdk.Iterator
 
 	EstimateTokenForSoldBase(tokenDenom string,
 		soldBase sdk.Int,
@@ -14,6 +14,7 @@ var (
 
 func init() {
 	cryptocodec.RegisterCrypto(amino)
+	sdk.RegisterEventAttributes(sdk.EventTypeSubmitProposal, AttributeKeyProposalId)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -21,6 +21,10 @@ type Client interface {
 	QueryDeposit(proposalId uint64, depositor string) (QueryDepositResp, sdk.Error)
 	QueryDeposits(proposalId uint64) ([]QueryDepositResp, sdk.Error)
 	QueryTallyResult(proposalId uint64) (QueryTallyResultResp, sdk.Error)
+
+	IterateProposals(proposalStatus string) *sdk.Iterator
+	IterateVotes(proposalId uint64) *sdk.Iterator
+	IterateDeposits(proposalId uint64) *sdk.Iterator
 }
 
 type SubmitProposalRequest struct {
@@ -120,26 +120,43 @@ func (gc govClient) QueryProposal(proposalId uint64) (QueryProposalResp, sdk.Err
 // if proposalStatus is nil will return all status's proposals
 // about proposalStatus see VoteOption_value
 func (gc govClient) QueryProposals(proposalStatus string) ([]QueryProposalResp, sdk.Error) {
-	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
+	var proposals []QueryProposalResp
+	err := gc.IterateProposals(proposalStatus).ForEach(func(item interface{}) bool {
+		proposals = append(proposals, item.(QueryProposalResp))
+		return true
+	})
 	if err != nil {
-		return nil, sdk.Wrap(err)
+		return nil, err
 	}
+	return proposals, nil
+}
 
-	res, err := NewQueryClient(conn).Proposals(
-		context.Background(),
-		&QueryProposalsRequest{
-			ProposalStatus: ProposalStatus(VoteOption_value[proposalStatus]),
-			Pagination: &query.PageRequest{
-				Offset:     0,
-				Limit:      100,
-				CountTotal: true,
-			},
-		})
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return Proposals(res.Proposals).Convert().([]QueryProposalResp), nil
+// IterateProposals returns an iterator over the proposals with the specified status,
+// the items are QueryProposalResp
+func (gc govClient) IterateProposals(proposalStatus string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := gc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).Proposals(
+			context.Background(),
+			&QueryProposalsRequest{
+				ProposalStatus: ProposalStatus(VoteOption_value[proposalStatus]),
+				Pagination:     pageReq,
+			})
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.Proposals))
+		for i, p := range res.Proposals {
+			items[i] = p.Convert().(QueryProposalResp)
+		}
+		return items, res.Pagination, nil
+	})
 }
 
 // about QueryVoteResp.Option see VoteOption_name
@@ -163,26 +180,42 @@ func (gc govClient) QueryVote(proposalId uint64, voter string) (QueryVoteResp, s
 }
 
 func (gc govClient) QueryVotes(proposalId uint64) ([]QueryVoteResp, sdk.Error) {
-	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
+	var votes []QueryVoteResp
+	err := gc.IterateVotes(proposalId).ForEach(func(item interface{}) bool {
+		votes = append(votes, item.(QueryVoteResp))
+		return true
+	})
 	if err != nil {
-		return nil, sdk.Wrap(err)
+		return nil, err
 	}
+	return votes, nil
+}
 
-	res, err := NewQueryClient(conn).Votes(
-		context.Background(),
-		&QueryVotesRequest{
-			ProposalId: proposalId,
-			Pagination: &query.PageRequest{
-				Offset:     0,
-				Limit:      100,
-				CountTotal: true,
-			},
-		})
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return Votes(res.Votes).Convert().([]QueryVoteResp), nil
+// IterateVotes returns an iterator over the votes of the proposal, the items are QueryVoteResp
+func (gc govClient) IterateVotes(proposalId uint64) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := gc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).Votes(
+			context.Background(),
+			&QueryVotesRequest{
+				ProposalId: proposalId,
+				Pagination: pageReq,
+			})
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.Votes))
+		for i, v := range res.Votes {
+			items[i] = v.Convert().(QueryVoteResp)
+		}
+		return items, res.Pagination, nil
+	})
 }
 
 // QueryParams params_type("voting", "tallying", "deposit"), if don't pass will return all params_typ res
@@ -226,27 +259,43 @@ func (gc govClient) QueryDeposit(proposalId uint64, depositor string) (QueryDepo
 }
 
 func (gc govClient) QueryDeposits(proposalId uint64) ([]QueryDepositResp, sdk.Error) {
-	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
+	var deposits []QueryDepositResp
+	err := gc.IterateDeposits(proposalId).ForEach(func(item interface{}) bool {
+		deposits = append(deposits, item.(QueryDepositResp))
+		return true
+	})
 	if err != nil {
-		return nil, sdk.Wrap(err)
+		return nil, err
 	}
+	return deposits, nil
+}
 
-	res, err := NewQueryClient(conn).Deposits(
-		context.Background(),
-		&QueryDepositsRequest{
-			ProposalId: proposalId,
-			Pagination: &query.PageRequest{
-				Offset:     0,
-				Limit:      100,
-				CountTotal: true,
+// IterateDeposits returns an iterator over the deposits of the proposal, the items are QueryDepositResp
+func (gc govClient) IterateDeposits(proposalId uint64) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := gc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).Deposits(
+			context.Background(),
+			&QueryDepositsRequest{
+				ProposalId: proposalId,
+				Pagination: pageReq,
 			},
-		},
-	)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return Deposits(res.Deposits).Convert().([]QueryDepositResp), nil
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.Deposits))
+		for i, d := range res.Deposits {
+			items[i] = d.Convert().(QueryDepositResp)
+		}
+		return items, res.Pagination, nil
+	})
 }
 
 func (gc govClient) QueryTallyResult(proposalId uint64) (QueryTallyResultResp, sdk.Error) {
@@ -1,17 +1,27 @@
 package modules
 
 import (
+	"crypto/tls"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type grpcClient struct {
-	url string
+	url  string
+	opts []grpc.DialOption
 }
 
-func NewGRPCClient(url string) grpcClient {
-	return grpcClient{url: url}
+// NewGRPCClient connects to url with TLS when tlsConfig is set, or without TLS when nil.
+// The transport credentials are set by tlsConfig, opts must not hold any
+func NewGRPCClient(url string, tlsConfig *tls.Config, opts ...grpc.DialOption) grpcClient {
+	transport := grpc.WithInsecure()
+	if tlsConfig != nil {
+		transport = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+	return grpcClient{url: url, opts: append([]grpc.DialOption{transport}, opts...)}
 }
 
 func (g grpcClient) GenConn() (*grpc.ClientConn, error) {
-	return grpc.Dial(g.url, grpc.WithInsecure())
+	return grpc.Dial(g.url, g.opts...)
 }
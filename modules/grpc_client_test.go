@@ -0,0 +1,22 @@
+package modules_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/irisnet/irishub-sdk-go/modules"
+)
+
+func TestGRPCClientTransport(t *testing.T) {
+	for name, tlsConfig := range map[string]*tls.Config{
+		"insecure": nil,
+		"tls":      {ServerName: "localhost"},
+	} {
+		conn, err := modules.NewGRPCClient("localhost:9090", tlsConfig, grpc.WithUserAgent("test")).GenConn()
+		require.NoError(t, err, name)
+		require.NoError(t, conn.Close())
+	}
+}
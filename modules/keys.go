@@ -1,25 +1,39 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 
 	"github.com/irisnet/irishub-sdk-go/crypto"
 	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
 	"github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/types/store"
 )
 
+// number of words of the created mnemonics
+const defaultMnemonicWords = 24
+
 type keyManager struct {
 	keyDAO store.KeyDAO
 	algo   string
 }
 
+// NewKeyManager returns the KeyManager of the keys of the KeyDAO, creating the keys of the algo by default.
+// It is the KeyManager of the client unless types.KeyManagerOption is set, e.g. to wrap it.
+func NewKeyManager(keyDAO store.KeyDAO, algo string) types.KeyManager {
+	return keyManager{keyDAO: keyDAO, algo: algo}
+}
+
 func (k keyManager) Sign(name, password string, data []byte) ([]byte, tmcrypto.PubKey, error) {
 	info, err := k.keyDAO.Read(name, password)
 	if err != nil {
-		return nil, nil, fmt.Errorf("name %s not exist", name)
+		return nil, nil, readError(name, err)
+	}
+	if info.WatchOnly() {
+		return nil, nil, fmt.Errorf("name %s: %w", name, types.ErrWatchOnly)
 	}
 
 	km, err := crypto.NewPrivateKeyManager([]byte(info.PrivKeyArmor), string(info.Algo))
@@ -36,49 +50,63 @@ func (k keyManager) Sign(name, password string, data []byte) ([]byte, tmcrypto.P
 }
 
 func (k keyManager) Insert(name, password string) (string, string, error) {
+	return k.InsertWithAlgo(name, password, k.algo)
+}
+
+func (k keyManager) InsertWithAlgo(name, password, algo string) (string, string, error) {
+	return k.InsertWithOptions(name, password, types.KeyOptions{Algo: algo})
+}
+
+func (k keyManager) InsertWithOptions(name, password string, opts types.KeyOptions) (string, string, error) {
 	if k.keyDAO.Has(name) {
 		return "", "", fmt.Errorf("name %s has existed", name)
 	}
 
-	km, err := crypto.NewAlgoKeyManager(k.algo)
+	words := opts.MnemonicWords
+	if words == 0 {
+		words = defaultMnemonicWords
+	}
+	mnemonic, err := crypto.NewMnemonic(words)
 	if err != nil {
 		return "", "", err
 	}
 
-	mnemonic, priv := km.Generate()
-
-	pubKey := km.ExportPubKey()
-	address := types.AccAddress(pubKey.Address().Bytes()).String()
-
-	info := store.KeyInfo{
-		Name:         name,
-		PubKey:       cryptoamino.MarshalPubkey(pubKey),
-		PrivKeyArmor: string(cryptoamino.MarshalPrivKey(priv)),
-		Algo:         k.algo,
-	}
-
-	if err = k.keyDAO.Write(name, password, info); err != nil {
+	address, err := k.recover(name, password, mnemonic, opts)
+	if err != nil {
 		return "", "", err
 	}
 	return address, mnemonic, nil
 }
 
 func (k keyManager) Recover(name, password, mnemonic, hdPath string) (string, error) {
+	return k.RecoverWithAlgo(name, password, mnemonic, hdPath, k.algo)
+}
+
+func (k keyManager) RecoverWithAlgo(name, password, mnemonic, hdPath, algo string) (string, error) {
+	return k.RecoverWithOptions(name, password, mnemonic, types.KeyOptions{Algo: algo, HDPath: hdPath})
+}
+
+func (k keyManager) RecoverWithOptions(name, password, mnemonic string, opts types.KeyOptions) (string, error) {
 	if k.keyDAO.Has(name) {
 		return "", fmt.Errorf("name %s has existed", name)
 	}
+	return k.recover(name, password, mnemonic, opts)
+}
 
-	var (
-		km  crypto.KeyManager
-		err error
-	)
-
+// recover derives the key from the mnemonic and writes it with its algorithm and hd path
+func (k keyManager) recover(name, password, mnemonic string, opts types.KeyOptions) (string, error) {
+	algo, hdPath := opts.Algo, opts.HDPath
+	if algo == "" {
+		algo = k.algo
+	}
 	if hdPath == "" {
-		km, err = crypto.NewMnemonicKeyManager(mnemonic, k.algo)
-	} else {
-		km, err = crypto.NewMnemonicKeyManagerWithHDPath(mnemonic, k.algo, hdPath)
+		hdPath = hd.FullPath
+		if algo == string(hd.Ed25519Type) {
+			hdPath = hd.Ed25519FullPath
+		}
 	}
 
+	km, err := crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, opts.BIP39Passphrase, algo, hdPath)
 	if err != nil {
 		return "", err
 	}
@@ -92,7 +120,8 @@ func (k keyManager) Recover(name, password, mnemonic, hdPath string) (string, er
 		Name:         name,
 		PubKey:       cryptoamino.MarshalPubkey(pubKey),
 		PrivKeyArmor: string(cryptoamino.MarshalPrivKey(priv)),
-		Algo:         k.algo,
+		Algo:         algo,
+		HDPath:       hdPath,
 	}
 
 	if err = k.keyDAO.Write(name, password, info); err != nil {
@@ -109,7 +138,8 @@ func (k keyManager) Import(name, password, armor string) (string, error) {
 
 	km := crypto.NewKeyManager()
 
-	priv, _, err := km.ImportPrivKey(armor, password)
+	// the armor records the algorithm of the key
+	priv, algo, err := km.ImportPrivKey(armor, password)
 	if err != nil {
 		return "", err
 	}
@@ -121,7 +151,7 @@ func (k keyManager) Import(name, password, armor string) (string, error) {
 		Name:         name,
 		PubKey:       cryptoamino.MarshalPubkey(pubKey),
 		PrivKeyArmor: string(cryptoamino.MarshalPrivKey(priv)),
-		Algo:         k.algo,
+		Algo:         algo,
 	}
 
 	err = k.keyDAO.Write(name, password, info)
@@ -134,7 +164,10 @@ func (k keyManager) Import(name, password, armor string) (string, error) {
 func (k keyManager) Export(name, password string) (armor string, err error) {
 	info, err := k.keyDAO.Read(name, password)
 	if err != nil {
-		return armor, fmt.Errorf("name %s not exist", name)
+		return armor, readError(name, err)
+	}
+	if info.WatchOnly() {
+		return "", fmt.Errorf("name %s: %w", name, types.ErrWatchOnly)
 	}
 
 	km, err := crypto.NewPrivateKeyManager([]byte(info.PrivKeyArmor), info.Algo)
@@ -145,6 +178,33 @@ func (k keyManager) Export(name, password string) (armor string, err error) {
 	return km.ExportPrivKey(password)
 }
 
+func (k keyManager) ImportWatchOnly(name, password, address string, pubKey tmcrypto.PubKey) (string, error) {
+	if k.keyDAO.Has(name) {
+		return "", fmt.Errorf("%s has existed", name)
+	}
+
+	info := store.KeyInfo{Name: name}
+	if pubKey != nil {
+		addr := types.AccAddress(pubKey.Address().Bytes()).String()
+		if len(address) > 0 && address != addr {
+			return "", fmt.Errorf("the address %s doesn't match the pubkey of %s", address, addr)
+		}
+		address = addr
+		info.PubKey = cryptoamino.MarshalPubkey(pubKey)
+		info.Algo = pubKey.Type()
+	} else {
+		if _, err := types.AccAddressFromBech32(address); err != nil {
+			return "", err
+		}
+		info.Address = address
+	}
+
+	if err := k.keyDAO.Write(name, password, info); err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
 func (k keyManager) Delete(name, password string) error {
 	return k.keyDAO.Delete(name, password)
 }
@@ -154,11 +214,83 @@ func (k keyManager) Find(name, password string) (tmcrypto.PubKey, types.AccAddre
 	if err != nil {
 		return nil, nil, types.WrapWithMessage(err, "name %s not exist", name)
 	}
+	return keyAddress(name, info)
+}
+
+func (k keyManager) List() ([]types.KeyMetadata, error) {
+	names, err := k.keyDAO.List()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]types.KeyMetadata, 0, len(names))
+	for _, name := range names {
+		key, err := k.ShowPublic(name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (k keyManager) ShowPublic(name string) (types.KeyMetadata, error) {
+	info, err := k.keyDAO.ReadMetadata(name)
+	if errors.Is(err, store.ErrUnsupported) {
+		return types.KeyMetadata{}, fmt.Errorf("name %s: %w", name, err)
+	}
+	if err != nil {
+		return types.KeyMetadata{}, types.WrapWithMessage(err, "name %s not exist", name)
+	}
+
+	pubKey, address, err := keyAddress(name, info)
+	if err != nil {
+		return types.KeyMetadata{}, err
+	}
+
+	return types.KeyMetadata{
+		Name:      name,
+		Algo:      info.Algo,
+		PubKey:    pubKey,
+		Address:   address,
+		HDPath:    info.HDPath,
+		WatchOnly: info.WatchOnly(),
+		CreatedAt: info.CreatedAt,
+	}, nil
+}
+
+func (k keyManager) ChangePassword(name, oldPassword, newPassword string) error {
+	return k.keyDAO.ChangePassword(name, oldPassword, newPassword)
+}
+
+func (k keyManager) Rename(name, newName, password string) error {
+	return k.keyDAO.Rename(name, newName, password)
+}
+
+// keyAddress returns the public key and the address of the stored key, the public key of a watch-only address is nil
+func keyAddress(name string, info store.KeyInfo) (tmcrypto.PubKey, types.AccAddress, error) {
+	if len(info.PubKey) == 0 && info.WatchOnly() {
+		address, err := types.AccAddressFromBech32(info.Address)
+		if err != nil {
+			return nil, nil, types.WrapWithMessage(err, "name %s not exist", name)
+		}
+		return nil, address, nil
+	}
+	if len(info.PubKey) == 0 {
+		return nil, nil, fmt.Errorf("name %s not exist", name)
+	}
 
 	pubKey, err := cryptoamino.PubKeyFromBytes(info.PubKey)
 	if err != nil {
 		return nil, nil, types.WrapWithMessage(err, "name %s not exist", name)
 	}
-
 	return pubKey, types.AccAddress(pubKey.Address().Bytes()), nil
 }
+
+// readError keeps the wrong password error explicit, any other error of the KeyDAO means the key doesn't exist
+func readError(name string, err error) error {
+	if errors.Is(err, store.ErrWrongPassword) {
+		return fmt.Errorf("name %s: %w", name, err)
+	}
+	return fmt.Errorf("name %s not exist", name)
+}
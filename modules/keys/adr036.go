@@ -0,0 +1,138 @@
+package keys
+
+import (
+	"encoding/json"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// MsgSignDataType is the amino type of the message of the ADR-036 sign doc
+const MsgSignDataType = "sign/MsgSignData"
+
+// ArbitrarySignature is the ADR-036 signature of arbitrary data
+type ArbitrarySignature struct {
+	Signature []byte          `json:"signature"`
+	PubKey    tmcrypto.PubKey `json:"pub_key"`
+	Address   string          `json:"address"`
+}
+
+type arbitrarySignatureJSON struct {
+	Signature []byte          `json:"signature"`
+	PubKey    json.RawMessage `json:"pub_key"`
+	Address   string          `json:"address"`
+}
+
+// MarshalJSON encodes the public key as its amino JSON, {"type":"tendermint/PubKeySecp256k1","value":"<base64>"}
+// as the wallets do
+func (s ArbitrarySignature) MarshalJSON() ([]byte, error) {
+	var pubKey []byte
+	if s.PubKey != nil {
+		bz, err := cryptoamino.MarshalPubKeyJSON(s.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		pubKey = bz
+	}
+	return json.Marshal(arbitrarySignatureJSON{Signature: s.Signature, PubKey: pubKey, Address: s.Address})
+}
+
+func (s *ArbitrarySignature) UnmarshalJSON(bz []byte) error {
+	var sig arbitrarySignatureJSON
+	if err := json.Unmarshal(bz, &sig); err != nil {
+		return err
+	}
+
+	var pubKey tmcrypto.PubKey
+	if len(sig.PubKey) > 0 && string(sig.PubKey) != "null" {
+		pk, err := cryptoamino.PubKeyFromJSON(sig.PubKey)
+		if err != nil {
+			return err
+		}
+		pubKey = pk
+	}
+	*s = ArbitrarySignature{Signature: sig.Signature, PubKey: pubKey, Address: sig.Address}
+	return nil
+}
+
+type signDataDoc struct {
+	AccountNumber string        `json:"account_number"`
+	ChainID       string        `json:"chain_id"`
+	Fee           signDataFee   `json:"fee"`
+	Memo          string        `json:"memo"`
+	Msgs          []signDataMsg `json:"msgs"`
+	Sequence      string        `json:"sequence"`
+}
+
+type signDataFee struct {
+	Amount []sdk.Coin `json:"amount"`
+	Gas    string     `json:"gas"`
+}
+
+type signDataMsg struct {
+	Type  string `json:"type"`
+	Value struct {
+		Data   []byte `json:"data"`
+		Signer string `json:"signer"`
+	} `json:"value"`
+}
+
+// ArbitrarySignBytes returns the ADR-036 sign bytes of the data: the amino JSON sign doc of a MsgSignData
+// of the signer, with an empty chain-id, a zero fee, account number and sequence, so that the signature
+// can never be a valid tx
+func ArbitrarySignBytes(signer string, data []byte) []byte {
+	msg := signDataMsg{Type: MsgSignDataType}
+	msg.Value.Data, msg.Value.Signer = data, signer
+
+	bz, err := json.Marshal(signDataDoc{
+		AccountNumber: "0",
+		Fee:           signDataFee{Amount: []sdk.Coin{}, Gas: "0"},
+		Msgs:          []signDataMsg{msg},
+		Sequence:      "0",
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// VerifyArbitrary verifies the ADR-036 signature of the data by the bech32 address, any registered key type
+// is supported
+func VerifyArbitrary(address string, data []byte, signature ArbitrarySignature) sdk.Error {
+	addr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+	if signature.PubKey == nil {
+		return sdk.Wrapf("missing pubkey")
+	}
+	if !addr.Equals(sdk.AccAddress(signature.PubKey.Address())) {
+		return sdk.Wrapf("pubkey doesn't match the address %s", address)
+	}
+	if signature.Address != "" && signature.Address != address {
+		return sdk.Wrapf("signature of %s, not %s", signature.Address, address)
+	}
+	if !signature.PubKey.VerifySignature(ArbitrarySignBytes(address, data), signature.Signature) {
+		return sdk.Wrapf("invalid signature of %s", address)
+	}
+	return nil
+}
+
+func (k keysClient) SignArbitrary(name, password string, data []byte) (ArbitrarySignature, sdk.Error) {
+	_, address, err := k.KeyManager.Find(name, password)
+	if err != nil {
+		return ArbitrarySignature{}, sdk.Wrap(err)
+	}
+
+	signature, pubKey, err := k.KeyManager.Sign(name, password, ArbitrarySignBytes(address.String(), data))
+	if err != nil {
+		return ArbitrarySignature{}, sdk.Wrap(err)
+	}
+	return ArbitrarySignature{Signature: signature, PubKey: pubKey, Address: address.String()}, nil
+}
+
+func (k keysClient) VerifyArbitrary(address string, data []byte, signature ArbitrarySignature) sdk.Error {
+	return VerifyArbitrary(address, data, signature)
+}
@@ -0,0 +1,54 @@
+package keys_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/keys"
+)
+
+func TestSignArbitrary(t *testing.T) {
+	_, client, alice, bob := fakechaintest.Setup(t)
+
+	require.Equal(t,
+		`{"account_number":"0","chain_id":"","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[{"type":"sign/MsgSignData","value":{"data":"bG9naW4=","signer":"`+alice+`"}}],"sequence":"0"}`,
+		string(keys.ArbitrarySignBytes(alice, []byte("login"))),
+	)
+
+	_, _, err := client.Key.AddWithAlgo("carol", fakechaintest.Password, "sm2")
+	require.NoError(t, err)
+	_, _, err = client.Key.AddWithAlgo("dave", fakechaintest.Password, "ed25519")
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "carol", "dave"} {
+		data := []byte("nonce 42")
+		signature, sdkErr := client.Key.SignArbitrary(name, fakechaintest.Password, data)
+		require.NoError(t, sdkErr)
+
+		address, sdkErr := client.Key.Show(name, fakechaintest.Password)
+		require.NoError(t, sdkErr)
+		require.Equal(t, address, signature.Address)
+
+		// the signature travels as JSON to the server
+		bz, err := json.Marshal(signature)
+		require.NoError(t, err)
+		var received keys.ArbitrarySignature
+		require.NoError(t, json.Unmarshal(bz, &received))
+		require.NoError(t, keys.VerifyArbitrary(address, data, received))
+		require.NoError(t, client.Key.VerifyArbitrary(address, data, received))
+
+		require.Error(t, keys.VerifyArbitrary(address, []byte("nonce 43"), received))
+		require.Error(t, keys.VerifyArbitrary(bob, data, received))
+	}
+
+	// the address of the signature is optional, the signature is always verified
+	signature, err := client.Key.SignArbitrary("alice", fakechaintest.Password, []byte("login"))
+	require.NoError(t, err)
+	signature.Address = ""
+	require.NoError(t, keys.VerifyArbitrary(alice, []byte("login"), signature))
+	signature.Signature[0] ^= 1
+	require.Error(t, keys.VerifyArbitrary(alice, []byte("login"), signature))
+}
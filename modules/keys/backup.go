@@ -0,0 +1,294 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/keystore"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+)
+
+// backupArchive is the archive written by Backup, the crypto holds the JSON of the backupKeys
+type backupArchive struct {
+	Version   int                 `json:"version"`
+	CreatedAt time.Time           `json:"created_at"`
+	Crypto    keystore.CryptoJSON `json:"crypto"`
+}
+
+// backupKey is a key of the archive, the armor of the private key is encrypted with the backup password,
+// the watch-only keys have no armor
+type backupKey struct {
+	Name      string    `json:"name"`
+	Algo      string    `json:"algo"`
+	Address   string    `json:"address"`
+	PubKey    []byte    `json:"pubkey,omitempty"`
+	HDPath    string    `json:"hd_path,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Armor     string    `json:"armor,omitempty"`
+}
+
+// restoredKey is a key of the archive decrypted before anything is written
+type restoredKey struct {
+	backupKey
+	pubKey  tmcrypto.PubKey
+	privKey tmcrypto.PrivKey
+	algo    string
+}
+
+func (k keysClient) Backup(w io.Writer, backupPassword string, opts BackupOptions) sdk.Error {
+	if backupPassword == "" {
+		return sdk.Wrapf("backup password is empty")
+	}
+
+	keys, err := k.KeyManager.List()
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	backupKeys := make([]backupKey, 0, len(keys))
+	for _, key := range keys {
+		bk := backupKey{
+			Name:      key.Name,
+			Algo:      key.Algo,
+			Address:   key.Address.String(),
+			HDPath:    key.HDPath,
+			CreatedAt: key.CreatedAt,
+		}
+		if key.PubKey != nil {
+			bk.PubKey = cryptoamino.MarshalPubkey(key.PubKey)
+		}
+
+		if !key.WatchOnly {
+			password, sdkErr := keyPassword(opts.Passwords, key.Name, backupPassword)
+			if sdkErr != nil {
+				return sdkErr
+			}
+			armor, err := k.KeyManager.Export(key.Name, password)
+			if err != nil {
+				return sdk.WrapWithMessage(err, "export %s", key.Name)
+			}
+			if password != backupPassword {
+				privKey, algo, err := crypto.UnarmorDecryptPrivKey(armor, password)
+				if err != nil {
+					return sdk.WrapWithMessage(err, "export %s", key.Name)
+				}
+				armor = crypto.EncryptArmorPrivKey(privKey, backupPassword, algo)
+			}
+			bk.Armor = armor
+		}
+		backupKeys = append(backupKeys, bk)
+	}
+
+	bz, err := json.Marshal(backupKeys)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	kdf := opts.KDF
+	if kdf == nil {
+		kdf = keystore.Scrypt{}
+	}
+	cryptoJSON, err := keystore.EncryptData(bz, backupPassword, kdf)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	err = json.NewEncoder(w).Encode(backupArchive{
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+		Crypto:    cryptoJSON,
+	})
+	return sdk.Wrap(err)
+}
+
+func (k keysClient) Restore(r io.Reader, backupPassword string, opts RestoreOptions) ([]RestoredKey, sdk.Error) {
+	conflict := opts.Conflict
+	switch conflict {
+	case "":
+		conflict = ConflictSkip
+	case ConflictSkip, ConflictOverwrite, ConflictRename:
+	default:
+		return nil, sdk.Wrapf("unknown conflict policy %s", conflict)
+	}
+
+	keys, err := decryptBackup(r, backupPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// the names used by the keys restored in dry run
+	taken := make(map[string]bool, len(keys))
+	exists := func(name string) bool {
+		if taken[name] {
+			return true
+		}
+		return k.keyDAO.Has(name)
+	}
+
+	restored := make([]RestoredKey, 0, len(keys))
+	for _, key := range keys {
+		rk := RestoredKey{
+			Name:       key.Name,
+			RestoredAs: key.Name,
+			Address:    key.Address,
+			Algo:       key.Algo,
+			WatchOnly:  key.privKey == nil,
+			CreatedAt:  key.CreatedAt,
+			Action:     RestoreCreated,
+		}
+
+		password, err := keyPassword(opts.Passwords, key.Name, backupPassword)
+		if err != nil {
+			return restored, err
+		}
+
+		if exists(key.Name) {
+			switch conflict {
+			case ConflictSkip:
+				rk.RestoredAs, rk.Action = "", RestoreSkipped
+				restored = append(restored, rk)
+				continue
+			case ConflictOverwrite:
+				rk.Action = RestoreOverwritten
+				if !opts.DryRun {
+					if err := k.overwriteKey(key.Name, password, key); err != nil {
+						return restored, sdk.WrapWithMessage(err, "overwrite %s", key.Name)
+					}
+				}
+				taken[rk.RestoredAs] = true
+				restored = append(restored, rk)
+				continue
+			case ConflictRename:
+				rk.Action = RestoreRenamed
+				for i := 1; exists(rk.RestoredAs); i++ {
+					rk.RestoredAs = fmt.Sprintf("%s-%d", key.Name, i)
+				}
+			}
+		}
+
+		if !opts.DryRun {
+			if err := k.restoreKey(rk.RestoredAs, password, key); err != nil {
+				return restored, sdk.WrapWithMessage(err, "restore %s", key.Name)
+			}
+		}
+		taken[rk.RestoredAs] = true
+		restored = append(restored, rk)
+	}
+	return restored, nil
+}
+
+// restoreKey writes the key of the archive under the name, encrypted with the password, with its HD path and
+// creation time
+func (k keysClient) restoreKey(name, password string, key restoredKey) error {
+	if k.keyDAO.Has(name) {
+		return fmt.Errorf("%s has existed", name)
+	}
+
+	info := store.KeyInfo{
+		Name:      name,
+		Algo:      key.algo,
+		HDPath:    key.HDPath,
+		CreatedAt: key.CreatedAt,
+	}
+	switch {
+	case key.privKey != nil:
+		info.PubKey = cryptoamino.MarshalPubkey(key.privKey.PubKey())
+		info.PrivKeyArmor = string(cryptoamino.MarshalPrivKey(key.privKey))
+	case key.pubKey != nil:
+		info.PubKey, info.Algo = cryptoamino.MarshalPubkey(key.pubKey), key.pubKey.Type()
+	default:
+		info.Address = key.Address
+	}
+	return k.keyDAO.Write(name, password, info)
+}
+
+// overwriteKey restores the key of the archive under a temporary name, then replaces the existing key, verified
+// by the password, so that the existing key is only deleted once the restored key is written
+func (k keysClient) overwriteKey(name, password string, key restoredKey) error {
+	tmpName := name + ".restore"
+	for i := 1; k.keyDAO.Has(tmpName); i++ {
+		tmpName = fmt.Sprintf("%s.restore-%d", name, i)
+	}
+	if err := k.restoreKey(tmpName, password, key); err != nil {
+		return err
+	}
+
+	if err := k.keyDAO.Delete(name, password); err != nil {
+		_ = k.keyDAO.Delete(tmpName, password)
+		return err
+	}
+	if err := k.keyDAO.Rename(tmpName, name, password); err != nil {
+		return fmt.Errorf("the key is restored as %s: %w", tmpName, err)
+	}
+	return nil
+}
+
+// decryptBackup decrypts every key of the archive, so that a corrupted archive fails before anything is restored
+func decryptBackup(r io.Reader, backupPassword string) ([]restoredKey, sdk.Error) {
+	if backupPassword == "" {
+		return nil, sdk.Wrapf("backup password is empty")
+	}
+
+	var archive backupArchive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, sdk.WrapWithMessage(err, "invalid backup archive")
+	}
+	if archive.Version != BackupVersion {
+		return nil, sdk.Wrapf("unsupported backup archive version %d", archive.Version)
+	}
+
+	bz, err := keystore.DecryptData(archive.Crypto, backupPassword)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	var backupKeys []backupKey
+	if err := json.Unmarshal(bz, &backupKeys); err != nil {
+		return nil, sdk.WrapWithMessage(err, "invalid backup archive")
+	}
+
+	keys := make([]restoredKey, len(backupKeys))
+	for i, bk := range backupKeys {
+		key := restoredKey{backupKey: bk, algo: bk.Algo}
+		if len(bk.PubKey) > 0 {
+			pubKey, err := cryptoamino.PubKeyFromBytes(bk.PubKey)
+			if err != nil {
+				return nil, sdk.WrapWithMessage(err, "invalid public key of %s", bk.Name)
+			}
+			key.pubKey = pubKey
+		}
+
+		if bk.Armor != "" {
+			privKey, algo, err := crypto.UnarmorDecryptPrivKey(bk.Armor, backupPassword)
+			if err != nil {
+				return nil, sdk.WrapWithMessage(err, "invalid private key of %s", bk.Name)
+			}
+			key.privKey, key.algo = privKey, algo
+			key.pubKey = privKey.PubKey()
+		}
+
+		if key.pubKey != nil && sdk.AccAddress(key.pubKey.Address()).String() != bk.Address {
+			return nil, sdk.Wrapf("the key of %s doesn't match its address %s", bk.Name, bk.Address)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// keyPassword returns the password of the key, the backup password when passwords is nil
+func keyPassword(passwords PasswordFunc, name, backupPassword string) (string, sdk.Error) {
+	if passwords == nil {
+		return backupPassword, nil
+	}
+	password, err := passwords(name)
+	if err != nil {
+		return "", sdk.WrapWithMessage(err, "get the password of %s", name)
+	}
+	return password, nil
+}
@@ -0,0 +1,114 @@
+package keys_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/keystore"
+	"github.com/irisnet/irishub-sdk-go/modules/keys"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+)
+
+func TestKeysBackup(t *testing.T) {
+	_, client, alice, bob := fakechaintest.Setup(t)
+	_, sdkErr := client.Key.ImportWatchOnly("carol", fakechaintest.Password, bob, nil)
+	require.NoError(t, sdkErr)
+
+	const backupPassword = "backup-fakechaintest.Password"
+	passwords := func(string) (string, error) { return fakechaintest.Password, nil }
+	var archive bytes.Buffer
+	sdkErr = client.Key.Backup(&archive, backupPassword, keys.BackupOptions{
+		Passwords: passwords,
+		KDF:       keystore.Scrypt{N: keystore.LightScryptN, P: keystore.LightScryptP},
+	})
+	require.NoError(t, sdkErr)
+	require.NotContains(t, archive.String(), alice)
+
+	// another keystore with the same names, which verifies the passwords
+	dao, err := store.NewLevelDB(t.TempDir(), nil)
+	require.NoError(t, err)
+	_, other, _, _ := fakechaintest.Setup(t, types.KeyDAOOption(dao))
+	restore := func(password string, opts keys.RestoreOptions) ([]keys.RestoredKey, types.Error) {
+		return other.Key.Restore(bytes.NewReader(archive.Bytes()), password, opts)
+	}
+
+	_, sdkErr = restore("wrong-fakechaintest.Password", keys.RestoreOptions{})
+	require.Error(t, sdkErr)
+
+	restored, sdkErr := restore(backupPassword, keys.RestoreOptions{Conflict: keys.ConflictRename, DryRun: true})
+	require.NoError(t, sdkErr)
+	require.Equal(t, []keys.RestoredKey{
+		{Name: "alice", RestoredAs: "alice-1", Address: alice, Algo: "secp256k1", Action: keys.RestoreRenamed},
+		{Name: "bob", RestoredAs: "bob-1", Address: bob, Algo: "secp256k1", Action: keys.RestoreRenamed},
+		{Name: "carol", RestoredAs: "carol", Address: bob, WatchOnly: true, Action: keys.RestoreCreated},
+	}, withoutCreatedAt(restored))
+	list, sdkErr := other.Key.List()
+	require.NoError(t, sdkErr)
+	require.Len(t, list, 2)
+
+	restored, sdkErr = restore(backupPassword, keys.RestoreOptions{})
+	require.NoError(t, sdkErr)
+	require.Equal(t, []keys.RestoreAction{keys.RestoreSkipped, keys.RestoreSkipped, keys.RestoreCreated}, restoreActions(restored))
+	key, sdkErr := other.Key.ShowPublic("carol")
+	require.NoError(t, sdkErr)
+	require.True(t, key.WatchOnly)
+	require.Equal(t, bob, key.Address.String())
+
+	restored, sdkErr = restore(backupPassword, keys.RestoreOptions{Conflict: keys.ConflictRename})
+	require.NoError(t, sdkErr)
+	require.Equal(t, []string{"alice-1", "bob-1", "carol-1"}, []string{restored[0].RestoredAs, restored[1].RestoredAs, restored[2].RestoredAs})
+	address, sdkErr := other.Key.Show("alice-1", backupPassword)
+	require.NoError(t, sdkErr)
+	require.Equal(t, alice, address)
+
+	// the HD path and the creation time are restored
+	original, sdkErr := client.Key.ShowPublic("alice")
+	require.NoError(t, sdkErr)
+	key, sdkErr = other.Key.ShowPublic("alice-1")
+	require.NoError(t, sdkErr)
+	require.NotEmpty(t, key.HDPath)
+	require.Equal(t, original.HDPath, key.HDPath)
+	require.True(t, original.CreatedAt.Equal(key.CreatedAt))
+
+	// an overwrite with the wrong password keeps the existing key
+	existing, sdkErr := other.Key.ShowPublic("alice")
+	require.NoError(t, sdkErr)
+	_, sdkErr = restore(backupPassword, keys.RestoreOptions{Conflict: keys.ConflictOverwrite})
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "overwrite alice")
+	key, sdkErr = other.Key.ShowPublic("alice")
+	require.NoError(t, sdkErr)
+	require.Equal(t, existing.Address, key.Address)
+	list, sdkErr = other.Key.List()
+	require.NoError(t, sdkErr)
+	require.Len(t, list, 6)
+
+	restored, sdkErr = restore(backupPassword, keys.RestoreOptions{Conflict: keys.ConflictOverwrite, Passwords: passwords})
+	require.NoError(t, sdkErr)
+	require.Equal(t, []keys.RestoreAction{keys.RestoreOverwritten, keys.RestoreOverwritten, keys.RestoreOverwritten}, restoreActions(restored))
+	address, sdkErr = other.Key.Show("alice", fakechaintest.Password)
+	require.NoError(t, sdkErr)
+	require.Equal(t, alice, address)
+	_, sdkErr = other.Key.Export("bob", fakechaintest.Password)
+	require.NoError(t, sdkErr)
+}
+
+func withoutCreatedAt(restored []keys.RestoredKey) []keys.RestoredKey {
+	for i := range restored {
+		restored[i].CreatedAt = time.Time{}
+	}
+	return restored
+}
+
+func restoreActions(restored []keys.RestoredKey) []keys.RestoreAction {
+	actions := make([]keys.RestoreAction, len(restored))
+	for i, key := range restored {
+		actions[i] = key.Action
+	}
+	return actions
+}
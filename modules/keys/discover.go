@@ -0,0 +1,122 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+)
+
+const defaultNamePrefix = "account"
+
+func (k keysClient) Discover(mnemonic, password string, opts DiscoverOptions) ([]DiscoveredKey, sdk.Error) {
+	if opts.Algo == "" {
+		opts.Algo = k.algo
+	}
+	if opts.GapLimit == 0 {
+		opts.GapLimit = DefaultGapLimit
+	}
+	if opts.NamePrefix == "" {
+		opts.NamePrefix = defaultNamePrefix
+	}
+
+	var discovered []DiscoveredKey
+	for account := uint32(0); ; account++ {
+		keys, err := k.discoverAccount(mnemonic, account, opts)
+		if err != nil {
+			return discovered, err
+		}
+		// BIP44: the discovery stops at the first account without any used address
+		if len(keys) == 0 {
+			return discovered, nil
+		}
+
+		for _, key := range keys {
+			if err := k.recoverDiscovered(key, password, mnemonic, opts); err != nil {
+				return discovered, err
+			}
+			discovered = append(discovered, key)
+		}
+	}
+}
+
+// discoverAccount returns the used addresses of the account, scanning until opts.GapLimit consecutive unused addresses
+func (k keysClient) discoverAccount(mnemonic string, account uint32, opts DiscoverOptions) ([]DiscoveredKey, sdk.Error) {
+	var keys []DiscoveredKey
+	for index, gap := uint32(0), uint32(0); gap < opts.GapLimit; index++ {
+		hdPath := hd.NewAccountParams(account, index).String()
+		km, err := crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, opts.BIP39Passphrase, opts.Algo, hdPath)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		address := sdk.AccAddress(km.ExportPubKey().Address()).String()
+
+		used, err := k.used(address)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		if !used {
+			gap++
+			continue
+		}
+
+		gap = 0
+		keys = append(keys, DiscoveredKey{
+			Name:    fmt.Sprintf("%s-%d-%d", opts.NamePrefix, account, index),
+			Address: address,
+			HDPath:  hdPath,
+			Account: account,
+			Index:   index,
+		})
+	}
+	return keys, nil
+}
+
+// used returns true when the account of the address exists on chain, or the address sent or received a tx
+func (k keysClient) used(address string) (bool, error) {
+	// the query fails when the account doesn't exist, the errors of the node are returned by QueryTxs below
+	if _, err := k.queries.QueryAccount(address); err == nil {
+		return true, nil
+	}
+
+	size := 1
+	builders := []*sdk.EventQueryBuilder{
+		sdk.NewEventQueryBuilder().AddCondition(sdk.NewCond(sdk.EventTypeMessage, sdk.AttributeKeySender).EQ(sdk.EventValue(address))),
+		sdk.NewEventQueryBuilder().AddCondition(sdk.Cond("transfer.recipient").EQ(sdk.EventValue(address))),
+	}
+	for _, builder := range builders {
+		res, err := k.queries.QueryTxs(builder, nil, &size)
+		if err != nil {
+			return false, err
+		}
+		if res.Total > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recoverDiscovered recovers the key into the KeyDAO, the key already recovered by a previous discovery is kept
+func (k keysClient) recoverDiscovered(key DiscoveredKey, password, mnemonic string, opts DiscoverOptions) sdk.Error {
+	existing, err := k.KeyManager.ShowPublic(key.Name)
+	switch {
+	case err == nil:
+		if existing.Address.String() != key.Address {
+			return sdk.Wrapf("name %s already exists with another address %s", key.Name, existing.Address)
+		}
+		return nil
+	case errors.Is(err, store.ErrUnsupported):
+		// the existing keys can't be checked
+		return sdk.Wrap(err)
+	}
+
+	_, err = k.KeyManager.RecoverWithOptions(key.Name, password, mnemonic, sdk.KeyOptions{
+		Algo:            opts.Algo,
+		BIP39Passphrase: opts.BIP39Passphrase,
+		HDPath:          key.HDPath,
+	})
+	return sdk.Wrap(err)
+}
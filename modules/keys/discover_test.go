@@ -0,0 +1,86 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules/keys"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestDiscoverAccounts(t *testing.T) {
+	chain, client, _, _ := fakechaintest.Setup(t)
+
+	mnemonic, err := crypto.NewMnemonic(24)
+	require.NoError(t, err)
+	address := func(account, index uint32) string {
+		km, err := crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, "", "secp256k1", hd.NewAccountParams(account, index).String())
+		require.NoError(t, err)
+		return types.AccAddress(km.ExportPubKey().Address()).String()
+	}
+
+	// the account 0 is funded at the indexes 0 and 3, the account 1 receives a tx at the index 1
+	require.NoError(t, chain.Fund(address(0, 0), types.NewInt64Coin(types.BaseDenom, 1)))
+	require.NoError(t, chain.Fund(address(0, 3), types.NewInt64Coin(types.BaseDenom, 1)))
+	amount, err := types.ParseDecCoins("1iris")
+	require.NoError(t, err)
+	_, err = client.Bank.Send(address(1, 1), amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	// beyond the gap limit
+	require.NoError(t, chain.Fund(address(0, 9), types.NewInt64Coin(types.BaseDenom, 1)))
+	// after the first unused account
+	require.NoError(t, chain.Fund(address(3, 0), types.NewInt64Coin(types.BaseDenom, 1)))
+
+	discovered, err := client.Key.Discover(mnemonic, fakechaintest.Password, keys.DiscoverOptions{GapLimit: 3})
+	require.NoError(t, err)
+	require.Equal(t, []keys.DiscoveredKey{
+		{Name: "account-0-0", Address: address(0, 0), HDPath: "44'/118'/0'/0/0", Account: 0, Index: 0},
+		{Name: "account-0-3", Address: address(0, 3), HDPath: "44'/118'/0'/0/3", Account: 0, Index: 3},
+		{Name: "account-1-1", Address: address(1, 1), HDPath: "44'/118'/1'/0/1", Account: 1, Index: 1},
+	}, discovered)
+
+	for _, key := range discovered {
+		addr, err := client.Key.Show(key.Name, fakechaintest.Password)
+		require.NoError(t, err)
+		require.Equal(t, key.Address, addr)
+	}
+
+	// the keys recovered by a previous discovery are kept
+	again, err := client.Key.Discover(mnemonic, fakechaintest.Password, keys.DiscoverOptions{GapLimit: 3})
+	require.NoError(t, err)
+	require.Equal(t, discovered, again)
+
+	// the default gap limit reaches the index 9
+	discovered, err = client.Key.Discover(mnemonic, fakechaintest.Password, keys.DiscoverOptions{NamePrefix: "wallet"})
+	require.NoError(t, err)
+	require.Len(t, discovered, 4)
+	require.Equal(t, "wallet-0-9", discovered[2].Name)
+}
+
+func TestDiscoverAccountsAlgo(t *testing.T) {
+	chain, client, _, _ := fakechaintest.Setup(t, types.AlgoOption("sm2"))
+
+	mnemonic, err := crypto.NewMnemonic(24)
+	require.NoError(t, err)
+	km, err := crypto.NewMnemonicKeyManagerWithPassphrase(mnemonic, "", "sm2", hd.NewAccountParams(0, 0).String())
+	require.NoError(t, err)
+	address := types.AccAddress(km.ExportPubKey().Address()).String()
+	require.NoError(t, chain.Fund(address, types.NewInt64Coin(types.BaseDenom, 1)))
+
+	// the addresses of the algorithm of the client are scanned, as recovered by default
+	discovered, err := client.Key.Discover(mnemonic, fakechaintest.Password, keys.DiscoverOptions{GapLimit: 3})
+	require.NoError(t, err)
+	require.Len(t, discovered, 1)
+	require.Equal(t, address, discovered[0].Address)
+
+	recovered, err := client.Key.Recover("carol", fakechaintest.Password, mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, address, recovered)
+	key, err := client.Key.ShowPublic(discovered[0].Name)
+	require.NoError(t, err)
+	require.Equal(t, "sm2", key.Algo)
+}
@@ -1,15 +1,149 @@
 package keys
 
 import (
+	"io"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/keystore"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 )
 
 type Client interface {
 	Add(name, password string) (address string, mnemonic string, err sdk.Error)
+	AddWithAlgo(name, password, algo string) (address string, mnemonic string, err sdk.Error)
+	// AddWithOptions creates a key with the algorithm, the BIP39 passphrase, the number of words of the mnemonic and the hd path of the options
+	AddWithOptions(name, password string, opts sdk.KeyOptions) (address string, mnemonic string, err sdk.Error)
 	Recover(name, password, mnemonic string) (address string, err sdk.Error)
 	RecoverWithHDPath(name, password, mnemonic, hdPath string) (address string, err sdk.Error)
+	RecoverWithAlgo(name, password, mnemonic, hdPath, algo string) (address string, err sdk.Error)
+	RecoverWithOptions(name, password, mnemonic string, opts sdk.KeyOptions) (address string, err sdk.Error)
+	// RecoverAccount recovers the key of the address index of the account, i.e. m/44'/118'/account'/0/index,
+	// from the mnemonic and the BIP39 passphrase
+	RecoverAccount(name, password, mnemonic, bip39Passphrase string, account, index uint32) (address string, err sdk.Error)
 	Import(name, password, privKeyArmor string) (address string, err sdk.Error)
 	Export(name, password string) (privKeyArmor string, err sdk.Error)
+	// ImportKeystore imports the secp256k1 key of a keystore JSON, e.g. the Web3 Secret Storage,
+	// the password decrypts the keystore and encrypts the imported key
+	ImportKeystore(name, password string, keystoreJSON []byte) (address string, err sdk.Error)
+	// ExportKeystore exports the secp256k1 key as the Web3 Secret Storage JSON encrypted with the password by the kdf,
+	// keystore.Scrypt{} when nil
+	ExportKeystore(name, password string, kdf keystore.KDF) (keystoreJSON []byte, err sdk.Error)
+	// Discover scans the accounts of the mnemonic for the addresses used on chain and recovers every used address
+	// into the KeyDAO, see DiscoverOptions
+	Discover(mnemonic, password string, opts DiscoverOptions) ([]DiscoveredKey, sdk.Error)
+	// SplitMnemonic splits the entropy of the mnemonic into Shamir shares, any threshold of which recover the key
+	SplitMnemonic(mnemonic string, shares, threshold int) ([]string, sdk.Error)
+	// SplitKey splits the private key of a stored key into Shamir shares, any threshold of which recover the key
+	SplitKey(name, password string, shares, threshold int) ([]string, sdk.Error)
+	// RecoverFromShares recovers the key of at least the threshold of the shares into the KeyDAO, the options apply
+	// to the shares of a mnemonic. The mistyped, mismatched or wrong shares are detected.
+	RecoverFromShares(name, password string, shares []string, opts sdk.KeyOptions) (address string, err sdk.Error)
+	// SignArbitrary signs the data off chain as specified by ADR-036, for the login with a wallet or the proof of ownership
+	SignArbitrary(name, password string, data []byte) (ArbitrarySignature, sdk.Error)
+	// VerifyArbitrary verifies the ADR-036 signature of the data by the bech32 address, see also the function VerifyArbitrary
+	VerifyArbitrary(address string, data []byte, signature ArbitrarySignature) sdk.Error
+	// ImportWatchOnly adds a watch-only key of the public key, or of the bech32 address when pubKey is nil. The key
+	// is usable as the From of BuildUnsignedTx, signing with it fails with types.ErrWatchOnly.
+	ImportWatchOnly(name, password, address string, pubKey tmcrypto.PubKey) (string, sdk.Error)
+	// Backup writes every key, with its metadata, to a single archive encrypted with the backup password,
+	// see BackupOptions
+	Backup(w io.Writer, backupPassword string, opts BackupOptions) sdk.Error
+	// Restore restores the keys of an archive written by Backup to the KeyDAO of the config, with their metadata,
+	// see RestoreOptions. It returns what was done, or would be done in dry run, for every key of the archive.
+	Restore(r io.Reader, backupPassword string, opts RestoreOptions) ([]RestoredKey, sdk.Error)
 	Delete(name, password string) sdk.Error
 	Show(name, password string) (string, sdk.Error)
+	List() ([]sdk.KeyMetadata, sdk.Error)
+	ShowPublic(name string) (sdk.KeyMetadata, sdk.Error)
+	ChangePassword(name, oldPassword, newPassword string) sdk.Error
+	Rename(name, newName, password string) sdk.Error
+}
+
+// DefaultGapLimit is the number of consecutive unused addresses ending the scan of an account, as in BIP44
+const DefaultGapLimit = 20
+
+// DiscoverOptions are the options of the account discovery. The accounts m/44'/118'/account'/0/index are scanned
+// from the account 0 until an account without any used address, and the addresses of an account until GapLimit
+// consecutive unused addresses. An address is used when the account exists on chain or appears in a tx.
+type DiscoverOptions struct {
+	// Algo is the algorithm of the keys, the algorithm of the client when empty as for the recovered keys
+	Algo string
+	// BIP39Passphrase is the optional passphrase of the mnemonic
+	BIP39Passphrase string
+	// GapLimit is DefaultGapLimit when zero
+	GapLimit uint32
+	// NamePrefix names the recovered keys <NamePrefix>-<account>-<index>, "account" when empty
+	NamePrefix string
+}
+
+// DiscoveredKey is a used address recovered by Discover
+type DiscoveredKey struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	HDPath  string `json:"hd_path"`
+	Account uint32 `json:"account"`
+	Index   uint32 `json:"index"`
+}
+
+// BackupVersion is the version of the archives written by Backup
+const BackupVersion = 1
+
+// PasswordFunc returns the password of the key of the name
+type PasswordFunc func(name string) (string, error)
+
+// BackupOptions are the options of Backup
+type BackupOptions struct {
+	// Passwords returns the password of every key, every key is encrypted with the backup password when nil
+	Passwords PasswordFunc
+	// KDF derives the encryption key of the archive from the backup password, keystore.Scrypt{} when nil
+	KDF keystore.KDF
+}
+
+// ConflictPolicy is how Restore handles a key of the archive whose name is already used
+type ConflictPolicy string
+
+const (
+	// ConflictSkip keeps the existing key, the default
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite replaces the existing key, verified by its password, by the key of the archive, which is
+	// written under a temporary name first
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictRename restores the key of the archive as <name>-1, <name>-2... the first free name
+	ConflictRename ConflictPolicy = "rename"
+)
+
+// RestoreOptions are the options of Restore
+type RestoreOptions struct {
+	// Conflict is ConflictSkip when empty
+	Conflict ConflictPolicy
+	// Passwords returns the password encrypting every restored key, under its name in the archive, and verifying
+	// the overwritten keys. The restored keys are encrypted with the backup password when nil.
+	Passwords PasswordFunc
+	// DryRun only lists what would be restored, nothing is written
+	DryRun bool
+}
+
+// RestoreAction is what Restore did with a key of the archive
+type RestoreAction string
+
+const (
+	RestoreCreated     RestoreAction = "created"
+	RestoreSkipped     RestoreAction = "skipped"
+	RestoreOverwritten RestoreAction = "overwritten"
+	RestoreRenamed     RestoreAction = "renamed"
+)
+
+// RestoredKey is a key of the archive restored by Restore
+type RestoredKey struct {
+	// Name is the name of the key in the archive
+	Name string `json:"name"`
+	// RestoredAs is the name of the restored key, empty when skipped
+	RestoredAs string        `json:"restored_as,omitempty"`
+	Address    string        `json:"address"`
+	Algo       string        `json:"algo"`
+	WatchOnly  bool          `json:"watch_only,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Action     RestoreAction `json:"action"`
 }
@@ -1,15 +1,26 @@
 package keys
 
 import (
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
+	"github.com/irisnet/irishub-sdk-go/keystore"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
 )
 
 type keysClient struct {
 	sdk.KeyManager
+	queries sdk.Queries
+	// keyDAO stores the keys restored by Restore
+	keyDAO store.KeyDAO
+	// algo is the algorithm of the keys created by the client
+	algo string
 }
 
-func NewClient(keyManager sdk.KeyManager) Client {
-	return keysClient{keyManager}
+func NewClient(baseClient sdk.BaseClient, keyDAO store.KeyDAO, algo string) Client {
+	return keysClient{KeyManager: baseClient, queries: baseClient, keyDAO: keyDAO, algo: algo}
 }
 
 func (k keysClient) Add(name, password string) (string, string, sdk.Error) {
@@ -17,6 +28,16 @@ func (k keysClient) Add(name, password string) (string, string, sdk.Error) {
 	return address, mnemonic, sdk.Wrap(err)
 }
 
+func (k keysClient) AddWithAlgo(name, password, algo string) (string, string, sdk.Error) {
+	address, mnemonic, err := k.InsertWithAlgo(name, password, algo)
+	return address, mnemonic, sdk.Wrap(err)
+}
+
+func (k keysClient) AddWithOptions(name, password string, opts sdk.KeyOptions) (string, string, sdk.Error) {
+	address, mnemonic, err := k.InsertWithOptions(name, password, opts)
+	return address, mnemonic, sdk.Wrap(err)
+}
+
 func (k keysClient) Recover(name, password, mnemonic string) (string, sdk.Error) {
 	address, err := k.KeyManager.Recover(name, password, mnemonic, "")
 	return address, sdk.Wrap(err)
@@ -27,6 +48,24 @@ func (k keysClient) RecoverWithHDPath(name, password, mnemonic, hdPath string) (
 	return address, sdk.Wrap(err)
 }
 
+func (k keysClient) RecoverWithAlgo(name, password, mnemonic, hdPath, algo string) (string, sdk.Error) {
+	address, err := k.KeyManager.RecoverWithAlgo(name, password, mnemonic, hdPath, algo)
+	return address, sdk.Wrap(err)
+}
+
+func (k keysClient) RecoverWithOptions(name, password, mnemonic string, opts sdk.KeyOptions) (string, sdk.Error) {
+	address, err := k.KeyManager.RecoverWithOptions(name, password, mnemonic, opts)
+	return address, sdk.Wrap(err)
+}
+
+func (k keysClient) RecoverAccount(name, password, mnemonic, bip39Passphrase string, account, index uint32) (string, sdk.Error) {
+	address, err := k.KeyManager.RecoverWithOptions(name, password, mnemonic, sdk.KeyOptions{
+		BIP39Passphrase: bip39Passphrase,
+		HDPath:          hd.NewAccountParams(account, index).String(),
+	})
+	return address, sdk.Wrap(err)
+}
+
 func (k keysClient) Import(name, password, privKeyArmor string) (string, sdk.Error) {
 	address, err := k.KeyManager.Import(name, password, privKeyArmor)
 	return address, sdk.Wrap(err)
@@ -37,6 +76,40 @@ func (k keysClient) Export(name, password string) (string, sdk.Error) {
 	return keystore, sdk.Wrap(err)
 }
 
+func (k keysClient) ImportKeystore(name, password string, keystoreJSON []byte) (string, sdk.Error) {
+	privKey, err := keystore.DecryptKey(keystoreJSON, password)
+	if err != nil {
+		return "", sdk.Wrap(err)
+	}
+
+	armor := crypto.EncryptArmorPrivKey(privKey, password, string(hd.Secp256k1Type))
+	address, err := k.KeyManager.Import(name, password, armor)
+	return address, sdk.Wrap(err)
+}
+
+func (k keysClient) ExportKeystore(name, password string, kdf keystore.KDF) ([]byte, sdk.Error) {
+	armor, err := k.KeyManager.Export(name, password)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	privKey, _, err := crypto.UnarmorDecryptPrivKey(armor, password)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	if kdf == nil {
+		kdf = keystore.Scrypt{}
+	}
+	keystoreJSON, err := keystore.EncryptKey(privKey, password, kdf)
+	return keystoreJSON, sdk.Wrap(err)
+}
+
+func (k keysClient) ImportWatchOnly(name, password, address string, pubKey tmcrypto.PubKey) (string, sdk.Error) {
+	address, err := k.KeyManager.ImportWatchOnly(name, password, address, pubKey)
+	return address, sdk.Wrap(err)
+}
+
 func (k keysClient) Delete(name, password string) sdk.Error {
 	err := k.KeyManager.Delete(name, password)
 	return sdk.Wrap(err)
@@ -49,3 +122,23 @@ func (k keysClient) Show(name, password string) (string, sdk.Error) {
 	}
 	return address.String(), nil
 }
+
+func (k keysClient) List() ([]sdk.KeyMetadata, sdk.Error) {
+	keys, err := k.KeyManager.List()
+	return keys, sdk.Wrap(err)
+}
+
+func (k keysClient) ShowPublic(name string) (sdk.KeyMetadata, sdk.Error) {
+	key, err := k.KeyManager.ShowPublic(name)
+	return key, sdk.Wrap(err)
+}
+
+func (k keysClient) ChangePassword(name, oldPassword, newPassword string) sdk.Error {
+	err := k.KeyManager.ChangePassword(name, oldPassword, newPassword)
+	return sdk.Wrap(err)
+}
+
+func (k keysClient) Rename(name, newName, password string) sdk.Error {
+	err := k.KeyManager.Rename(name, newName, password)
+	return sdk.Wrap(err)
+}
@@ -0,0 +1,262 @@
+package keys_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/keystore"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/keys"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/store"
+)
+
+func TestKeys(t *testing.T) {
+	_, client, alice, bob := fakechaintest.Setup(t)
+
+	keys, err := client.Key.List()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, "alice", keys[0].Name)
+	require.Equal(t, alice, keys[0].Address.String())
+	require.Equal(t, bob, keys[1].Address.String())
+
+	key, err := client.Key.ShowPublic("bob")
+	require.NoError(t, err)
+	require.Equal(t, bob, key.Address.String())
+	require.Equal(t, "secp256k1", key.Algo)
+	require.False(t, key.CreatedAt.IsZero())
+	require.NotNil(t, key.PubKey)
+
+	require.NoError(t, client.Key.Rename("bob", "carol", fakechaintest.Password))
+	_, err = client.Key.ShowPublic("bob")
+	require.Error(t, err)
+	address, err := client.Key.Show("carol", fakechaintest.Password)
+	require.NoError(t, err)
+	require.Equal(t, bob, address)
+
+	// the in-memory store of the fakechain doesn't encrypt the keys
+	err = client.Key.ChangePassword("carol", fakechaintest.Password, "87654321")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+}
+
+func TestSendSm2(t *testing.T) {
+	chain, client, alice, bob := fakechaintest.Setup(t, types.AlgoOption("sm2"))
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+
+	key, err := client.Key.ShowPublic("alice")
+	require.NoError(t, err)
+	require.Equal(t, "sm2", key.PubKey.Type())
+
+	_, err = client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	require.Equal(t, "10000000", chain.Balances(bob).AmountOf(types.BaseDenom).String())
+
+	// the second tx is verified with the sm2 pubkey set on the account
+	_, err = client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+
+	account, err := client.QueryAccount(alice)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), account.Sequence)
+	require.NotEmpty(t, account.PubKey)
+}
+
+func TestKeysAlgo(t *testing.T) {
+	chain, client, _, bob := fakechaintest.Setup(t)
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+
+	carol, mnemonic, err := client.Key.AddWithAlgo("carol", fakechaintest.Password, "sm2")
+	require.NoError(t, err)
+	dave, daveMnemonic, err := client.Key.AddWithAlgo("dave", fakechaintest.Password, "ed25519")
+	require.NoError(t, err)
+	_, _, err = client.Key.AddWithAlgo("eve", fakechaintest.Password, "unknown")
+	require.Error(t, err)
+
+	keys, err := client.Key.List()
+	require.NoError(t, err)
+	algos := map[string]string{}
+	for _, key := range keys {
+		algos[key.Name] = key.Algo
+		require.Equal(t, key.Algo, key.PubKey.Type())
+	}
+	require.Equal(t, map[string]string{"alice": "secp256k1", "bob": "secp256k1", "carol": "sm2", "dave": "ed25519"}, algos)
+
+	// the sm2 key signs with its own algorithm on a secp256k1 client
+	require.NoError(t, chain.Fund(carol, types.NewInt64Coin(types.BaseDenom, 1000000000)))
+	_, err = client.Bank.Send(bob, amount, fakechaintest.BaseTx("carol"))
+	require.NoError(t, err)
+	require.Equal(t, "10000000", chain.Balances(bob).AmountOf(types.BaseDenom).String())
+
+	address, err := client.Key.RecoverWithAlgo("frank", fakechaintest.Password, mnemonic, "", "sm2")
+	require.NoError(t, err)
+	require.Equal(t, carol, address)
+
+	// the ed25519 keys are derived by SLIP-0010 from a hardened path and can't sign the txs
+	key, err := client.Key.ShowPublic("dave")
+	require.NoError(t, err)
+	require.Equal(t, hd.Ed25519FullPath, key.HDPath)
+	_, err = client.Key.RecoverWithAlgo("grace", fakechaintest.Password, daveMnemonic, hd.FullPath, "ed25519")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be hardened")
+	require.NoError(t, chain.Fund(dave, types.NewInt64Coin(types.BaseDenom, 1000000000)))
+	_, err = client.Bank.Send(bob, amount, fakechaintest.BaseTx("dave"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the chain doesn't accept the txs signed by the key algorithm: ed25519")
+
+	// the algorithm of the imported key is read from the armor
+	armor, err := client.Key.Export("dave", fakechaintest.Password)
+	require.NoError(t, err)
+	require.NoError(t, client.Key.Delete("dave", fakechaintest.Password))
+	address, err = client.Key.Import("dave", fakechaintest.Password, armor)
+	require.NoError(t, err)
+	require.Equal(t, dave, address)
+	key, err = client.Key.ShowPublic("dave")
+	require.NoError(t, err)
+	require.Equal(t, "ed25519", key.Algo)
+}
+
+func TestKeystore(t *testing.T) {
+	_, client, alice, _ := fakechaintest.Setup(t)
+
+	keystoreJSON, err := client.Key.ExportKeystore("alice", fakechaintest.Password, keystore.PBKDF2{C: 1024})
+	require.NoError(t, err)
+	require.Contains(t, string(keystoreJSON), alice)
+
+	require.NoError(t, client.Key.Delete("alice", fakechaintest.Password))
+	address, err := client.Key.ImportKeystore("alice", fakechaintest.Password, keystoreJSON)
+	require.NoError(t, err)
+	require.Equal(t, alice, address)
+
+	_, err = client.Key.ImportKeystore("carol", "87654321", keystoreJSON)
+	require.Error(t, err)
+}
+
+func TestKeysHDWallet(t *testing.T) {
+	_, client, _, _ := fakechaintest.Setup(t)
+
+	address, mnemonic, err := client.Key.AddWithOptions("carol", fakechaintest.Password, types.KeyOptions{
+		BIP39Passphrase: "25th word",
+		MnemonicWords:   12,
+	})
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(mnemonic), 12)
+
+	key, err := client.Key.ShowPublic("carol")
+	require.NoError(t, err)
+	require.Equal(t, "44'/118'/0'/0/0", key.HDPath)
+
+	// the passphrase is required to recover the key
+	recovered, err := client.Key.RecoverAccount("dave", fakechaintest.Password, mnemonic, "25th word", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, address, recovered)
+	recovered, err = client.Key.Recover("eve", fakechaintest.Password, mnemonic)
+	require.NoError(t, err)
+	require.NotEqual(t, address, recovered)
+
+	// every account and address index derives another key
+	recovered, err = client.Key.RecoverAccount("frank", fakechaintest.Password, mnemonic, "25th word", 1, 2)
+	require.NoError(t, err)
+	require.NotEqual(t, address, recovered)
+	key, err = client.Key.ShowPublic("frank")
+	require.NoError(t, err)
+	require.Equal(t, "44'/118'/1'/0/2", key.HDPath)
+}
+
+func TestWatchOnly(t *testing.T) {
+	chain, client, _, bob := fakechaintest.Setup(t)
+
+	km, err := crypto.NewAlgoKeyManager("secp256k1")
+	require.NoError(t, err)
+	pubKey := km.ExportPubKey()
+	dave := types.AccAddress(pubKey.Address()).String()
+
+	// by address or by pubkey
+	carol, sdkErr := client.Key.ImportWatchOnly("carol", fakechaintest.Password, bob, nil)
+	require.NoError(t, sdkErr)
+	require.Equal(t, bob, carol)
+	addr, sdkErr := client.Key.ImportWatchOnly("dave", fakechaintest.Password, "", pubKey)
+	require.NoError(t, sdkErr)
+	require.Equal(t, dave, addr)
+	_, sdkErr = client.Key.ImportWatchOnly("erin", fakechaintest.Password, bob, pubKey)
+	require.Error(t, sdkErr)
+	require.NoError(t, chain.Fund(bob, types.NewInt64Coin(types.BaseDenom, 1000000000)))
+	require.NoError(t, chain.Fund(dave, types.NewInt64Coin(types.BaseDenom, 1000000000)))
+
+	key, sdkErr := client.Key.ShowPublic("carol")
+	require.NoError(t, sdkErr)
+	require.True(t, key.WatchOnly)
+	require.Nil(t, key.PubKey)
+	require.Equal(t, bob, key.Address.String())
+	key, sdkErr = client.Key.ShowPublic("dave")
+	require.NoError(t, sdkErr)
+	require.True(t, key.WatchOnly)
+	require.True(t, pubKey.Equals(key.PubKey))
+
+	amount, err := types.ParseDecCoins("1iris")
+	require.NoError(t, err)
+	for _, name := range []string{"carol", "dave"} {
+		_, sdkErr = client.Bank.Send(bob, amount, fakechaintest.BaseTx(name))
+		require.Error(t, sdkErr)
+		require.Contains(t, sdkErr.Error(), types.ErrWatchOnly.Error())
+
+		_, sdkErr = client.Key.Export(name, fakechaintest.Password)
+		require.Error(t, sdkErr)
+	}
+
+	// the unsigned tx of a watch-only key
+	address, sdkErr := client.Key.Show("dave", fakechaintest.Password)
+	require.NoError(t, sdkErr)
+	msg := &bank.MsgSend{
+		FromAddress: address,
+		ToAddress:   bob,
+		Amount:      types.NewCoins(types.NewInt64Coin(types.BaseDenom, 1000000)),
+	}
+	bz, sdkErr := client.BuildUnsignedTx([]types.Msg{msg}, fakechaintest.BaseTx("dave"))
+	require.NoError(t, sdkErr)
+
+	var unsigned struct {
+		Body struct {
+			Messages []map[string]interface{} `json:"messages"`
+		} `json:"body"`
+		AuthInfo struct {
+			SignerInfos []interface{} `json:"signer_infos"`
+		} `json:"auth_info"`
+		Signatures []string `json:"signatures"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &unsigned))
+	require.Len(t, unsigned.Body.Messages, 1)
+	require.Equal(t, dave, unsigned.Body.Messages[0]["from_address"])
+	require.Empty(t, unsigned.AuthInfo.SignerInfos)
+	require.Empty(t, unsigned.Signatures)
+}
+
+func TestKeysFileDAO(t *testing.T) {
+	_, client, alice, _ := fakechaintest.Setup(t, types.KeyDAOOption(store.NewFileDAO(t.TempDir())))
+
+	address, err := client.Key.Show("alice", fakechaintest.Password)
+	require.NoError(t, err)
+	require.Equal(t, alice, address)
+
+	// the keyring-file backend can't read the keys without the password
+	_, err = client.Key.ShowPublic("alice")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+	_, err = client.Key.List()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+	err = client.Key.Backup(&strings.Builder{}, "backup-password", keys.BackupOptions{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+}
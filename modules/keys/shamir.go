@@ -0,0 +1,78 @@
+package keys
+
+import (
+	bip39 "github.com/cosmos/go-bip39"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/crypto/shamir"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+func (k keysClient) SplitMnemonic(mnemonic string, shares, threshold int) ([]string, sdk.Error) {
+	entropy, err := shamir.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return splitSecret(shamir.KindEntropy, entropy, shares, threshold)
+}
+
+func (k keysClient) SplitKey(name, password string, shares, threshold int) ([]string, sdk.Error) {
+	armor, err := k.KeyManager.Export(name, password)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	privKey, _, err := crypto.UnarmorDecryptPrivKey(armor, password)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return splitSecret(shamir.KindPrivKey, cryptoamino.MarshalPrivKey(privKey), shares, threshold)
+}
+
+func (k keysClient) RecoverFromShares(name, password string, shares []string, opts sdk.KeyOptions) (string, sdk.Error) {
+	parsed := make([]shamir.Share, len(shares))
+	for i, share := range shares {
+		s, err := shamir.ParseShare(share)
+		if err != nil {
+			return "", sdk.WrapWithMessage(err, "share %d", i+1)
+		}
+		parsed[i] = s
+	}
+
+	kind, secret, err := shamir.Recover(parsed)
+	if err != nil {
+		return "", sdk.Wrap(err)
+	}
+
+	switch kind {
+	case shamir.KindEntropy:
+		mnemonic, err := bip39.NewMnemonic(secret)
+		if err != nil {
+			return "", sdk.Wrap(err)
+		}
+		address, err := k.KeyManager.RecoverWithOptions(name, password, mnemonic, opts)
+		return address, sdk.Wrap(err)
+	default:
+		privKey, err := cryptoamino.PrivKeyFromBytes(secret)
+		if err != nil {
+			return "", sdk.Wrap(err)
+		}
+		armor := crypto.EncryptArmorPrivKey(privKey, password, privKey.Type())
+		address, err := k.KeyManager.Import(name, password, armor)
+		return address, sdk.Wrap(err)
+	}
+}
+
+func splitSecret(kind shamir.Kind, secret []byte, n, k int) ([]string, sdk.Error) {
+	shares, err := shamir.SplitSecret(kind, secret, n, k)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	words := make([]string, len(shares))
+	for i, share := range shares {
+		words[i] = share.String()
+	}
+	return words, nil
+}
@@ -0,0 +1,54 @@
+package keys_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestShamirShares(t *testing.T) {
+	_, client, alice, _ := fakechaintest.Setup(t)
+
+	mnemonic, err := crypto.NewMnemonic(24)
+	require.NoError(t, err)
+	address, err := client.Key.Recover("carol", fakechaintest.Password, mnemonic)
+	require.NoError(t, err)
+
+	shares, err := client.Key.SplitMnemonic(mnemonic, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	recovered, err := client.Key.RecoverFromShares("dave", fakechaintest.Password, []string{shares[4], shares[1], shares[2]}, types.KeyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, address, recovered)
+
+	_, err = client.Key.RecoverFromShares("eve", fakechaintest.Password, shares[:2], types.KeyOptions{})
+	require.Error(t, err)
+
+	// the shares of another split of the same mnemonic don't mix
+	others, err := client.Key.SplitMnemonic(mnemonic, 5, 3)
+	require.NoError(t, err)
+	_, err = client.Key.RecoverFromShares("eve", fakechaintest.Password, []string{shares[0], shares[1], others[2]}, types.KeyOptions{})
+	require.Error(t, err)
+
+	words := strings.Fields(shares[0])
+	if words[3] == "abandon" {
+		words[3] = "zoo"
+	} else {
+		words[3] = "abandon"
+	}
+	_, err = client.Key.RecoverFromShares("eve", fakechaintest.Password, []string{strings.Join(words, " "), shares[1], shares[2]}, types.KeyOptions{})
+	require.Error(t, err)
+
+	// the stored keys without mnemonic
+	shares, err = client.Key.SplitKey("alice", fakechaintest.Password, 3, 2)
+	require.NoError(t, err)
+	recovered, err = client.Key.RecoverFromShares("frank", fakechaintest.Password, shares[1:], types.KeyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, alice, recovered)
+}
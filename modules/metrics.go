@@ -0,0 +1,132 @@
+package modules
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/metrics"
+)
+
+// failedCode is the code recorded when the request didn't get a response
+const failedCode = -1
+
+// metricsTmClient records the abci queries, the broadcasts and the subscriptions of the wrapped client
+type metricsTmClient struct {
+	sdk.TmClient
+	m *metrics.Metrics
+}
+
+func newMetricsTmClient(client sdk.TmClient, m *metrics.Metrics) sdk.TmClient {
+	return metricsTmClient{TmClient: client, m: m}
+}
+
+func (c metricsTmClient) ABCIQuery(ctx context.Context, path string, data tmbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	start := time.Now()
+	res, err := c.TmClient.ABCIQuery(ctx, path, data)
+	c.observeABCIQuery(path, res, err, start)
+	return res, err
+}
+
+func (c metricsTmClient) ABCIQueryWithOptions(ctx context.Context, path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	start := time.Now()
+	res, err := c.TmClient.ABCIQueryWithOptions(ctx, path, data, opts)
+	c.observeABCIQuery(path, res, err, start)
+	return res, err
+}
+
+func (c metricsTmClient) BroadcastTxCommit(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	start := time.Now()
+	res, err := c.TmClient.BroadcastTxCommit(ctx, tx)
+	switch {
+	case err != nil:
+		c.m.ObserveBroadcast(string(sdk.Commit), "", failedCode, start)
+	case !res.CheckTx.IsOK():
+		c.m.ObserveBroadcast(string(sdk.Commit), res.CheckTx.Codespace, int64(res.CheckTx.Code), start)
+	default:
+		c.m.ObserveBroadcast(string(sdk.Commit), res.DeliverTx.Codespace, int64(res.DeliverTx.Code), start)
+		c.m.ObserveGas(res.DeliverTx.GasWanted, res.DeliverTx.GasUsed)
+	}
+	return res, err
+}
+
+func (c metricsTmClient) BroadcastTxSync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	start := time.Now()
+	res, err := c.TmClient.BroadcastTxSync(ctx, tx)
+	c.observeBroadcast(sdk.Sync, res, err, start)
+	return res, err
+}
+
+func (c metricsTmClient) BroadcastTxAsync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	start := time.Now()
+	res, err := c.TmClient.BroadcastTxAsync(ctx, tx)
+	c.observeBroadcast(sdk.Async, res, err, start)
+	return res, err
+}
+
+func (c metricsTmClient) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	subscription, err := c.TmClient.SubscribeNewBlock(builder, handler)
+	c.observeSubscribe(err)
+	return subscription, err
+}
+
+func (c metricsTmClient) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	subscription, err := c.TmClient.SubscribeTx(builder, handler)
+	c.observeSubscribe(err)
+	return subscription, err
+}
+
+func (c metricsTmClient) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	subscription, err := c.TmClient.SubscribeNewBlockHeader(handler)
+	c.observeSubscribe(err)
+	return subscription, err
+}
+
+func (c metricsTmClient) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	subscription, err := c.TmClient.SubscribeValidatorSetUpdates(handler)
+	c.observeSubscribe(err)
+	return subscription, err
+}
+
+func (c metricsTmClient) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	err := c.TmClient.Unsubscribe(subscription)
+	if err == nil {
+		c.m.SubscriptionRemoved()
+	}
+	return err
+}
+
+func (c metricsTmClient) observeABCIQuery(path string, res *ctypes.ResultABCIQuery, err error, start time.Time) {
+	code := int64(failedCode)
+	if err == nil {
+		code = int64(res.Response.Code)
+	}
+	c.m.ObserveABCIQuery(path, code, start)
+}
+
+func (c metricsTmClient) observeBroadcast(mode sdk.BroadcastMode, res *ctypes.ResultBroadcastTx, err error, start time.Time) {
+	if err != nil {
+		c.m.ObserveBroadcast(string(mode), "", failedCode, start)
+		return
+	}
+	c.m.ObserveBroadcast(string(mode), res.Codespace, int64(res.Code), start)
+}
+
+func (c metricsTmClient) observeSubscribe(err sdk.Error) {
+	if err == nil {
+		c.m.SubscriptionAdded()
+	}
+}
+
+// isSequenceMismatch reports whether the broadcast failed because the account sequence was stale,
+// the chain code of the error is not mapped by sdk.GetError so the log is checked too
+func isSequenceMismatch(err sdk.Error) bool {
+	return err.Code() == uint32(sdk.InvalidSequence) ||
+		strings.Contains(err.Error(), "account sequence mismatch")
+}
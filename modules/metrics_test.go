@@ -0,0 +1,43 @@
+package modules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	m := metrics.NewMetrics("test")
+	_, client, _, bob := fakechaintest.Setup(t, types.MetricsOption(m))
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	_, err = client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+
+	expected := `
+# HELP test_client_broadcasts_total Number of broadcast transactions by mode, codespace and result code.
+# TYPE test_client_broadcasts_total counter
+test_client_broadcasts_total{code="0",codespace="",mode="commit"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(m, strings.NewReader(expected), "test_client_broadcasts_total"))
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(m))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	require.True(t, names["test_client_tx_gas_used"])
+	require.True(t, names["test_client_cache_accesses_total"])
+}
@@ -18,6 +18,7 @@ type Client interface {
 	QueryDenom(denomID string) (QueryDenomResp, sdk.Error)
 	QueryDenoms() ([]QueryDenomResp, sdk.Error)
 	QueryNFT(denomID, tokenID string) (QueryNFTResp, sdk.Error)
+	IterateDenoms() *sdk.Iterator
 }
 
 type IssueDenomRequest struct {
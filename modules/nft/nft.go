@@ -7,6 +7,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/codec/types"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
 )
 
 type nftClient struct {
@@ -267,3 +268,20 @@ func (nc nftClient) QueryNFT(denom, tokenID string) (QueryNFTResp, sdk.Error) {
 
 	return res.NFT.Convert().(QueryNFTResp), nil
 }
+
+// IterateDenoms returns an iterator over all the denoms, the items are QueryDenomResp.
+// The denoms query isn't paginated, so all denoms are fetched at once
+func (nc nftClient) IterateDenoms() *sdk.Iterator {
+	return sdk.NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		denoms, err := nc.QueryDenoms()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(denoms))
+		for i, denom := range denoms {
+			items[i] = denom
+		}
+		return items, nil, nil
+	})
+}
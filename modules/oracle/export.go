@@ -18,6 +18,8 @@ type Client interface {
 	QueryFeed(feedName string) (QueryFeedResp, sdk.Error)
 	QueryFeeds(state string) ([]QueryFeedResp, sdk.Error)
 	QueryFeedValue(feedName string) ([]QueryFeedValueResp, sdk.Error)
+	IterateFeeds(state string) *sdk.Iterator
+	IterateFeedValues(feedName string) *sdk.Iterator
 }
 
 type CreateFeedRequest struct {
@@ -6,6 +6,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/codec"
 	"github.com/irisnet/irishub-sdk-go/codec/types"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
 )
 
 type oracleClient struct {
@@ -171,3 +172,37 @@ func (oc oracleClient) QueryFeedValue(feedName string) ([]QueryFeedValueResp, sd
 	}
 	return feedValues(res.FeedValues).Convert().([]QueryFeedValueResp), nil
 }
+
+// IterateFeeds returns an iterator over the feeds with the specified state, the items are QueryFeedResp.
+// The feeds query isn't paginated, so all feeds are fetched at once
+func (oc oracleClient) IterateFeeds(state string) *sdk.Iterator {
+	return sdk.NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		feeds, err := oc.QueryFeeds(state)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(feeds))
+		for i, feed := range feeds {
+			items[i] = feed
+		}
+		return items, nil, nil
+	})
+}
+
+// IterateFeedValues returns an iterator over the values of the feed, the items are QueryFeedValueResp.
+// The feed value query isn't paginated, so all values are fetched at once
+func (oc oracleClient) IterateFeedValues(feedName string) *sdk.Iterator {
+	return sdk.NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		values, err := oc.QueryFeedValue(feedName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			items[i] = value
+		}
+		return items, nil, nil
+	})
+}
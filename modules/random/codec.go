@@ -15,6 +15,7 @@ var (
 func init() {
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
+	sdk.RegisterEventAttributes(eventTypeRequestRequestRandom, attributeKeyRequestID, attributeKeyGenerateHeight)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -10,6 +10,7 @@ type Client interface {
 
 	QueryRandom(ReqId string) (QueryRandomResp, sdk.Error)
 	QueryRandomRequestQueue(height int64) ([]QueryRandomRequestQueueResp, sdk.Error)
+	IterateRandomRequestQueue(height int64) *sdk.Iterator
 }
 
 type RequestRandomRequest struct {
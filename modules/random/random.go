@@ -7,6 +7,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/codec"
 	cdctypes "github.com/irisnet/irishub-sdk-go/codec/types"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
 )
 
 type randomClient struct {
@@ -106,3 +107,20 @@ func (rc randomClient) QueryRandomRequestQueue(height int64) ([]QueryRandomReque
 	}
 	return Requests(res.Requests).Convert().([]QueryRandomRequestQueueResp), nil
 }
+
+// IterateRandomRequestQueue returns an iterator over the random requests queued for the height,
+// the items are QueryRandomRequestQueueResp. The queue query isn't paginated, so all requests are fetched at once
+func (rc randomClient) IterateRandomRequestQueue(height int64) *sdk.Iterator {
+	return sdk.NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		requests, err := rc.QueryRandomRequestQueue(height)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(requests))
+		for i, request := range requests {
+			items[i] = request
+		}
+		return items, nil, nil
+	})
+}
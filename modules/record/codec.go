@@ -15,6 +15,7 @@ var (
 func init() {
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
+	sdk.RegisterEventAttributes(eventTypeCreateRecord, attributeKeyRecordID)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -51,6 +51,9 @@ func (r rpcClient) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk
 	}
 
 	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(tmtypes.EventNewBlock))
+	if err := builder.Validate(); err != nil {
+		return sdk.Subscription{}, sdk.Wrap(err)
+	}
 	query := builder.Build()
 
 	return r.SubscribeAny(query, func(data sdk.EventData) {
@@ -63,7 +66,11 @@ func (r rpcClient) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.Event
 	if builder == nil {
 		builder = sdk.NewEventQueryBuilder()
 	}
-	query := builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue)).Build()
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue))
+	if err := builder.Validate(); err != nil {
+		return sdk.Subscription{}, sdk.Wrap(err)
+	}
+	query := builder.Build()
 	return r.SubscribeAny(query, func(data sdk.EventData) {
 		handler(data.(sdk.EventDataTx))
 	})
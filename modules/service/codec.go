@@ -15,6 +15,10 @@ var (
 func init() {
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
+	sdk.RegisterEventAttributes(eventTypeNewBatchRequest, attributeKeyRequests, attributeKeyRequestContextID)
+	sdk.RegisterEventAttributes(eventTypeNewBatchRequestProvider, attributeKeyRequests, attributeKeyServiceName, attributeKeyProvider)
+	sdk.RegisterEventAttributes(sdk.EventTypeCreateContext, attributeKeyRequestContextID)
+	sdk.RegisterEventAttributes(sdk.EventTypeResponseService, attributeKeyRequestContextID, attributeKeyRequestID)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
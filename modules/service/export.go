@@ -29,6 +29,8 @@ type Tx interface {
 
 // Query defines a set of query interfaces in the service module
 type Query interface {
+	// the list queries keep their paging arguments and return a single page, the Iterate methods
+	// walk through all the pages following NextKey
 	QueryServiceDefinition(serviceName string) (QueryServiceDefinitionResponse, sdk.Error)
 	QueryServiceBinding(serviceName string, provider string) (QueryServiceBindingResponse, sdk.Error)
 	QueryServiceBindings(serviceName string, pageReq *query.PageRequest) ([]QueryServiceBindingResponse, sdk.Error)
@@ -40,6 +42,11 @@ type Query interface {
 	QueryRequestContext(requestContextID string) (QueryRequestContextResp, sdk.Error)
 	QueryFees(provider string) (sdk.Coins, sdk.Error)
 	QueryParams() (QueryParamsResp, sdk.Error)
+
+	IterateServiceBindings(serviceName string) *sdk.Iterator
+	IterateServiceRequests(serviceName string, provider string) *sdk.Iterator
+	IterateRequestsByReqCtx(requestContextID string, batchCounter uint64) *sdk.Iterator
+	IterateServiceResponses(requestContextID string, batchCounter uint64) *sdk.Iterator
 }
 
 // Client defines a set of interfaces in the service module
@@ -746,3 +746,126 @@ func (s serviceClient) GenServiceResponseMsgs(events sdk.StringEvents, serviceNa
 	}
 	return msgs
 }
+
+// IterateServiceBindings returns an iterator over all bindings of the specified service,
+// the items are QueryServiceBindingResponse
+func (s serviceClient) IterateServiceBindings(serviceName string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := s.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).Bindings(
+			context.Background(),
+			&QueryBindingsRequest{
+				ServiceName: serviceName,
+				Pagination:  pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.ServiceBindings))
+		for i, binding := range resp.ServiceBindings {
+			items[i] = binding.Convert().(QueryServiceBindingResponse)
+		}
+		return items, resp.Pagination, nil
+	})
+}
+
+// IterateServiceRequests returns an iterator over all the active requests of the specified service binding,
+// the items are QueryServiceRequestResponse
+func (s serviceClient) IterateServiceRequests(serviceName string, provider string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		if err := sdk.ValidateAccAddress(provider); err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		conn, err := s.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).Requests(
+			context.Background(),
+			&QueryRequestsRequest{
+				ServiceName: serviceName,
+				Provider:    provider,
+				Pagination:  pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.Requests))
+		for i, request := range resp.Requests {
+			items[i] = request.Convert().(QueryServiceRequestResponse)
+		}
+		return items, resp.Pagination, nil
+	})
+}
+
+// IterateRequestsByReqCtx returns an iterator over all requests of the specified request context ID and batch counter,
+// the items are QueryServiceRequestResponse
+func (s serviceClient) IterateRequestsByReqCtx(reqCtxID string, batchCounter uint64) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := s.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).RequestsByReqCtx(
+			context.Background(),
+			&QueryRequestsByReqCtxRequest{
+				RequestContextId: reqCtxID,
+				BatchCounter:     batchCounter,
+				Pagination:       pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.Requests))
+		for i, request := range resp.Requests {
+			items[i] = request.Convert().(QueryServiceRequestResponse)
+		}
+		return items, resp.Pagination, nil
+	})
+}
+
+// IterateServiceResponses returns an iterator over all responses of the specified request context and batch counter,
+// the items are QueryServiceResponseResponse
+func (s serviceClient) IterateServiceResponses(reqCtxID string, batchCounter uint64) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := s.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		resp, err := NewQueryClient(conn).Responses(
+			context.Background(),
+			&QueryResponsesRequest{
+				RequestContextId: reqCtxID,
+				BatchCounter:     batchCounter,
+				Pagination:       pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(resp.Responses))
+		for i, response := range resp.Responses {
+			items[i] = response.Convert().(QueryServiceResponseResponse)
+		}
+		return items, resp.Pagination, nil
+	})
+}
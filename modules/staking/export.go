@@ -16,6 +16,8 @@ type Client interface {
 	Undelegate(request UndelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	BeginRedelegate(request BeginRedelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 
+	// the list queries keep their paging arguments and return a single page, the Iterate methods
+	// walk through all the pages following NextKey
 	QueryValidators(status string, page, size uint64) (QueryValidatorsResp, sdk.Error)
 	QueryValidator(validatorAddr string) (QueryValidatorResp, sdk.Error)
 	QueryValidatorDelegations(validatorAddr string, page, size uint64) (QueryValidatorDelegationsResp, sdk.Error)
@@ -30,6 +32,15 @@ type Client interface {
 	QueryHistoricalInfo(height int64) (QueryHistoricalInfoResp, sdk.Error)
 	QueryPool() (QueryPoolResp, sdk.Error)
 	QueryParams() (QueryParamsResp, sdk.Error)
+
+	IterateValidators(status string) *sdk.Iterator
+	IterateValidatorDelegations(validatorAddr string) *sdk.Iterator
+	IterateValidatorUnbondingDelegations(validatorAddr string) *sdk.Iterator
+	IterateDelegatorDelegations(delegatorAddr string) *sdk.Iterator
+	IterateDelegatorUnbondingDelegations(delegatorAddr string) *sdk.Iterator
+	IterateRedelegations(request QueryRedelegationsReq) *sdk.Iterator
+	IterateDelegatorValidators(delegatorAddr string) *sdk.Iterator
+	IterateAllDelegations(status string) *sdk.Iterator
 }
 
 type CreateValidatorRequest struct {
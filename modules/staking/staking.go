@@ -468,3 +468,216 @@ func (sc stakingClient) QueryParams() (QueryParamsResp, sdk.Error) {
 	}
 	return res.Convert().(QueryParamsResp), nil
 }
+
+// IterateValidators returns an iterator over the validators with the specified status,
+// the items are QueryValidatorResp
+func (sc stakingClient) IterateValidators(status string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).Validators(
+			context.Background(),
+			&QueryValidatorsRequest{
+				Status:     status,
+				Pagination: pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.Validators))
+		for i, v := range res.Validators {
+			items[i] = v.Convert(sc.Marshaler).(QueryValidatorResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateValidatorDelegations returns an iterator over the delegations of the validator,
+// the items are QueryDelegationResp
+func (sc stakingClient) IterateValidatorDelegations(validatorAddr string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).ValidatorDelegations(
+			context.Background(),
+			&QueryValidatorDelegationsRequest{
+				ValidatorAddr: validatorAddr,
+				Pagination:    pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.DelegationResponses))
+		for i, d := range res.DelegationResponses {
+			items[i] = d.Convert().(QueryDelegationResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateValidatorUnbondingDelegations returns an iterator over the unbonding delegations of the validator,
+// the items are QueryUnbondingDelegationResp
+func (sc stakingClient) IterateValidatorUnbondingDelegations(validatorAddr string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).ValidatorUnbondingDelegations(
+			context.Background(),
+			&QueryValidatorUnbondingDelegationsRequest{
+				ValidatorAddr: validatorAddr,
+				Pagination:    pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.UnbondingResponses))
+		for i, u := range res.UnbondingResponses {
+			items[i] = u.Convert().(QueryUnbondingDelegationResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateDelegatorDelegations returns an iterator over the delegations of the delegator,
+// the items are QueryDelegationResp
+func (sc stakingClient) IterateDelegatorDelegations(delegatorAddr string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).DelegatorDelegations(
+			context.Background(),
+			&QueryDelegatorDelegationsRequest{
+				DelegatorAddr: delegatorAddr,
+				Pagination:    pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.DelegationResponses))
+		for i, d := range res.DelegationResponses {
+			items[i] = d.Convert().(QueryDelegationResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateDelegatorUnbondingDelegations returns an iterator over the unbonding delegations of the delegator,
+// the items are QueryUnbondingDelegationResp
+func (sc stakingClient) IterateDelegatorUnbondingDelegations(delegatorAddr string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).DelegatorUnbondingDelegations(
+			context.Background(),
+			&QueryDelegatorUnbondingDelegationsRequest{
+				DelegatorAddr: delegatorAddr,
+				Pagination:    pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.UnbondingResponses))
+		for i, u := range res.UnbondingResponses {
+			items[i] = u.Convert().(QueryUnbondingDelegationResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateRedelegations returns an iterator over the redelegations matching the request,
+// the items are RedelegationResp. Page and Size of the request are ignored
+func (sc stakingClient) IterateRedelegations(request QueryRedelegationsReq) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).Redelegations(
+			context.Background(),
+			&QueryRedelegationsRequest{
+				DelegatorAddr:    request.DelegatorAddr,
+				SrcValidatorAddr: request.SrcValidatorAddr,
+				DstValidatorAddr: request.DstValidatorAddr,
+				Pagination:       pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.RedelegationResponses))
+		for i, r := range res.RedelegationResponses {
+			items[i] = r.Convert().(RedelegationResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateDelegatorValidators returns an iterator over the validators the delegator bonded to,
+// the items are QueryValidatorResp
+func (sc stakingClient) IterateDelegatorValidators(delegatorAddr string) *sdk.Iterator {
+	return sdk.NewIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		defer func() { _ = conn.Close() }()
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		res, err := NewQueryClient(conn).DelegatorValidators(
+			context.Background(),
+			&QueryDelegatorValidatorsRequest{
+				DelegatorAddr: delegatorAddr,
+				Pagination:    pageReq,
+			},
+		)
+		if err != nil {
+			return nil, nil, sdk.Wrap(err)
+		}
+
+		items := make([]interface{}, len(res.Validators))
+		for i, v := range res.Validators {
+			items[i] = v.Convert(sc.Marshaler).(QueryValidatorResp)
+		}
+		return items, res.Pagination, nil
+	})
+}
+
+// IterateAllDelegations returns an iterator over the delegations of all the validators with the specified status,
+// the items are QueryDelegationResp
+func (sc stakingClient) IterateAllDelegations(status string) *sdk.Iterator {
+	return sdk.NewNestedIterator(sc.IterateValidators(status), func(item interface{}) *sdk.Iterator {
+		return sc.IterateValidatorDelegations(item.(QueryValidatorResp).OperatorAddress)
+	})
+}
@@ -6,11 +6,16 @@ import (
 	"strings"
 
 	"github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/irisnet/irishub-sdk-go/codec"
 	"github.com/irisnet/irishub-sdk-go/modules/token"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/cache"
+	"github.com/irisnet/irishub-sdk-go/utils/metrics"
+	"github.com/irisnet/irishub-sdk-go/utils/tracing"
 )
 
 type tokenQuery struct {
@@ -19,29 +24,45 @@ type tokenQuery struct {
 	cdc codec.Marshaler
 	log.Logger
 	cache.Cache
+	registry sdk.TokenRegistry
+	metrics  *metrics.Metrics
+	tracer   tracing.Tracer
 }
 
 func (l tokenQuery) QueryToken(denom string) (sdk.Token, error) {
+	return l.queryToken(context.Background(), denom)
+}
+
+// queryToken returns the token from the registry, the cache or the chain, in that order
+func (l tokenQuery) queryToken(ctx context.Context, denom string) (sdk.Token, error) {
 	denom = strings.ToLower(denom)
-	if t, err := l.Get(l.prefixKey(denom)); err == nil {
+	if l.registry != nil {
+		if t, ok := l.registry.Token(denom); ok {
+			return t, nil
+		}
+	}
+
+	t, err := l.Get(l.prefixKey(denom))
+	l.metrics.CacheAccess(metrics.CacheToken, err == nil)
+	if err == nil {
 		return t.(sdk.Token), nil
 	}
 
 	conn, err := l.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return sdk.Token{}, sdk.Wrap(err)
 	}
+	defer func() { _ = conn.Close() }()
 
 	response, err := token.NewQueryClient(conn).Token(
-		context.Background(),
+		ctx,
 		&token.QueryTokenRequest{Denom: denom},
 	)
+	if status.Code(err) == codes.NotFound {
+		return sdk.Token{}, sdk.Wrapf("token %s not found", denom)
+	}
 	if err != nil {
-		l.Debug("client query token failed",
-			" denom ", denom,
-			" err ", err.Error())
-		return sdk.Token{}, nil
+		return sdk.Token{}, sdk.WrapWithMessage(err, "failed to query the token %s", denom)
 	}
 
 	var srcToken token.TokenInterface
@@ -61,11 +82,23 @@ func (l tokenQuery) SaveTokens(tokens ...sdk.Token) {
 			l.Debug("cache token failed", "symbol", t.Symbol)
 		}
 	}
+	if l.registry != nil && len(tokens) > 0 {
+		if err := l.registry.SaveTokens(tokens...); err != nil {
+			l.Error("save tokens to the registry failed", "err", err.Error())
+		}
+	}
 }
 
 func (l tokenQuery) ToMinCoin(coins ...sdk.DecCoin) (dstCoins sdk.Coins, err sdk.Error) {
+	return l.toMinCoin(context.Background(), coins...)
+}
+
+func (l tokenQuery) toMinCoin(ctx context.Context, coins ...sdk.DecCoin) (dstCoins sdk.Coins, err sdk.Error) {
+	ctx, span := l.tracer.Start(ctx, "ToMinCoin", attribute.String(tracing.AttributeKeyDenoms, sdk.DecCoins(coins).String()))
+	defer func() { tracing.End(span, err) }()
+
 	for _, coin := range coins {
-		token, err := l.QueryToken(coin.Denom)
+		token, err := l.queryToken(ctx, coin.Denom)
 		if err != nil {
 			return nil, sdk.Wrap(err)
 		}
@@ -80,8 +113,15 @@ func (l tokenQuery) ToMinCoin(coins ...sdk.DecCoin) (dstCoins sdk.Coins, err sdk
 }
 
 func (l tokenQuery) ToMainCoin(coins ...sdk.Coin) (dstCoins sdk.DecCoins, err sdk.Error) {
+	return l.toMainCoin(context.Background(), coins...)
+}
+
+func (l tokenQuery) toMainCoin(ctx context.Context, coins ...sdk.Coin) (dstCoins sdk.DecCoins, err sdk.Error) {
+	ctx, span := l.tracer.Start(ctx, "ToMainCoin", attribute.String(tracing.AttributeKeyDenoms, sdk.Coins(coins).String()))
+	defer func() { tracing.End(span, err) }()
+
 	for _, coin := range coins {
-		token, err := l.QueryToken(coin.Denom)
+		token, err := l.queryToken(ctx, coin.Denom)
 		if err != nil {
 			return dstCoins, sdk.Wrap(err)
 		}
@@ -15,6 +15,10 @@ var (
 func init() {
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
+	sdk.RegisterEventAttributes(EventTypeIssueToken, AttributeKeySymbol, AttributeKeyCreator)
+	sdk.RegisterEventAttributes(EventTypeEditToken, AttributeKeySymbol, AttributeKeyOwner)
+	sdk.RegisterEventAttributes(EventTypeMintToken, AttributeKeySymbol, AttributeKeyAmount, AttributeKeyRecipient)
+	sdk.RegisterEventAttributes(EventTypeTransferTokenOwner, AttributeKeySymbol, AttributeKeyOwner, AttributeKeyDstOwner)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -16,6 +16,12 @@ type Client interface {
 	QueryTokens(owner string) (sdk.Tokens, error)
 	QueryFees(symbol string) (QueryFeesResp, error)
 	QueryParams() (QueryParamsResp, error)
+	IterateTokens(owner string) *sdk.Iterator
+
+	// PreloadTokens queries all the tokens and saves them, to the token registry of the client when it has one
+	PreloadTokens() (sdk.Tokens, sdk.Error)
+	// SubscribeTokenUpdates saves the tokens issued, edited or transferred, then calls the callback with them
+	SubscribeTokenUpdates(callback func(sdk.Token)) (sdk.Subscription, sdk.Error)
 }
 
 type IssueTokenRequest struct {
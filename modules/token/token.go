@@ -10,6 +10,7 @@ import (
 	"github.com/irisnet/irishub-sdk-go/codec"
 	"github.com/irisnet/irishub-sdk-go/codec/types"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
 )
 
 type tokenClient struct {
@@ -190,3 +191,83 @@ func (t tokenClient) QueryParams() (QueryParamsResp, error) {
 
 	return res.Params.Convert().(QueryParamsResp), nil
 }
+
+// IterateTokens returns an iterator over the tokens of the owner, the items are sdk.Token.
+// The tokens query isn't paginated, so all tokens are fetched at once
+func (t tokenClient) IterateTokens(owner string) *sdk.Iterator {
+	return sdk.NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		tokens, err := t.QueryTokens(owner)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(tokens))
+		for i, token := range tokens {
+			items[i] = token
+		}
+		return items, nil, nil
+	})
+}
+
+func (t tokenClient) PreloadTokens() (sdk.Tokens, sdk.Error) {
+	tokens, err := t.QueryTokens("")
+	if err != nil {
+		return nil, sdk.WrapWithMessage(err, "failed to preload the tokens")
+	}
+	return tokens, nil
+}
+
+func (t tokenClient) SubscribeTokenUpdates(callback func(sdk.Token)) (sdk.Subscription, sdk.Error) {
+	builder := sdk.NewEventQueryBuilder().
+		AddCondition(sdk.NewCond(sdk.EventTypeMessage, sdk.AttributeKeyModule).EQ(sdk.EventValue(ModuleName)))
+
+	return t.SubscribeTx(builder, func(data sdk.EventDataTx) {
+		if data.Result.Code != 0 {
+			return
+		}
+
+		var (
+			seen    = make(map[string]bool)
+			symbols []string
+		)
+		for _, typ := range []string{EventTypeIssueToken, EventTypeEditToken, EventTypeTransferTokenOwner} {
+			for _, symbol := range data.Result.Events.GetValues(typ, AttributeKeySymbol) {
+				if !seen[symbol] {
+					seen[symbol] = true
+					symbols = append(symbols, symbol)
+				}
+			}
+		}
+
+		for _, symbol := range symbols {
+			// the registry and the cache hold the token before the update
+			token, err := t.queryChainToken(symbol)
+			if err != nil {
+				t.Logger().Error("refresh token failed", "symbol", symbol, "height", data.Height, "err", err.Error())
+				continue
+			}
+			t.SaveTokens(token)
+			callback(token)
+		}
+	})
+}
+
+// queryChainToken queries the token from the chain, without the token registry and the cache
+func (t tokenClient) queryChainToken(symbol string) (sdk.Token, error) {
+	conn, err := t.GenConn()
+	if err != nil {
+		return sdk.Token{}, sdk.Wrap(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	res, err := NewQueryClient(conn).Token(context.Background(), &QueryTokenRequest{Denom: symbol})
+	if err != nil {
+		return sdk.Token{}, sdk.Wrap(err)
+	}
+
+	var src TokenInterface
+	if err = t.UnpackAny(res.Token, &src); err != nil {
+		return sdk.Token{}, sdk.Wrap(err)
+	}
+	return src.(*Token).Convert().(sdk.Token), nil
+}
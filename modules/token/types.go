@@ -10,6 +10,18 @@ import (
 
 const (
 	ModuleName = "token"
+
+	EventTypeIssueToken         = "issue_token"
+	EventTypeEditToken          = "edit_token"
+	EventTypeMintToken          = "mint_token"
+	EventTypeTransferTokenOwner = "transfer_token_owner"
+
+	AttributeKeyCreator   = "creator"
+	AttributeKeySymbol    = "symbol"
+	AttributeKeyAmount    = "amount"
+	AttributeKeyOwner     = "owner"
+	AttributeKeyDstOwner  = "dst_owner"
+	AttributeKeyRecipient = "recipient"
 )
 
 var (
@@ -256,8 +268,11 @@ func (t Token) GetMintable() bool {
 	return t.Mintable
 }
 
-// GetOwner implements exported.TokenI
+// GetOwner implements exported.TokenI, the owner is empty when the token has none, e.g. the native token
 func (t Token) GetOwner() sdk.AccAddress {
+	if len(t.Owner) == 0 {
+		return sdk.AccAddress{}
+	}
 	return sdk.MustAccAddressFromBech32(t.Owner)
 }
 
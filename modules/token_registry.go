@@ -0,0 +1,116 @@
+package modules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/fileutil"
+)
+
+var _ sdk.TokenRegistry = &TokenRegistry{}
+
+// TokenRegistry is a TokenRegistry saving the tokens to a JSON file, which is replaced atomically, to be set with
+// types.TokenRegistryOption so that the coins of the registered tokens are converted without the chain.
+// It is filled by token.Client.PreloadTokens and refreshed by token.Client.SubscribeTokenUpdates.
+// The tokens are only kept in memory when the filename is empty.
+type TokenRegistry struct {
+	filename string
+
+	mu     sync.RWMutex
+	tokens map[string]sdk.Token // by symbol
+	units  map[string]string    // symbol by min unit
+}
+
+// NewTokenRegistry returns the registry of the tokens saved to the file, the file is created by the first save
+func NewTokenRegistry(filename string) (*TokenRegistry, error) {
+	r := &TokenRegistry{
+		filename: filename,
+		tokens:   make(map[string]sdk.Token),
+		units:    make(map[string]string),
+	}
+	if filename == "" {
+		return r, nil
+	}
+
+	bz, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens sdk.Tokens
+	if err := json.Unmarshal(bz, &tokens); err != nil {
+		return nil, sdk.WrapWithMessage(err, "invalid token registry %s", filename)
+	}
+	for _, t := range tokens {
+		r.add(t)
+	}
+	return r, nil
+}
+
+func (r *TokenRegistry) Token(denom string) (sdk.Token, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	denom = strings.ToLower(denom)
+	if t, ok := r.tokens[denom]; ok {
+		return t, true
+	}
+	if symbol, ok := r.units[denom]; ok {
+		return r.tokens[symbol], true
+	}
+	return sdk.Token{}, false
+}
+
+// Tokens returns the registered tokens, sorted by symbol
+func (r *TokenRegistry) Tokens() sdk.Tokens {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sorted()
+}
+
+func (r *TokenRegistry) SaveTokens(tokens ...sdk.Token) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range tokens {
+		r.add(t)
+	}
+	if r.filename == "" {
+		return nil
+	}
+
+	bz, err := json.MarshalIndent(r.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(r.filename, bz, 0600)
+}
+
+// add registers the token under its symbol and its min unit, replacing the token of the same symbol
+func (r *TokenRegistry) add(t sdk.Token) {
+	symbol := strings.ToLower(t.Symbol)
+	if old, ok := r.tokens[symbol]; ok {
+		delete(r.units, strings.ToLower(old.MinUnit))
+	}
+	r.tokens[symbol] = t
+	r.units[strings.ToLower(t.MinUnit)] = symbol
+}
+
+func (r *TokenRegistry) sorted() sdk.Tokens {
+	tokens := make(sdk.Tokens, 0, len(r.tokens))
+	for _, t := range r.tokens {
+		tokens = append(tokens, t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Symbol < tokens[j].Symbol
+	})
+	return tokens
+}
@@ -0,0 +1,103 @@
+package modules_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/modules"
+	"github.com/irisnet/irishub-sdk-go/modules/token"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// offlineGRPC is a grpc client whose node is unreachable
+type offlineGRPC struct{}
+
+func (offlineGRPC) GenConn() (*grpc.ClientConn, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTokenRegistry(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tokens.json")
+	registry, err := modules.NewTokenRegistry(filename)
+	require.NoError(t, err)
+	chain, client, alice, _ := fakechaintest.Setup(t, types.TokenRegistryOption(registry))
+
+	require.NoError(t, chain.AddToken(token.Token{
+		Symbol:        "btc",
+		Name:          "Bitcoin",
+		Scale:         8,
+		MinUnit:       "satoshi",
+		InitialSupply: 21000,
+		MaxSupply:     21000000,
+		Mintable:      true,
+		Owner:         alice,
+	}))
+	tokens, sdkErr := client.Token.PreloadTokens()
+	require.NoError(t, sdkErr)
+	require.Len(t, tokens, 2)
+	require.Len(t, registry.Tokens(), 2)
+
+	var updated []types.Token
+	_, sdkErr = client.Token.SubscribeTokenUpdates(func(t types.Token) {
+		updated = append(updated, t)
+	})
+	require.NoError(t, sdkErr)
+
+	_, err = client.Token.EditToken(token.EditTokenRequest{
+		Symbol:    "btc",
+		Name:      "Bitcoin Core",
+		MaxSupply: 21000000,
+		Mintable:  true,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+	_, err = client.Token.IssueToken(token.IssueTokenRequest{
+		Symbol:        "eth",
+		Name:          "Ethereum",
+		Scale:         18,
+		MinUnit:       "wei",
+		InitialSupply: 1000,
+		MaxSupply:     1000000,
+		Mintable:      true,
+	}, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+
+	require.Len(t, updated, 2)
+	require.Equal(t, "Bitcoin Core", updated[0].Name)
+	require.Equal(t, "eth", updated[1].Symbol)
+	btc, ok := registry.Token("satoshi")
+	require.True(t, ok)
+	require.Equal(t, "Bitcoin Core", btc.Name)
+
+	// the unknown denoms fail instead of being converted with an empty token
+	_, sdkErr = client.ToMinCoin(types.NewDecCoin("doge", types.NewInt(1)))
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "token doge not found")
+
+	// the tokens saved to the file are converted without the chain
+	registry, err = modules.NewTokenRegistry(filename)
+	require.NoError(t, err)
+	require.Len(t, registry.Tokens(), 3)
+	cfg, err := chain.Config(types.GRPCClientOption(offlineGRPC{}), types.TokenRegistryOption(registry))
+	require.NoError(t, err)
+	offline := sdk.NewIRISHUBClient(cfg)
+
+	amount, err := types.ParseDecCoins("1.5btc,2eth")
+	require.NoError(t, err)
+	coins, sdkErr := offline.ToMinCoin(amount...)
+	require.NoError(t, sdkErr)
+	require.Equal(t, "150000000satoshi,2000000000000000000wei", coins.String())
+
+	main, sdkErr := offline.ToMainCoin(coins...)
+	require.NoError(t, sdkErr)
+	require.Equal(t, amount.String(), main.String())
+
+	_, sdkErr = offline.ToMinCoin(types.NewDecCoin("doge", types.NewInt(1)))
+	require.Error(t, sdkErr)
+	require.Contains(t, sdkErr.Error(), "connection refused")
+}
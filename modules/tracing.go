@@ -0,0 +1,168 @@
+package modules
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.opentelemetry.io/otel/attribute"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/tracing"
+)
+
+// tracingTmClient traces the abci queries, the broadcasts, the tx queries and the subscription handlers of the wrapped client
+type tracingTmClient struct {
+	sdk.TmClient
+	tracer tracing.Tracer
+}
+
+func newTracingTmClient(client sdk.TmClient, tracer tracing.Tracer) sdk.TmClient {
+	return tracingTmClient{TmClient: client, tracer: tracer}
+}
+
+func (c tracingTmClient) ABCIQuery(ctx context.Context, path string, data tmbytes.HexBytes) (res *ctypes.ResultABCIQuery, err error) {
+	ctx, span := c.tracer.Start(ctx, "ABCIQuery", attribute.String(tracing.AttributeKeyABCIPath, path))
+	defer func() {
+		if err == nil {
+			span.SetAttributes(attribute.Int64(tracing.AttributeKeyCode, int64(res.Response.Code)))
+		}
+		tracing.End(span, err)
+	}()
+	return c.TmClient.ABCIQuery(ctx, path, data)
+}
+
+func (c tracingTmClient) ABCIQueryWithOptions(ctx context.Context, path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (res *ctypes.ResultABCIQuery, err error) {
+	ctx, span := c.tracer.Start(ctx, "ABCIQuery",
+		attribute.String(tracing.AttributeKeyABCIPath, path),
+		attribute.Int64(tracing.AttributeKeyTxHeight, opts.Height),
+	)
+	defer func() {
+		if err == nil {
+			span.SetAttributes(attribute.Int64(tracing.AttributeKeyCode, int64(res.Response.Code)))
+		}
+		tracing.End(span, err)
+	}()
+	return c.TmClient.ABCIQueryWithOptions(ctx, path, data, opts)
+}
+
+func (c tracingTmClient) BroadcastTxCommit(ctx context.Context, tx tmtypes.Tx) (res *ctypes.ResultBroadcastTxCommit, err error) {
+	ctx, span := c.tracer.Start(ctx, "BroadcastTxCommit", attribute.String(tracing.AttributeKeyTxHash, tmbytes.HexBytes(tx.Hash()).String()))
+	defer func() {
+		if err == nil {
+			codespace, code := res.DeliverTx.Codespace, res.DeliverTx.Code
+			if !res.CheckTx.IsOK() {
+				codespace, code = res.CheckTx.Codespace, res.CheckTx.Code
+			}
+			span.SetAttributes(
+				attribute.Int64(tracing.AttributeKeyTxHeight, res.Height),
+				attribute.String(tracing.AttributeKeyCodespace, codespace),
+				attribute.Int64(tracing.AttributeKeyCode, int64(code)),
+			)
+		}
+		tracing.End(span, err)
+	}()
+	return c.TmClient.BroadcastTxCommit(ctx, tx)
+}
+
+func (c tracingTmClient) BroadcastTxSync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.broadcastTx(ctx, "BroadcastTxSync", tx, c.TmClient.BroadcastTxSync)
+}
+
+func (c tracingTmClient) BroadcastTxAsync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.broadcastTx(ctx, "BroadcastTxAsync", tx, c.TmClient.BroadcastTxAsync)
+}
+
+func (c tracingTmClient) Tx(ctx context.Context, hash []byte, prove bool) (res *ctypes.ResultTx, err error) {
+	ctx, span := c.tracer.Start(ctx, "Tx", attribute.String(tracing.AttributeKeyTxHash, tmbytes.HexBytes(hash).String()))
+	defer func() {
+		if err == nil {
+			span.SetAttributes(attribute.Int64(tracing.AttributeKeyTxHeight, res.Height))
+		}
+		tracing.End(span, err)
+	}()
+	return c.TmClient.Tx(ctx, hash, prove)
+}
+
+func (c tracingTmClient) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	ctx, span := c.tracer.Start(ctx, "TxSearch", attribute.String(tracing.AttributeKeyQuery, query))
+	res, err := c.TmClient.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	tracing.End(span, err)
+	return res, err
+}
+
+func (c tracingTmClient) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	return c.TmClient.SubscribeNewBlock(builder, func(block sdk.EventDataNewBlock) {
+		_, span := c.tracer.Start(context.Background(), "SubscribeNewBlock.handle",
+			attribute.Int64(tracing.AttributeKeyTxHeight, block.Block.Height),
+		)
+		defer span.End()
+		handler(block)
+	})
+}
+
+func (c tracingTmClient) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	return c.TmClient.SubscribeTx(builder, func(tx sdk.EventDataTx) {
+		attrs := []attribute.KeyValue{
+			attribute.String(tracing.AttributeKeyTxHash, tx.Hash),
+			attribute.Int64(tracing.AttributeKeyTxHeight, tx.Height),
+		}
+		if tx.Tx != nil {
+			attrs = append(attrs, tracing.MsgTypes(msgTypes(tx.Tx.GetMsgs())))
+		}
+
+		_, span := c.tracer.Start(context.Background(), "SubscribeTx.handle", attrs...)
+		defer span.End()
+		handler(tx)
+	})
+}
+
+func (c tracingTmClient) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	return c.TmClient.SubscribeNewBlockHeader(func(header sdk.EventDataNewBlockHeader) {
+		_, span := c.tracer.Start(context.Background(), "SubscribeNewBlockHeader.handle",
+			attribute.Int64(tracing.AttributeKeyTxHeight, header.Header.Height),
+		)
+		defer span.End()
+		handler(header)
+	})
+}
+
+func (c tracingTmClient) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	return c.TmClient.SubscribeValidatorSetUpdates(func(updates sdk.EventDataValidatorSetUpdates) {
+		_, span := c.tracer.Start(context.Background(), "SubscribeValidatorSetUpdates.handle")
+		defer span.End()
+		handler(updates)
+	})
+}
+
+func (c tracingTmClient) broadcastTx(ctx context.Context, name string, tx tmtypes.Tx,
+	broadcast func(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)) (res *ctypes.ResultBroadcastTx, err error) {
+	ctx, span := c.tracer.Start(ctx, name, attribute.String(tracing.AttributeKeyTxHash, tmbytes.HexBytes(tx.Hash()).String()))
+	defer func() {
+		if err == nil {
+			span.SetAttributes(
+				attribute.String(tracing.AttributeKeyCodespace, res.Codespace),
+				attribute.Int64(tracing.AttributeKeyCode, int64(res.Code)),
+			)
+		}
+		tracing.End(span, err)
+	}()
+	return broadcast(ctx, tx)
+}
+
+// msgTypes returns the type urls of the messages, e.g. /cosmos.bank.v1beta1.MsgSend
+func msgTypes(msgs []sdk.Msg) []string {
+	types := make([]string, len(msgs))
+	for i, msg := range msgs {
+		types[i] = "/" + proto.MessageName(msg)
+	}
+	return types
+}
+
+// hashTx returns the hash of the transaction bytes, as returned by the chain
+func hashTx(txBytes []byte) string {
+	return tmbytes.HexBytes(tmtypes.Tx(txBytes).Hash()).String()
+}
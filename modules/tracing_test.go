@@ -0,0 +1,67 @@
+package modules_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/irisnet/irishub-sdk-go/fakechain/fakechaintest"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	_, client, alice, bob := fakechaintest.Setup(t, types.TracerProviderOption(provider))
+
+	amount, err := types.ParseDecCoins("10iris")
+	require.NoError(t, err)
+	res, err := client.Bank.Send(bob, amount, fakechaintest.BaseTx("alice"))
+	require.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range exporter.GetSpans().Snapshots() {
+		spans[span.Name()] = span
+	}
+
+	root, ok := spans["BuildAndSend"]
+	require.True(t, ok)
+	for _, name := range []string{"QueryAddress", "QueryAndRefreshAccount", "ToMinCoin", "Sign", "Broadcast"} {
+		span, ok := spans[name]
+		require.True(t, ok, name)
+		require.Equal(t, root.SpanContext().TraceID(), span.SpanContext().TraceID(), name)
+		require.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID(), name)
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range spans["Broadcast"].Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	require.Equal(t, res.Hash, attrs["tx.hash"])
+	require.Equal(t, strconv.FormatInt(res.Height, 10), attrs["tx.height"])
+
+	attrs = make(map[string]string)
+	for _, attr := range spans["Sign"].Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", attrs["tx.msg_types"])
+	require.Equal(t, alice, attrs["account.address"])
+
+	_, err = client.ToMainCoin(types.NewCoin("uiris", types.NewInt(1000000)))
+	require.NoError(t, err)
+	var span sdktrace.ReadOnlySpan
+	for _, s := range exporter.GetSpans().Snapshots() {
+		if s.Name() == "ToMainCoin" {
+			span = s
+		}
+	}
+	require.NotNil(t, span)
+	attrs = make(map[string]string)
+	for _, attr := range span.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	require.Equal(t, "1000000uiris", attrs["denoms"])
+}
@@ -8,11 +8,14 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"go.opentelemetry.io/otel/attribute"
 
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
 	clienttx "github.com/irisnet/irishub-sdk-go/client/tx"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/types/query"
+	"github.com/irisnet/irishub-sdk-go/utils/tracing"
 )
 
 // QueryTx returns the tx info
@@ -35,6 +38,10 @@ func (base baseClient) QueryTx(hash string) (sdk.ResultQueryTx, error) {
 }
 
 func (base baseClient) QueryTxs(builder *sdk.EventQueryBuilder, page, size *int) (sdk.ResultSearchTxs, error) {
+	if err := builder.Validate(); err != nil {
+		return sdk.ResultSearchTxs{}, err
+	}
+
 	query := builder.Build()
 	if len(query) == 0 {
 		return sdk.ResultSearchTxs{}, errors.New("must declare at least one tag to search")
@@ -65,6 +72,31 @@ func (base baseClient) QueryTxs(builder *sdk.EventQueryBuilder, page, size *int)
 	}, nil
 }
 
+// maxTxSearchPerPage is the maximum number of txs per page of the tendermint tx search
+const maxTxSearchPerPage = 100
+
+// IterateTxs returns an iterator over all the txs matching the builder, the items are sdk.ResultQueryTx
+func (base baseClient) IterateTxs(builder *sdk.EventQueryBuilder) *sdk.Iterator {
+	return sdk.NewOffsetIterator(func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		size := int(pageReq.Limit)
+		if size > maxTxSearchPerPage {
+			size = maxTxSearchPerPage
+		}
+		page := int(pageReq.Offset)/size + 1
+
+		res, err := base.QueryTxs(builder, &page, &size)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items := make([]interface{}, len(res.Txs))
+		for i, tx := range res.Txs {
+			items[i] = tx
+		}
+		return items, &query.PageResponse{Total: uint64(res.Total)}, nil
+	})
+}
+
 func (base baseClient) QueryBlock(height int64) (sdk.BlockDetail, error) {
 	block, err := base.Block(context.Background(), &height)
 	if err != nil {
@@ -84,7 +116,19 @@ func (base baseClient) QueryBlock(height int64) (sdk.BlockDetail, error) {
 }
 
 func (base baseClient) EstimateTxGas(txBytes []byte) (uint64, error) {
-	res, err := base.ABCIQuery(context.Background(), "/app/simulate", txBytes)
+	return base.estimateTxGas(context.Background(), txBytes)
+}
+
+func (base baseClient) TxDefaults() sdk.TxDefaults {
+	return sdk.TxDefaults{
+		Gas:        base.cfg.Gas,
+		Fee:        base.cfg.Fee,
+		MaxTxBytes: base.cfg.MaxTxBytes,
+	}
+}
+
+func (base baseClient) estimateTxGas(ctx context.Context, txBytes []byte) (uint64, error) {
+	res, err := base.ABCIQuery(ctx, "/app/simulate", txBytes)
 	if err != nil {
 		return 0, err
 	}
@@ -98,13 +142,13 @@ func (base baseClient) EstimateTxGas(txBytes []byte) (uint64, error) {
 	return adjusted, nil
 }
 
-func (base *baseClient) buildTx(msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
-	builder, err := base.prepare(baseTx)
+func (base *baseClient) buildTx(ctx context.Context, msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
+	builder, err := base.prepare(ctx, baseTx)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
 	}
 
-	txByte, err := builder.BuildAndSign(baseTx.From, msgs, false)
+	txByte, err := base.sign(ctx, builder, baseTx.From, msgs)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
 	}
@@ -113,13 +157,13 @@ func (base *baseClient) buildTx(msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *cli
 	return txByte, builder, nil
 }
 
-func (base *baseClient) buildTxWithAccount(addr string, accountNumber, sequence uint64, msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
-	builder, err := base.prepareTemp(addr, accountNumber, sequence, baseTx)
+func (base *baseClient) buildTxWithAccount(ctx context.Context, addr string, accountNumber, sequence uint64, msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
+	builder, err := base.prepareTemp(ctx, addr, accountNumber, sequence, baseTx)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
 	}
 
-	txByte, err := builder.BuildAndSign(baseTx.From, msgs, false)
+	txByte, err := base.sign(ctx, builder, baseTx.From, msgs)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
 	}
@@ -128,9 +172,36 @@ func (base *baseClient) buildTxWithAccount(addr string, accountNumber, sequence
 	return txByte, builder, nil
 }
 
-func (base baseClient) broadcastTx(txBytes []byte, mode sdk.BroadcastMode, simulate bool) (res sdk.ResultTx, err sdk.Error) {
+// sign builds and signs the transaction in a span carrying the message types and the signer
+func (base *baseClient) sign(ctx context.Context, builder *clienttx.Factory, name string, msgs []sdk.Msg) (txByte []byte, err error) {
+	_, span := base.tracer.Start(ctx, "Sign",
+		tracing.MsgTypes(msgTypes(msgs)),
+		attribute.String(tracing.AttributeKeyAccountName, name),
+		attribute.String(tracing.AttributeKeyAccountAddress, builder.Address()),
+		attribute.Int64(tracing.AttributeKeyAccountNumber, int64(builder.AccountNumber())),
+		attribute.Int64(tracing.AttributeKeyAccountSequence, int64(builder.Sequence())),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	return builder.BuildAndSign(name, msgs, false)
+}
+
+func (base baseClient) broadcastTx(ctx context.Context, txBytes []byte, mode sdk.BroadcastMode, simulate bool) (res sdk.ResultTx, err sdk.Error) {
+	ctx, span := base.tracer.Start(ctx, "Broadcast",
+		attribute.String(tracing.AttributeKeyBroadcastMode, string(mode)),
+		attribute.String(tracing.AttributeKeyTxHash, hashTx(txBytes)),
+	)
+	defer func() {
+		span.SetAttributes(
+			attribute.Int64(tracing.AttributeKeyTxHeight, res.Height),
+			attribute.Int64(tracing.AttributeKeyTxGasWanted, res.GasWanted),
+			attribute.Int64(tracing.AttributeKeyTxGasUsed, res.GasUsed),
+		)
+		tracing.End(span, err)
+	}()
+
 	if simulate {
-		estimateGas, err := base.EstimateTxGas(txBytes)
+		estimateGas, err := base.estimateTxGas(ctx, txBytes)
 		if err != nil {
 			return res, sdk.Wrap(err)
 		}
@@ -140,11 +211,11 @@ func (base baseClient) broadcastTx(txBytes []byte, mode sdk.BroadcastMode, simul
 
 	switch mode {
 	case sdk.Commit:
-		res, err = base.broadcastTxCommit(txBytes)
+		res, err = base.broadcastTxCommit(ctx, txBytes)
 	case sdk.Async:
-		res, err = base.broadcastTxAsync(txBytes)
+		res, err = base.broadcastTxAsync(ctx, txBytes)
 	case sdk.Sync:
-		res, err = base.broadcastTxSync(txBytes)
+		res, err = base.broadcastTxSync(ctx, txBytes)
 	default:
 		err = sdk.Wrapf("commit mode(%s) not supported", mode)
 	}
@@ -153,8 +224,8 @@ func (base baseClient) broadcastTx(txBytes []byte, mode sdk.BroadcastMode, simul
 
 // broadcastTxCommit broadcasts transaction bytes to a Tendermint node
 // and waits for a commit.
-func (base baseClient) broadcastTxCommit(tx []byte) (sdk.ResultTx, sdk.Error) {
-	res, err := base.BroadcastTxCommit(context.Background(), tx)
+func (base baseClient) broadcastTxCommit(ctx context.Context, tx []byte) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BroadcastTxCommit(ctx, tx)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -178,8 +249,8 @@ func (base baseClient) broadcastTxCommit(tx []byte) (sdk.ResultTx, sdk.Error) {
 
 // BroadcastTxSync broadcasts transaction bytes to a Tendermint node
 // synchronously.
-func (base baseClient) broadcastTxSync(tx []byte) (sdk.ResultTx, sdk.Error) {
-	res, err := base.BroadcastTxSync(context.Background(), tx)
+func (base baseClient) broadcastTxSync(ctx context.Context, tx []byte) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BroadcastTxSync(ctx, tx)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -193,8 +264,8 @@ func (base baseClient) broadcastTxSync(tx []byte) (sdk.ResultTx, sdk.Error) {
 
 // BroadcastTxAsync broadcasts transaction bytes to a Tendermint node
 // asynchronously.
-func (base baseClient) broadcastTxAsync(tx []byte) (sdk.ResultTx, sdk.Error) {
-	res, err := base.BroadcastTxAsync(context.Background(), tx)
+func (base baseClient) broadcastTxAsync(ctx context.Context, tx []byte) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BroadcastTxAsync(ctx, tx)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -228,6 +299,7 @@ func (base baseClient) parseTxResult(res *ctypes.ResultTx, resBlock *ctypes.Resu
 	return sdk.ResultQueryTx{
 		Hash:   res.Hash.String(),
 		Height: res.Height,
+		Index:  res.Index,
 		Tx:     tx,
 		Result: sdk.TxResult{
 			Code:      res.TxResult.Code,
@@ -12,8 +12,23 @@ type TxManager interface {
 	BuildTxHash(msg []Msg, baseTx BaseTx) (string, Error)
 	BuildAndSend(msg []Msg, baseTx BaseTx) (ResultTx, Error)
 	BuildAndSign(msg []Msg, baseTx BaseTx) ([]byte, Error)
+	// BuildSignedTx returns the protobuf bytes of the signed tx, to be broadcast as is, e.g. with BroadcastTxCommit
+	BuildSignedTx(msg []Msg, baseTx BaseTx) ([]byte, Error)
+	// BuildUnsignedTx returns the JSON of the unsigned tx from baseTx.From, e.g. a watch-only key, to be signed elsewhere
+	BuildUnsignedTx(msg []Msg, baseTx BaseTx) ([]byte, Error)
 	SendBatch(msgs Msgs, baseTx BaseTx) ([]ResultTx, Error)
 	BuildAndSendWithAccount(addr string, accountNumber, sequence uint64, msg []Msg, baseTx BaseTx) (ResultTx, Error)
+	// EstimateTxGas returns the gas of the signed tx simulated by the node, multiplied by the GasAdjustment of the config
+	EstimateTxGas(txBytes []byte) (uint64, error)
+	// TxDefaults returns the gas and the fee of the config, used when BaseTx has none, and the max tx bytes
+	TxDefaults() TxDefaults
+}
+
+// TxDefaults is the part of ClientConfig applying to the txs
+type TxDefaults struct {
+	Gas        uint64
+	Fee        DecCoins
+	MaxTxBytes uint64
 }
 
 type Queries interface {
@@ -44,6 +59,7 @@ type AccountQuery interface {
 type TmQuery interface {
 	QueryTx(hash string) (ResultQueryTx, error)
 	QueryTxs(builder *EventQueryBuilder, page, size *int) (ResultSearchTxs, error)
+	IterateTxs(builder *EventQueryBuilder) *Iterator
 	QueryBlock(height int64) (BlockDetail, error)
 }
 
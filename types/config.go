@@ -1,10 +1,15 @@
 package types
 
 import (
+	"crypto/tls"
 	"fmt"
 	"os"
 
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/irisnet/irishub-sdk-go/types/store"
+	"github.com/irisnet/irishub-sdk-go/utils/metrics"
 )
 
 const (
@@ -26,6 +31,9 @@ type ClientConfig struct {
 	// irishub grpc address
 	GRPCAddr string
 
+	// TLS config of the grpc connection to GRPCAddr, the connection is insecure when nil
+	GRPCTLSConfig *tls.Config
+
 	// irishub chain-id
 	ChainID string
 
@@ -38,6 +46,9 @@ type ClientConfig struct {
 	// PrivKeyArmor DAO Implements
 	KeyDAO store.KeyDAO
 
+	// KeyManager Implements, replaces the key manager of the KeyDAO when set, e.g. a remote signer
+	KeyManager KeyManager
+
 	// Private key generation algorithm(sm2,secp256k1)
 	Algo string
 
@@ -58,6 +69,24 @@ type ClientConfig struct {
 
 	//whether to enable caching
 	Cached bool
+
+	// TmClient Implements, replaces the rpc client connected to NodeURI when set
+	TmClient TmClient
+
+	// GRPCClient Implements, replaces the grpc client connected to GRPCAddr when set
+	GRPCClient GRPCClient
+
+	// TokenRegistry Implements, the tokens are looked up in it before they are queried from the chain when set
+	TokenRegistry TokenRegistry
+
+	// prometheus metrics of the client, disabled when nil
+	Metrics *metrics.Metrics
+
+	// OpenTelemetry tracer provider, the no-op provider is used when nil
+	TracerProvider trace.TracerProvider
+
+	// propagator of the trace context in the grpc metadata, the W3C trace context is used when nil
+	Propagator propagation.TextMapPropagator
 }
 
 func NewClientConfig(uri, grpcAddr, chainID string, options ...Option) (ClientConfig, error) {
@@ -79,7 +108,7 @@ func NewClientConfig(uri, grpcAddr, chainID string, options ...Option) (ClientCo
 }
 
 func (cfg *ClientConfig) checkAndSetDefault() error {
-	if len(cfg.NodeURI) == 0 {
+	if len(cfg.NodeURI) == 0 && cfg.TmClient == nil {
 		return fmt.Errorf("nodeURI is required")
 	}
 
@@ -226,3 +255,59 @@ func CachedOption(enabled bool) Option {
 		return nil
 	}
 }
+
+func TmClientOption(client TmClient) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.TmClient = client
+		return nil
+	}
+}
+
+func GRPCClientOption(client GRPCClient) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GRPCClient = client
+		return nil
+	}
+}
+
+func GRPCTLSOption(tlsConfig *tls.Config) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GRPCTLSConfig = tlsConfig
+		return nil
+	}
+}
+
+func TokenRegistryOption(registry TokenRegistry) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.TokenRegistry = registry
+		return nil
+	}
+}
+
+func KeyManagerOption(keyManager KeyManager) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.KeyManager = keyManager
+		return nil
+	}
+}
+
+func MetricsOption(m *metrics.Metrics) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.Metrics = m
+		return nil
+	}
+}
+
+func TracerProviderOption(provider trace.TracerProvider) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.TracerProvider = provider
+		return nil
+	}
+}
+
+func PropagatorOption(propagator propagation.TextMapPropagator) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.Propagator = propagator
+		return nil
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 )
 
 type WSClient interface {
@@ -90,7 +94,7 @@ func (a Attributes) String() string {
 
 type EventTxHandler func(EventDataTx)
 
-//EventDataNewBlock for SubscribeNewBlock
+// EventDataNewBlock for SubscribeNewBlock
 type EventDataNewBlock struct {
 	Block            Block            `json:"block"`
 	ResultBeginBlock ResultBeginBlock `json:"result_begin_block"`
@@ -109,7 +113,7 @@ type PubKey struct {
 
 type EventNewBlockHandler func(EventDataNewBlock)
 
-//EventDataNewBlockHeader for SubscribeNewBlockHeader
+// EventDataNewBlockHeader for SubscribeNewBlockHeader
 type EventDataNewBlockHeader struct {
 	Header Header `json:"header"`
 
@@ -119,7 +123,7 @@ type EventDataNewBlockHeader struct {
 
 type EventNewBlockHeaderHandler func(EventDataNewBlockHeader)
 
-//EventDataValidatorSetUpdates for SubscribeValidatorSetUpdates
+// EventDataValidatorSetUpdates for SubscribeValidatorSetUpdates
 type Validator struct {
 	Bech32Address    string `json:"bech32_address"`
 	Bech32PubKey     string `json:"bech32_pubkey"`
@@ -134,13 +138,27 @@ type EventDataValidatorSetUpdates struct {
 
 type EventValidatorSetUpdatesHandler func(EventDataValidatorSetUpdates)
 
-//EventQueryBuilder for build query string
+// EventQueryBuilder for build query string
 type condition struct {
 	key   EventKey
 	value EventValue
 	op    string
 }
 
+const (
+	opLTE      = "<="
+	opGTE      = ">="
+	opLT       = "<"
+	opGT       = ">"
+	opEQ       = "="
+	opContains = "CONTAINS"
+	opExists   = "EXISTS"
+)
+
+// EventDate is a date-only operand, it is rendered as `DATE 2006-01-02` in the query.
+// A time.Time operand is rendered as `TIME 2006-01-02T15:04:05Z07:00`
+type EventDate time.Time
+
 // Cond return a condition object with a key
 func Cond(key EventKey) *condition {
 	return &condition{
@@ -156,28 +174,34 @@ func NewCond(typ, attrKey string) *condition {
 }
 
 func (c *condition) LTE(v EventValue) *condition {
-	return c.fill(v, "<=")
+	return c.fill(v, opLTE)
 }
 
 func (c *condition) GTE(v EventValue) *condition {
-	return c.fill(v, ">=")
+	return c.fill(v, opGTE)
 }
 
 func (c *condition) LE(v EventValue) *condition {
-	return c.fill(v, "<")
+	return c.fill(v, opLT)
 }
 
 func (c *condition) GE(v EventValue) *condition {
-	return c.fill(v, ">")
+	return c.fill(v, opGT)
 }
 
 func (c *condition) EQ(v EventValue) *condition {
-	return c.fill(v, "=")
+	return c.fill(v, opEQ)
 }
 
-//func (c *condition) Contains(v EventValue) *condition {
-//	return c.fill(v, "CONTAINS")
-//}
+// Contains matches the events whose attribute value contains the sub string v
+func (c *condition) Contains(v EventValue) *condition {
+	return c.fill(v, opContains)
+}
+
+// Exists matches the events which contain the attribute, whatever its value is
+func (c *condition) Exists() *condition {
+	return c.fill(nil, opExists)
+}
 
 func (c *condition) fill(v EventValue, op string) *condition {
 	c.value = v
@@ -190,42 +214,189 @@ func (c *condition) String() string {
 		return ""
 	}
 
-	switch c.value.(type) {
-	case int64, uint64:
-		return fmt.Sprintf("%s%s%d", c.key, c.op, c.value)
+	switch c.op {
+	case opExists:
+		return fmt.Sprintf("%s %s", c.key, c.op)
+	case opContains:
+		return fmt.Sprintf("%s %s %s", c.key, c.op, formatEventValue(c.value))
 	default:
-		return fmt.Sprintf("%s%s'%s'", c.key, c.op, c.value)
+		return fmt.Sprintf("%s%s%s", c.key, c.op, formatEventValue(c.value))
 	}
 }
 
-//EventQueryBuilder is responsible for constructing listening conditions
+// validate checks the format of the key and the operand of the condition,
+// and that the event type and attribute key have been registered when strict
+func (c *condition) validate(strict bool) error {
+	key := string(c.key)
+	i := strings.LastIndex(key, ".")
+	if i <= 0 || i == len(key)-1 {
+		return fmt.Errorf("invalid event key: %s, expected format: {eventType}.{attributeKey}", key)
+	}
+
+	if c.op != "" && c.op != opExists && c.value == nil {
+		return fmt.Errorf("missing operand for event attribute: %s", key)
+	}
+
+	typ, attrKey := key[:i], key[i+1:]
+	if strict && !IsEventAttributeRegistered(typ, attrKey) {
+		return fmt.Errorf("unknown event attribute: %s", key)
+	}
+	return nil
+}
+
+func formatEventValue(v EventValue) string {
+	switch v := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return fmt.Sprintf("TIME %s", v.Format(tmquery.TimeLayout))
+	case EventDate:
+		return fmt.Sprintf("DATE %s", time.Time(v).Format(tmquery.DateLayout))
+	default:
+		return fmt.Sprintf("'%s'", v)
+	}
+}
+
+// EventQueryBuilder is responsible for constructing listening conditions
 type EventQueryBuilder struct {
-	conditions []string
+	conditions []condition
 }
 
 func NewEventQueryBuilder() *EventQueryBuilder {
 	return &EventQueryBuilder{
-		conditions: []string{},
+		conditions: []condition{},
 	}
 }
 
-//AddCondition is responsible for adding listening conditions
+// ParseEventQuery parses a tendermint query string back into an EventQueryBuilder
+func ParseEventQuery(query string) (*EventQueryBuilder, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := q.Conditions()
+	if err != nil {
+		return nil, err
+	}
+
+	// the tendermint parser returns time.Time for both TIME and DATE operands
+	dates := dateOperands(query)
+
+	builder := NewEventQueryBuilder()
+	for _, cond := range conditions {
+		c := Cond(EventKey(cond.CompositeKey))
+		value := EventValue(cond.Operand)
+
+		if t, ok := cond.Operand.(time.Time); ok {
+			if len(dates) > 0 && dates[0] {
+				value = EventDate(t)
+			}
+			if len(dates) > 0 {
+				dates = dates[1:]
+			}
+		}
+
+		switch cond.Op {
+		case tmquery.OpLessEqual:
+			c.LTE(value)
+		case tmquery.OpGreaterEqual:
+			c.GTE(value)
+		case tmquery.OpLess:
+			c.LE(value)
+		case tmquery.OpGreater:
+			c.GE(value)
+		case tmquery.OpEqual:
+			c.EQ(value)
+		case tmquery.OpContains:
+			c.Contains(value)
+		case tmquery.OpExists:
+			c.Exists()
+		default:
+			return nil, fmt.Errorf("unsupported operator in query: %s", query)
+		}
+		builder.AddCondition(c)
+	}
+	return builder, nil
+}
+
+// dateOperands returns whether every TIME or DATE operand of the query is a DATE, in the order of the conditions.
+// The operands follow a comparison operator outside of the quoted strings.
+func dateOperands(query string) []bool {
+	var (
+		dates           []bool
+		quoted, operand bool
+	)
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'':
+			quoted = !quoted
+			operand = false
+		case quoted:
+		case c == '<' || c == '>' || c == '=':
+			operand = true
+		case c == ' ':
+		default:
+			if operand && strings.HasPrefix(query[i:], "DATE ") {
+				dates = append(dates, true)
+			} else if operand && strings.HasPrefix(query[i:], "TIME ") {
+				dates = append(dates, false)
+			}
+			operand = false
+		}
+	}
+	return dates
+}
+
+// AddCondition is responsible for adding listening conditions
 func (eqb *EventQueryBuilder) AddCondition(c *condition) *EventQueryBuilder {
 	if c == nil {
 		return nil
 	}
-	eqb.conditions = append(eqb.conditions, c.String())
+	eqb.conditions = append(eqb.conditions, *c)
 	return eqb
 }
 
-//Build is responsible for constructing the listening condition into a listening instruction identified by tendermint
+// Validate checks the format of the keys and the operands of the conditions, the subscriptions and the tx queries
+// validate their builder, any event type and attribute key is accepted
+func (eqb *EventQueryBuilder) Validate() error {
+	for _, c := range eqb.conditions {
+		if err := c.validate(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateStrict also checks every condition against the registered event types and attribute keys,
+// so that a misspelled key is reported instead of silently matching nothing. The events of the modules
+// without client, e.g. distribution, ibc or the custom events of the chain, must be registered first
+// by RegisterEventAttributes.
+func (eqb *EventQueryBuilder) ValidateStrict() error {
+	for _, c := range eqb.conditions {
+		if err := c.validate(true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build is responsible for constructing the listening condition into a listening instruction identified by tendermint
 func (eqb *EventQueryBuilder) Build() string {
 	var buf bytes.Buffer
 	for _, condition := range eqb.conditions {
+		cond := condition.String()
+		if len(cond) == 0 {
+			continue
+		}
 		if buf.Len() > 0 {
 			buf.WriteString(" AND ")
 		}
-		buf.WriteString(condition)
+		buf.WriteString(cond)
 	}
 	return buf.String()
 }
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventQueryBuilder_Build(t *testing.T) {
+	ts := time.Date(2021, 8, 1, 12, 30, 0, 0, time.UTC)
+
+	builder := NewEventQueryBuilder().
+		AddCondition(Cond(TypeKey).EQ(TxValue)).
+		AddCondition(NewCond(EventTypeMessage, AttributeKeySender).EQ("iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z")).
+		AddCondition(NewCond("tx", "height").GTE(int64(100))).
+		AddCondition(NewCond("transfer", "amount").Contains("uiris")).
+		AddCondition(NewCond("transfer", "recipient").Exists()).
+		AddCondition(Cond("tx.time").LE(ts)).
+		AddCondition(Cond("tx.date").GE(EventDate(ts)))
+
+	require.Equal(t,
+		"tm.event='Tx' AND "+
+			"message.sender='iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z' AND "+
+			"tx.height>=100 AND "+
+			"transfer.amount CONTAINS 'uiris' AND "+
+			"transfer.recipient EXISTS AND "+
+			"tx.time<TIME 2021-08-01T12:30:00Z AND "+
+			"tx.date>DATE 2021-08-01",
+		builder.Build(),
+	)
+}
+
+func TestParseEventQuery(t *testing.T) {
+	queries := []string{
+		"tm.event='Tx' AND message.action='send'",
+		"tx.height>=100 AND tx.height<=200",
+		"transfer.amount CONTAINS 'uiris' AND transfer.recipient EXISTS",
+		"tx.time>TIME 2021-08-01T12:30:00Z",
+		"tx.date<DATE 2021-08-01",
+		"tx.date>DATE 2021-08-01 AND tx.time<TIME 2021-08-01T00:00:00Z",
+		"tx.time>TIME 2021-08-01T00:00:00Z AND tx.date<=DATE 2021-08-01",
+		"memo.text='x> DATE ' AND tx.time>TIME 2021-08-01T00:00:00Z",
+	}
+
+	for _, query := range queries {
+		builder, err := ParseEventQuery(query)
+		require.NoError(t, err)
+		require.Equal(t, query, builder.Build())
+	}
+
+	_, err := ParseEventQuery("tx.height=>1")
+	require.Error(t, err)
+}
+
+func TestEventQueryBuilder_Validate(t *testing.T) {
+	builder := NewEventQueryBuilder().
+		AddCondition(NewCond(EventTypeMessage, AttributeKeyAction).EQ("send"))
+	require.NoError(t, builder.ValidateStrict())
+
+	builder.AddCondition(NewCond(EventTypeMessage, "sneder").EQ("iaa1"))
+	require.Error(t, builder.ValidateStrict())
+	require.NoError(t, builder.Validate())
+
+	require.Error(t, NewEventQueryBuilder().AddCondition(Cond("height").EQ(1)).Validate())
+	require.Error(t, NewEventQueryBuilder().AddCondition(Cond("tx.height").EQ(nil)).Validate())
+	require.Error(t, NewEventQueryBuilder().AddCondition(Cond("unknown.key").EQ(1)).ValidateStrict())
+
+	// the events of the modules without client are accepted by the queries
+	require.NoError(t, NewEventQueryBuilder().AddCondition(Cond("delegate.validator").EQ("iva1")).Validate())
+
+	RegisterEventAttributes("unknown", "key")
+	require.NoError(t, NewEventQueryBuilder().AddCondition(Cond("unknown.key").EQ(1)).ValidateStrict())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -137,8 +138,52 @@ var (
 	AttributeKeyAmount = "amount"
 
 	TxValue EventValue = "Tx"
+
+	eventAttributes = struct {
+		sync.RWMutex
+		m map[string]map[string]struct{}
+	}{m: make(map[string]map[string]struct{})}
 )
 
+func init() {
+	RegisterEventAttributes("tm", "event")
+	RegisterEventAttributes("tx", "hash", "height", "acc_seq", "signature", "fee")
+	RegisterEventAttributes(EventTypeMessage, AttributeKeyAction, AttributeKeyModule, AttributeKeySender)
+	RegisterEventAttributes("transfer", "recipient", AttributeKeySender, AttributeKeyAmount)
+	RegisterEventAttributes("coin_spent", "spender", AttributeKeyAmount)
+	RegisterEventAttributes("coin_received", "receiver", AttributeKeyAmount)
+	RegisterEventAttributes("burn", "burner", AttributeKeyAmount)
+}
+
+// RegisterEventAttributes registers the attribute keys of an event type,
+// EventQueryBuilder.ValidateStrict only accepts the registered event attributes
+func RegisterEventAttributes(typ string, attrKeys ...string) {
+	eventAttributes.Lock()
+	defer eventAttributes.Unlock()
+
+	keys, ok := eventAttributes.m[typ]
+	if !ok {
+		keys = make(map[string]struct{})
+		eventAttributes.m[typ] = keys
+	}
+	for _, key := range attrKeys {
+		keys[key] = struct{}{}
+	}
+}
+
+// IsEventAttributeRegistered returns whether the attribute key of the event type has been registered
+func IsEventAttributeRegistered(typ, attrKey string) bool {
+	eventAttributes.RLock()
+	defer eventAttributes.RUnlock()
+
+	keys, ok := eventAttributes.m[typ]
+	if !ok {
+		return false
+	}
+	_, ok = keys[attrKey]
+	return ok
+}
+
 type (
 	// StringAttributes defines a slice of StringEvents objects.
 	StringEvents []StringEvent
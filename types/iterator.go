@@ -0,0 +1,245 @@
+package types
+
+import (
+	"github.com/irisnet/irishub-sdk-go/types/query"
+)
+
+// DefaultPageSize is the number of items fetched per page by an Iterator
+const DefaultPageSize uint64 = 100
+
+// PageFetcher fetches the page specified by pageReq,
+// returns the items of the page and the pagination response of the query.
+// A nil pageRes means that the query is not paginated and all items have been returned.
+type PageFetcher func(pageReq *query.PageRequest) (items []interface{}, pageRes *query.PageResponse, err error)
+
+// Iterator walks through all the items of a list query page by page.
+//
+//	it := client.Staking.IterateValidators("")
+//	for it.Next() {
+//		validator := it.Value().(staking.QueryValidatorResp)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	fetch      PageFetcher
+	pageSize   uint64
+	offsetMode bool
+
+	items   []interface{}
+	index   int
+	nextKey []byte
+	offset  uint64
+	total   uint64
+	started bool
+	done    bool
+	err     Error
+
+	outer *Iterator
+	inner func(item interface{}) *Iterator
+	cur   *Iterator
+}
+
+// NewIterator returns an Iterator which follows the NextKey returned by the query
+func NewIterator(fetch PageFetcher) *Iterator {
+	return &Iterator{
+		fetch:    fetch,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// NewOffsetIterator returns an Iterator which pages by offset, for the queries which don't return NextKey
+func NewOffsetIterator(fetch PageFetcher) *Iterator {
+	it := NewIterator(fetch)
+	it.offsetMode = true
+	return it
+}
+
+// NewNestedIterator returns an Iterator which walks through the items of the inner iterator
+// created for every item of the outer iterator, e.g. the delegations of all validators
+func NewNestedIterator(outer *Iterator, inner func(item interface{}) *Iterator) *Iterator {
+	return &Iterator{
+		outer: outer,
+		inner: inner,
+	}
+}
+
+// WithPageSize sets the number of items fetched per page
+func (it *Iterator) WithPageSize(size uint64) *Iterator {
+	if size > 0 {
+		it.pageSize = size
+	}
+	if it.outer != nil {
+		it.outer.WithPageSize(size)
+	}
+	return it
+}
+
+// Next advances the iterator to the next item, it returns false when all items
+// have been visited, the iterator has been stopped or an error occurred
+func (it *Iterator) Next() bool {
+	if it.outer != nil {
+		return it.nextNested()
+	}
+
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if it.index+1 < len(it.items) {
+		it.index++
+		return true
+	}
+
+	for {
+		if it.started && !it.hasMorePages() {
+			it.done = true
+			return false
+		}
+		if !it.fetchPage() {
+			return false
+		}
+		if len(it.items) > 0 {
+			return true
+		}
+	}
+}
+
+// Value returns the current item, the concrete type is documented by the method that created the iterator
+func (it *Iterator) Value() interface{} {
+	if it.outer != nil {
+		if it.cur == nil {
+			return nil
+		}
+		return it.cur.Value()
+	}
+
+	if it.index < 0 || it.index >= len(it.items) {
+		return nil
+	}
+	return it.items[it.index]
+}
+
+// Err returns the error which terminated the iteration
+func (it *Iterator) Err() Error {
+	return it.err
+}
+
+// Total returns the total number of items reported by the first page, if the query supports it
+func (it *Iterator) Total() uint64 {
+	if it.outer != nil {
+		return 0
+	}
+	return it.total
+}
+
+// Stop terminates the iteration early, following calls of Next return false
+func (it *Iterator) Stop() {
+	it.done = true
+	if it.outer != nil {
+		it.outer.Stop()
+	}
+	if it.cur != nil {
+		it.cur.Stop()
+	}
+}
+
+// ForEach calls fn for every item until fn returns false or all items have been visited
+func (it *Iterator) ForEach(fn func(item interface{}) bool) Error {
+	for it.Next() {
+		if !fn(it.Value()) {
+			it.Stop()
+			break
+		}
+	}
+	return it.Err()
+}
+
+// All returns all the remaining items
+func (it *Iterator) All() ([]interface{}, Error) {
+	var items []interface{}
+	err := it.ForEach(func(item interface{}) bool {
+		items = append(items, item)
+		return true
+	})
+	return items, err
+}
+
+func (it *Iterator) hasMorePages() bool {
+	if it.offsetMode {
+		// the query may return less items than the page size, e.g. when the node caps it, the total decides then
+		if it.total > 0 {
+			return len(it.items) > 0 && it.offset < it.total
+		}
+		return uint64(len(it.items)) == it.pageSize
+	}
+	return len(it.nextKey) > 0
+}
+
+func (it *Iterator) fetchPage() bool {
+	pageReq := &query.PageRequest{
+		Limit:      it.pageSize,
+		CountTotal: !it.started,
+	}
+	if it.offsetMode {
+		pageReq.Offset = it.offset
+	} else {
+		pageReq.Key = it.nextKey
+	}
+
+	items, pageRes, err := it.fetch(pageReq)
+	if err != nil {
+		it.err = Wrap(err)
+		return false
+	}
+
+	if !it.started && pageRes != nil {
+		it.total = pageRes.Total
+	}
+	it.started = true
+	it.items = items
+	it.index = 0
+	it.offset += uint64(len(items))
+
+	switch {
+	case pageRes == nil:
+		// the query isn't paginated, all items have been returned
+		if it.total == 0 {
+			it.total = uint64(len(items))
+		}
+		it.nextKey = nil
+		it.offsetMode = false
+	case it.offsetMode:
+	default:
+		it.nextKey = pageRes.NextKey
+	}
+	return true
+}
+
+func (it *Iterator) nextNested() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	for {
+		if it.cur != nil {
+			if it.cur.Next() {
+				return true
+			}
+			if err := it.cur.Err(); err != nil {
+				it.err = err
+				return false
+			}
+		}
+
+		if !it.outer.Next() {
+			it.err = it.outer.Err()
+			it.done = true
+			return false
+		}
+		it.cur = it.inner(it.outer.Value())
+		if it.pageSize > 0 {
+			it.cur.WithPageSize(it.pageSize)
+		}
+	}
+}
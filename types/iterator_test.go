@@ -0,0 +1,129 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/types/query"
+)
+
+// keyFetcher pages over n integers, the next key is the index of the next item
+func keyFetcher(n int, calls *int) PageFetcher {
+	return func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		*calls++
+		start := 0
+		if len(pageReq.Key) > 0 {
+			start, _ = strconv.Atoi(string(pageReq.Key))
+		}
+
+		var items []interface{}
+		for i := start; i < n && uint64(len(items)) < pageReq.Limit; i++ {
+			items = append(items, i)
+		}
+
+		res := &query.PageResponse{}
+		if next := start + len(items); next < n {
+			res.NextKey = []byte(strconv.Itoa(next))
+		}
+		if pageReq.CountTotal {
+			res.Total = uint64(n)
+		}
+		return items, res, nil
+	}
+}
+
+func TestIterator_NextKey(t *testing.T) {
+	var calls int
+	it := NewIterator(keyFetcher(25, &calls)).WithPageSize(10)
+
+	items, err := it.All()
+	require.NoError(t, err)
+	require.Len(t, items, 25)
+	require.Equal(t, 3, calls)
+	require.EqualValues(t, 25, it.Total())
+	for i, item := range items {
+		require.Equal(t, i, item)
+	}
+}
+
+func TestIterator_Offset(t *testing.T) {
+	fetch := func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		var items []interface{}
+		for i := pageReq.Offset; i < 7 && uint64(len(items)) < pageReq.Limit; i++ {
+			items = append(items, int(i))
+		}
+		return items, &query.PageResponse{Total: 7}, nil
+	}
+
+	items, err := NewOffsetIterator(fetch).WithPageSize(3).All()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{0, 1, 2, 3, 4, 5, 6}, items)
+}
+
+func TestIterator_NotPaginated(t *testing.T) {
+	var calls int
+	it := NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		calls++
+		return []interface{}{"a", "b"}, nil, nil
+	})
+
+	items, err := it.All()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b"}, items)
+	require.EqualValues(t, 2, it.Total())
+	require.Equal(t, 1, calls)
+}
+
+func TestIterator_Stop(t *testing.T) {
+	var calls int
+	it := NewIterator(keyFetcher(100, &calls)).WithPageSize(10)
+
+	var visited []interface{}
+	err := it.ForEach(func(item interface{}) bool {
+		visited = append(visited, item)
+		return len(visited) < 15
+	})
+	require.NoError(t, err)
+	require.Len(t, visited, 15)
+	require.Equal(t, 2, calls)
+	require.False(t, it.Next())
+}
+
+func TestIterator_Error(t *testing.T) {
+	it := NewIterator(func(_ *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		return nil, nil, errors.New("connection refused")
+	})
+
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+}
+
+func TestNestedIterator(t *testing.T) {
+	var outerCalls, innerCalls int
+	it := NewNestedIterator(NewIterator(keyFetcher(3, &outerCalls)), func(item interface{}) *Iterator {
+		return NewIterator(keyFetcher(item.(int)+1, &innerCalls))
+	}).WithPageSize(2)
+
+	items, err := it.All()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{0, 0, 1, 0, 1, 2}, items)
+}
+
+func TestIterator_OffsetCapped(t *testing.T) {
+	// the node returns at most 100 items whatever the page size is
+	fetch := func(pageReq *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		var items []interface{}
+		for i := pageReq.Offset; i < 250 && len(items) < 100 && uint64(len(items)) < pageReq.Limit; i++ {
+			items = append(items, int(i))
+		}
+		return items, &query.PageResponse{Total: 250}, nil
+	}
+
+	items, err := NewOffsetIterator(fetch).WithPageSize(500).All()
+	require.NoError(t, err)
+	require.Len(t, items, 250)
+	require.Equal(t, 249, items[249])
+}
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"errors"
+	"time"
+
 	"github.com/tendermint/tendermint/crypto"
 
 	cdctypes "github.com/irisnet/irishub-sdk-go/codec/types"
@@ -22,12 +25,62 @@ type Module interface {
 	RegisterInterfaceTypes(registry cdctypes.InterfaceRegistry)
 }
 
+// ErrWatchOnly is returned when signing with a watch-only key, whose private key is stored elsewhere
+var ErrWatchOnly = errors.New("watch-only key can't sign")
+
+// ErrTxKeyAlgo is returned when signing a tx with a key the chain doesn't accept, i.e. ed25519, whose keys
+// only sign arbitrary data
+var ErrTxKeyAlgo = errors.New("the chain doesn't accept the txs signed by the key algorithm")
+
 type KeyManager interface {
 	Sign(name, password string, data []byte) ([]byte, crypto.PubKey, error)
 	Insert(name, password string) (string, string, error)
+	// InsertWithAlgo creates a key of the signing algorithm, e.g. secp256k1, sm2 or ed25519, the ed25519 keys
+	// can't sign the txs
+	InsertWithAlgo(name, password, algo string) (string, string, error)
+	// InsertWithOptions creates a key and its mnemonic as specified by the options
+	InsertWithOptions(name, password string, opts KeyOptions) (string, string, error)
 	Recover(name, password, mnemonic, hdPath string) (string, error)
+	// RecoverWithAlgo recovers a key of the signing algorithm from the mnemonic
+	RecoverWithAlgo(name, password, mnemonic, hdPath, algo string) (string, error)
+	// RecoverWithOptions recovers a key from the mnemonic as specified by the options
+	RecoverWithOptions(name, password, mnemonic string, opts KeyOptions) (string, error)
 	Import(name, password string, privKeyArmor string) (address string, err error)
 	Export(name, password string) (privKeyArmor string, err error)
+	// ImportWatchOnly stores a watch-only key of the public key, or of the bech32 address when pubKey is nil,
+	// usable as the From of the unsigned txs
+	ImportWatchOnly(name, password, address string, pubKey crypto.PubKey) (string, error)
 	Delete(name, password string) error
 	Find(name, password string) (crypto.PubKey, AccAddress, error)
+	List() ([]KeyMetadata, error)
+	ShowPublic(name string) (KeyMetadata, error)
+	ChangePassword(name, oldPassword, newPassword string) error
+	Rename(name, newName, password string) error
+}
+
+// KeyMetadata is the public information of a key, readable without the password
+type KeyMetadata struct {
+	Name    string        `json:"name"`
+	Algo    string        `json:"algo"`
+	PubKey  crypto.PubKey `json:"pubkey"`
+	Address AccAddress    `json:"address"`
+	// HDPath is the BIP44 path the key was derived by, empty for the imported keys
+	HDPath string `json:"hd_path,omitempty"`
+	// WatchOnly is true for the keys without private key, the PubKey of a watch-only address is nil
+	WatchOnly bool      `json:"watch_only,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// KeyOptions are the options of a created or recovered key, the zero value creates
+// the key of the algorithm of the client by hd.FullPath from a 24 words mnemonic without BIP39 passphrase
+type KeyOptions struct {
+	// Algo is the signing algorithm, e.g. secp256k1, sm2 or ed25519
+	Algo string
+	// BIP39Passphrase is the optional passphrase of the mnemonic, a.k.a. the 25th word
+	BIP39Passphrase string
+	// MnemonicWords is the number of words of the created mnemonic, 12 or 24
+	MnemonicWords int
+	// HDPath is the BIP44 path of the key, e.g. hd.NewAccountParams(account, index).String(), hd.FullPath by
+	// default or hd.Ed25519FullPath for ed25519, whose indexes must all be hardened
+	HDPath string
 }
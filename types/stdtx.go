@@ -222,8 +222,10 @@ type ResultTx struct {
 
 // ResultQueryTx is used to prepare info to display
 type ResultQueryTx struct {
-	Hash      string   `json:"hash"`
-	Height    int64    `json:"height"`
+	Hash   string `json:"hash"`
+	Height int64  `json:"height"`
+	// Index is the index of the tx in its block
+	Index     uint32   `json:"index"`
 	Tx        Tx       `json:"tx"`
 	Result    TxResult `json:"result"`
 	Timestamp string   `json:"timestamp"`
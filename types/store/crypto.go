@@ -0,0 +1,189 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// CryptoVersion1 derives the key with Argon2id and encrypts with XChaCha20-Poly1305
+	CryptoVersion1 = 1
+
+	versionPrefix = "$v"
+	saltLen       = 16
+	// version, time, memory and threads
+	headerLen = 1 + 4 + 4 + 1
+
+	// Default Argon2id parameters, as recommended by RFC 9106 for memory constrained environments
+	DefaultArgon2Time    uint32 = 3
+	DefaultArgon2Memory  uint32 = 64 * 1024
+	DefaultArgon2Threads uint8  = 4
+
+	// Upper bounds of the Argon2id parameters, the parameters of the stored data are checked
+	// before deriving the key, they are read before the data is authenticated
+	MaxArgon2Time   uint32 = 64
+	MaxArgon2Memory uint32 = 1024 * 1024
+)
+
+var (
+	_ VersionedCrypto = AEAD{}
+
+	// ErrWrongPassword is returned when the data can't be decrypted with the password
+	ErrWrongPassword = errors.New("wrong password")
+	// ErrEmptyPassword is returned when a key with a private key is unlocked without password
+	ErrEmptyPassword = errors.New("password is empty")
+	// ErrUnsupportedVersion is returned when the data was encrypted by an unknown format
+	ErrUnsupportedVersion = errors.New("unsupported encryption version")
+)
+
+// AEAD is the versioned Crypto encrypting with XChaCha20-Poly1305 under a key derived
+// from the password by Argon2id with a random salt. The encrypted data is
+// "$v1$" followed by the base64 of the version, the Argon2id parameters, the salt,
+// the nonce and the sealed data, so the parameters can change without breaking the
+// decryption of the existing data. The zero value uses the default parameters.
+type AEAD struct {
+	Time    uint32
+	Memory  uint32 // in KiB
+	Threads uint8
+}
+
+// Encrypt implements Crypto
+func (a AEAD) Encrypt(text string, password string) (string, error) {
+	t, memory, threads := a.params()
+	if err := checkArgon2Params(t, memory, threads); err != nil {
+		return "", err
+	}
+
+	bz := make([]byte, headerLen+saltLen+chacha20poly1305.NonceSizeX, headerLen+saltLen+chacha20poly1305.NonceSizeX+len(text)+chacha20poly1305.Overhead)
+	bz[0] = CryptoVersion1
+	binary.BigEndian.PutUint32(bz[1:5], t)
+	binary.BigEndian.PutUint32(bz[5:9], memory)
+	bz[9] = threads
+
+	salt := bz[headerLen : headerLen+saltLen]
+	nonce := bz[headerLen+saltLen:]
+	if _, err := io.ReadFull(rand.Reader, bz[headerLen:]); err != nil {
+		return "", err
+	}
+
+	aead, err := chacha20poly1305.NewX(argon2.IDKey([]byte(password), salt, t, memory, threads, chacha20poly1305.KeySize))
+	if err != nil {
+		return "", err
+	}
+
+	// the header is authenticated too, so the parameters can't be tampered with
+	bz = aead.Seal(bz, nonce, []byte(text), bz[:headerLen])
+	return fmt.Sprintf("%s%d$%s", versionPrefix, CryptoVersion1, base64.RawURLEncoding.EncodeToString(bz)), nil
+}
+
+// Decrypt implements Crypto, it returns ErrWrongPassword if the password doesn't match
+func (a AEAD) Decrypt(data string, password string) (string, error) {
+	version, ok := CryptoVersionOf(data)
+	if !ok {
+		return "", fmt.Errorf("%w: not a versioned ciphertext", ErrUnsupportedVersion)
+	}
+	if version != CryptoVersion1 {
+		return "", fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	bz, err := base64.RawURLEncoding.DecodeString(data[strings.LastIndex(data, "$")+1:])
+	if err != nil {
+		return "", err
+	}
+	if len(bz) < headerLen+saltLen+chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead || bz[0] != CryptoVersion1 {
+		return "", errors.New("malformed ciphertext")
+	}
+
+	t := binary.BigEndian.Uint32(bz[1:5])
+	memory := binary.BigEndian.Uint32(bz[5:9])
+	threads := bz[9]
+	if err := checkArgon2Params(t, memory, threads); err != nil {
+		return "", err
+	}
+	salt := bz[headerLen : headerLen+saltLen]
+	nonce := bz[headerLen+saltLen : headerLen+saltLen+chacha20poly1305.NonceSizeX]
+	sealed := bz[headerLen+saltLen+chacha20poly1305.NonceSizeX:]
+
+	aead, err := chacha20poly1305.NewX(argon2.IDKey([]byte(password), salt, t, memory, threads, chacha20poly1305.KeySize))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := aead.Open(nil, nonce, sealed, bz[:headerLen])
+	if err != nil {
+		return "", ErrWrongPassword
+	}
+	return string(plaintext), nil
+}
+
+// NeedsUpgrade reports whether the data was encrypted by another format or other parameters than a's
+func (a AEAD) NeedsUpgrade(data string) bool {
+	version, ok := CryptoVersionOf(data)
+	if !ok || version != CryptoVersion1 {
+		return true
+	}
+
+	bz, err := base64.RawURLEncoding.DecodeString(data[strings.LastIndex(data, "$")+1:])
+	if err != nil || len(bz) < headerLen {
+		return true
+	}
+
+	t, memory, threads := a.params()
+	return binary.BigEndian.Uint32(bz[1:5]) != t ||
+		binary.BigEndian.Uint32(bz[5:9]) != memory ||
+		bz[9] != threads
+}
+
+func (a AEAD) params() (t, memory uint32, threads uint8) {
+	t, memory, threads = a.Time, a.Memory, a.Threads
+	if t == 0 {
+		t = DefaultArgon2Time
+	}
+	if memory == 0 {
+		memory = DefaultArgon2Memory
+	}
+	if threads == 0 {
+		threads = DefaultArgon2Threads
+	}
+	return t, memory, threads
+}
+
+// checkArgon2Params returns an error if a parameter is zero or above its bound,
+// argon2.IDKey panics on a zero time or threads and allocates the memory up front
+func checkArgon2Params(t, memory uint32, threads uint8) error {
+	if t == 0 || t > MaxArgon2Time {
+		return fmt.Errorf("invalid argon2 time %d, must be in [1, %d]", t, MaxArgon2Time)
+	}
+	if memory == 0 || memory > MaxArgon2Memory {
+		return fmt.Errorf("invalid argon2 memory %d KiB, must be in [1, %d]", memory, MaxArgon2Memory)
+	}
+	if threads == 0 {
+		return errors.New("invalid argon2 threads 0")
+	}
+	return nil
+}
+
+// CryptoVersionOf returns the version of the data encrypted by a versioned Crypto,
+// ok is false for the data encrypted by the legacy AES
+func CryptoVersionOf(data string) (version int, ok bool) {
+	if !strings.HasPrefix(data, versionPrefix) {
+		return 0, false
+	}
+
+	end := strings.Index(data[len(versionPrefix):], "$")
+	if end < 0 {
+		return 0, false
+	}
+	if _, err := fmt.Sscanf(data[len(versionPrefix):len(versionPrefix)+end], "%d", &version); err != nil {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/crypto/keys/secp256k1"
+)
+
+// fast parameters, the defaults are too slow for unit tests
+var testAEAD = AEAD{Time: 1, Memory: 1024, Threads: 1}
+
+func TestAEAD(t *testing.T) {
+	encrypted, err := testAEAD.Encrypt("secret", "password")
+	require.NoError(t, err)
+
+	version, ok := CryptoVersionOf(encrypted)
+	require.True(t, ok)
+	require.Equal(t, CryptoVersion1, version)
+	require.False(t, testAEAD.NeedsUpgrade(encrypted))
+	require.True(t, AEAD{}.NeedsUpgrade(encrypted))
+
+	decrypted, err := testAEAD.Decrypt(encrypted, "password")
+	require.NoError(t, err)
+	require.Equal(t, "secret", decrypted)
+
+	// the parameters are read from the data
+	decrypted, err = AEAD{}.Decrypt(encrypted, "password")
+	require.NoError(t, err)
+	require.Equal(t, "secret", decrypted)
+
+	_, err = testAEAD.Decrypt(encrypted, "wrong")
+	require.True(t, errors.Is(err, ErrWrongPassword))
+
+	legacy, err := AES{}.Encrypt("secret", "password")
+	require.NoError(t, err)
+	_, ok = CryptoVersionOf(legacy)
+	require.False(t, ok)
+	require.True(t, testAEAD.NeedsUpgrade(legacy))
+	_, err = testAEAD.Decrypt(legacy, "password")
+	require.True(t, errors.Is(err, ErrUnsupportedVersion))
+
+	_, err = testAEAD.Decrypt("$v2$AAAA", "password")
+	require.True(t, errors.Is(err, ErrUnsupportedVersion))
+
+	_, err = AEAD{Memory: MaxArgon2Memory + 1}.Encrypt("secret", "password")
+	require.Error(t, err)
+}
+
+func TestAEADMalformedParams(t *testing.T) {
+	encrypted, err := testAEAD.Encrypt("secret", "password")
+	require.NoError(t, err)
+
+	prefix := encrypted[:strings.LastIndex(encrypted, "$")+1]
+	bz, err := base64.RawURLEncoding.DecodeString(encrypted[len(prefix):])
+	require.NoError(t, err)
+
+	for name, tamper := range map[string]func(bz []byte){
+		"zero time":    func(bz []byte) { binary.BigEndian.PutUint32(bz[1:5], 0) },
+		"huge time":    func(bz []byte) { binary.BigEndian.PutUint32(bz[1:5], MaxArgon2Time+1) },
+		"zero memory":  func(bz []byte) { binary.BigEndian.PutUint32(bz[5:9], 0) },
+		"huge memory":  func(bz []byte) { binary.BigEndian.PutUint32(bz[5:9], 0xffffffff) },
+		"zero threads": func(bz []byte) { bz[9] = 0 },
+	} {
+		tampered := append([]byte{}, bz...)
+		tamper(tampered)
+		_, err := testAEAD.Decrypt(prefix+base64.RawURLEncoding.EncodeToString(tampered), "password")
+		require.Error(t, err, name)
+		require.False(t, errors.Is(err, ErrWrongPassword), name)
+	}
+}
+
+func TestLevelDBMigration(t *testing.T) {
+	dao, err := NewLevelDB(t.TempDir(), testAEAD)
+	require.NoError(t, err)
+	db := dao.(LevelDBDAO)
+
+	legacy := writeLegacyKey(t, db, "alice", "password")
+	writeLegacyKey(t, db, "bob", "password")
+	require.NoError(t, db.Write("charlie", "password", newKeyInfo("charlie")))
+
+	_, err = db.Read("alice", "wrong")
+	require.True(t, errors.Is(err, ErrWrongPassword))
+
+	// the legacy key is re-encrypted when it is read
+	info, err := db.Read("alice", "password")
+	require.NoError(t, err)
+	require.Equal(t, legacy.PrivKeyArmor, info.PrivKeyArmor)
+
+	stored, err := db.ReadMetadata("alice")
+	require.NoError(t, err)
+	require.False(t, testAEAD.NeedsUpgrade(stored.PrivKeyArmor))
+
+	// bob is the only key left to re-encrypt
+	reEncrypted, err := db.ReEncrypt(func(name string) (string, error) {
+		return "password", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"bob"}, reEncrypted)
+
+	// a stronger configuration re-encrypts all the keys
+	upgraded := LevelDBDAO{db: db.db, Crypto: AEAD{Time: 2, Memory: 1024, Threads: 1}}
+	reEncrypted, err = upgraded.ReEncrypt(func(name string) (string, error) {
+		return "password", nil
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"alice", "bob", "charlie"}, reEncrypted)
+
+	info, err = upgraded.Read("bob", "password")
+	require.NoError(t, err)
+	_, err = cryptoamino.PrivKeyFromBytes([]byte(info.PrivKeyArmor))
+	require.NoError(t, err)
+}
+
+func newKeyInfo(name string) KeyInfo {
+	priv := secp256k1.GenPrivKey()
+	return KeyInfo{
+		Name:         name,
+		PubKey:       cryptoamino.MarshalPubkey(priv.PubKey()),
+		PrivKeyArmor: string(cryptoamino.MarshalPrivKey(priv)),
+		Algo:         "secp256k1",
+	}
+}
+
+// writeLegacyKey writes a key encrypted by the legacy AES, as the previous versions did
+func writeLegacyKey(t *testing.T, db LevelDBDAO, name, password string) KeyInfo {
+	info := newKeyInfo(name)
+	encrypted, err := AES{}.Encrypt(info.PrivKeyArmor, password)
+	require.NoError(t, err)
+
+	stored := info
+	stored.PrivKeyArmor = encrypted
+	bz, err := json.Marshal(stored)
+	require.NoError(t, err)
+	require.NoError(t, db.db.SetSync(infoKey(name), bz))
+	return info
+}
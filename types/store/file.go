@@ -1,194 +1,396 @@
 package store
 
-//
-//import (
-//	"encoding/json"
-//	"fmt"
-//	"io/ioutil"
-//	"os"
-//	"path/filepath"
-//	"strings"
-//	"time"
-//
-//	"github.com/99designs/keyring"
-//	jose "github.com/dvsekhvalnov/jose2go"
-//	"github.com/mitchellh/go-homedir"
-//	"github.com/mtibben/percent"
-//	"github.com/pkg/errors"
-//
-//	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
-//	"github.com/irisnet/irishub-sdk-go/crypto/hd"
-//)
-//
-//const (
-//	keyringFileDirName = "keyring-file"
-//)
-//
-//var (
-//	_ KeyDAO = FileDAO{}
-//
-//	filenameEscape = func(s string) string {
-//		return percent.Encode(s, "/")
-//	}
-//)
-//
-////Execute the local file system to realize the persistence of the key data, and the stored data is encrypted using `PBES2`.
-////Can directly read the data of `iris` keys (--keyring-backend = file)
-//type FileDAO struct {
-//	dir string
-//}
-//
-//func NewFileDAO(dir string) KeyDAO {
-//	fileDir := filepath.Join(dir, keyringFileDirName)
-//	return &FileDAO{dir: fileDir}
-//}
-//
-//// Write will use user password to encrypt data and save to file, the file name is user name
-//func (f FileDAO) Write(name, password string, info KeyInfo) error {
-//	pubkey, err := PubKeyFromBytes(info.PubKey)
-//	if err != nil {
-//		return err
-//	}
-//
-//	lInfo := localInfo{
-//		Name:         info.Name,
-//		PubKey:       pubkey,
-//		PrivKeyArmor: info.PrivKeyArmor,
-//		Algo:         hd.PubKeyType(info.Algo),
-//	}
-//
-//	item := keyring.Item{
-//		Key:  name,
-//		Data: marshalInfo(lInfo),
-//	}
-//
-//	bytes, err := json.Marshal(item)
-//	if err != nil {
-//		return err
-//	}
-//
-//	token, err := jose.Encrypt(
-//		string(bytes), jose.PBES2_HS256_A128KW, jose.A256GCM, password,
-//		jose.Headers(map[string]interface{}{"created": time.Now().String()}),
-//	)
-//	if err != nil {
-//		return err
-//	}
-//
-//	filename, err := f.filename(item.Key)
-//	if err != nil {
-//		return err
-//	}
-//
-//	return ioutil.WriteFile(filename, []byte(token), 0600)
-//}
-//
-//// Read will read encrypted data from file and decrypt with user password
-//func (f FileDAO) Read(name, password string) (KeyInfo, error) {
-//	filename, err := f.filename(name)
-//	if err != nil {
-//		return KeyInfo{}, err
-//	}
-//
-//	if len(password) == 0 {
-//		return KeyInfo{}, fmt.Errorf("no password")
-//	}
-//
-//	bytes, err := ioutil.ReadFile(filename)
-//	if err != nil {
-//		return KeyInfo{}, errors.Wrap(err, "not found")
-//	}
-//
-//	payload, _, err := jose.Decode(string(bytes), password)
-//	if err != nil {
-//		return KeyInfo{}, err
-//	}
-//
-//	var decoded keyring.Item
-//	err = json.Unmarshal([]byte(payload), &decoded)
-//	if err != nil {
-//		return KeyInfo{}, err
-//	}
-//
-//	info, err := unmarshalInfo(decoded.Data)
-//	if err != nil {
-//		return KeyInfo{}, err
-//	}
-//
-//	i, ok := info.(localInfo)
-//	if !ok {
-//		return KeyInfo{}, fmt.Errorf("only support type KeyInfo")
-//	}
-//
-//	return KeyInfo{
-//		Name:         i.Name,
-//		PubKey:       cryptoamino.MarshalPubkey(i.PubKey),
-//		PrivKeyArmor: i.PrivKeyArmor,
-//		Algo:         string(i.Algo),
-//	}, nil
-//}
-//
-//// Delete will delete user data and use user password to verify permissions
-//func (f FileDAO) Delete(name, password string) error {
-//	//Perform security verification
-//	if _, err := f.Read(name, password); err != nil {
-//		return err
-//	}
-//
-//	filename, err := f.filename(name)
-//	if err != nil {
-//		return err
-//	}
-//
-//	return os.Remove(filename)
-//}
-//
-//// Has returns whether the specified user name exists
-//func (f FileDAO) Has(name string) bool {
-//	filename, err := f.filename(name)
-//	if err != nil {
-//		return false
-//	}
-//	if _, err = os.Stat(filename); err == nil {
-//		return true
-//	}
-//	if os.IsNotExist(err) {
-//		return false
-//	}
-//	return false
-//}
-//
-//func (f *FileDAO) filename(key string) (string, error) {
-//	dir, err := f.resolveDir()
-//	if err != nil {
-//		return "", err
-//	}
-//
-//	return filepath.Join(dir, filenameEscape(string(infoKey(key)))), nil
-//}
-//
-//func (f *FileDAO) resolveDir() (string, error) {
-//	if f.dir == "" {
-//		return "", fmt.Errorf("no directory provided for file keyring")
-//	}
-//
-//	dir := f.dir
-//
-//	// expand tilde for home directory
-//	if strings.HasPrefix(dir, "~") {
-//		home, err := homedir.Dir()
-//		if err != nil {
-//			return "", err
-//		}
-//		dir = strings.Replace(dir, "~", home, 1)
-//	}
-//
-//	stat, err := os.Stat(dir)
-//	if os.IsNotExist(err) {
-//		err = os.MkdirAll(dir, 0700)
-//	} else if err != nil && !stat.IsDir() {
-//		err = fmt.Errorf("%s is a file, not a directory", dir)
-//	}
-//
-//	return dir, err
-//}
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/99designs/keyring"
+	jose "github.com/dvsekhvalnov/jose2go"
+	"github.com/mitchellh/go-homedir"
+	"github.com/mtibben/percent"
+	"golang.org/x/crypto/bcrypt"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+	"github.com/irisnet/irishub-sdk-go/crypto/hd"
+	"github.com/irisnet/irishub-sdk-go/utils/fileutil"
+)
+
+const (
+	keyringFileDirName = "keyring-file"
+	addressSuffix      = "address"
+	// keyhashFilename is the file of the bcrypt hash of the keyring passphrase, as written by the iris CLI
+	keyhashFilename = "keyhash"
+
+	// layout of time.Time.String, used by the keyring for the created header
+	createdLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+var (
+	_ KeyDAO = FileDAO{}
+
+	// ErrPasswordRequired is returned by FileDAO.ReadMetadata, the keyring-file backend encrypts the whole key information.
+	// It is an ErrUnsupported.
+	ErrPasswordRequired = fmt.Errorf("%w: the keyring-file backend can't read a key without the password", ErrUnsupported)
+
+	filenameEscape = func(s string) string {
+		return percent.Encode(s, "/")
+	}
+	filenameUnescape = func(s string) string {
+		return percent.Decode(s)
+	}
+)
+
+// Execute the local file system to realize the persistence of the key data, and the stored data is encrypted using `PBES2`.
+// Can directly read and write the data of `iris keys` (--keyring-backend = file): every key is a `<name>.info`
+// file holding the amino encoded localInfo, indexed by a `<hex address>.address` file.
+//
+// The iris CLI encrypts all the keys of the directory with the same keyring passphrase, so Write requires that
+// passphrase, verified by the keyhash file of the directory, as password.
+//
+// The key information is encrypted as a whole, so ReadMetadata fails with ErrPasswordRequired, and the List,
+// ShowPublic, Backup and Discover of the keys client, which read the keys without password, are unsupported.
+type FileDAO struct {
+	dir string
+}
+
+// NewFileDAO returns the KeyDAO of the keyring-file directory under dir, e.g. the home directory of the iris CLI
+func NewFileDAO(dir string) KeyDAO {
+	fileDir := filepath.Join(dir, keyringFileDirName)
+	return FileDAO{dir: fileDir}
+}
+
+// Write will use user password to encrypt data and save to file, the file name is user name.
+// The password must be the keyring passphrase of the directory, it becomes the passphrase of a new directory.
+func (f FileDAO) Write(name, password string, info KeyInfo) error {
+	if f.Has(name) {
+		return fmt.Errorf("name %s has exist", name)
+	}
+	if err := f.checkKeyhash(password); err != nil {
+		return err
+	}
+
+	if info.CreatedAt.IsZero() {
+		info.CreatedAt = time.Now()
+	}
+	return f.write(name, password, info)
+}
+
+// Read will read encrypted data from file and decrypt with user password
+func (f FileDAO) Read(name, password string) (KeyInfo, error) {
+	item, created, err := f.readItem(string(infoKey(name)), password)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+
+	info, err := unmarshalInfo(item.Data)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+
+	if i, ok := info.(offlineInfo); ok {
+		return KeyInfo{
+			Name:      i.Name,
+			PubKey:    cryptoamino.MarshalPubkey(i.PubKey),
+			Algo:      string(i.Algo),
+			CreatedAt: created,
+		}, nil
+	}
+
+	i, ok := info.(localInfo)
+	if !ok {
+		return KeyInfo{}, fmt.Errorf("only support type KeyInfo")
+	}
+
+	return KeyInfo{
+		Name:         i.Name,
+		PubKey:       cryptoamino.MarshalPubkey(i.PubKey),
+		PrivKeyArmor: i.PrivKeyArmor,
+		Algo:         string(i.Algo),
+		CreatedAt:    created,
+	}, nil
+}
+
+// ReadMetadata always fails with ErrPasswordRequired, the key information can't be read without the password
+func (f FileDAO) ReadMetadata(name string) (KeyInfo, error) {
+	return KeyInfo{}, ErrPasswordRequired
+}
+
+// Delete will delete user data and use user password to verify permissions
+func (f FileDAO) Delete(name, password string) error {
+	//Perform security verification
+	info, err := f.Read(name, password)
+	if err != nil {
+		return err
+	}
+
+	if err := f.remove(string(infoKey(name))); err != nil {
+		return err
+	}
+
+	addrKey, err := addressKey(info)
+	if err != nil {
+		return err
+	}
+	if err := f.remove(addrKey); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Has returns whether the specified user name exists
+func (f FileDAO) Has(name string) bool {
+	filename, err := f.filename(string(infoKey(name)))
+	if err != nil {
+		return false
+	}
+	if _, err = os.Stat(filename); err == nil {
+		return true
+	}
+	return false
+}
+
+// List returns the names of all the keys of the directory
+func (f FileDAO) List() ([]string, error) {
+	dir, err := f.resolveDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		key := filenameUnescape(file.Name())
+		if !file.IsDir() && strings.HasSuffix(key, "."+infoSuffix) {
+			names = append(names, strings.TrimSuffix(key, "."+infoSuffix))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ChangePassword is unsupported, the iris CLI encrypts all the keys of the directory with the keyring passphrase
+// of the keyhash, so a key re-encrypted with another password would be unreadable by the CLI
+func (f FileDAO) ChangePassword(name, oldPassword, newPassword string) error {
+	return fmt.Errorf("%w: the keyring-file backend uses a single passphrase for the directory", ErrUnsupported)
+}
+
+// Rename writes the key under the new name, then removes the old one
+func (f FileDAO) Rename(name, newName, password string) error {
+	if f.Has(newName) {
+		return fmt.Errorf("name %s has exist", newName)
+	}
+
+	info, err := f.Read(name, password)
+	if err != nil {
+		return err
+	}
+
+	info.Name = newName
+	if err := f.write(newName, password, info); err != nil {
+		return err
+	}
+	return f.remove(string(infoKey(name)))
+}
+
+// write writes the key and its address index, encrypted with the password, the watch-only keys
+// as the offline keys of the iris CLI
+func (f FileDAO) write(name, password string, info KeyInfo) error {
+	if info.WatchOnly() && len(info.PubKey) == 0 {
+		return fmt.Errorf("the keyring-file only stores the watch-only keys by public key")
+	}
+
+	pubkey, err := PubKeyFromBytes(info.PubKey)
+	if err != nil {
+		return err
+	}
+
+	var i Info = localInfo{
+		Name:         name,
+		PubKey:       pubkey,
+		PrivKeyArmor: info.PrivKeyArmor,
+		Algo:         hd.PubKeyType(info.Algo),
+	}
+	if info.WatchOnly() {
+		i = offlineInfo{Name: name, PubKey: pubkey, Algo: hd.PubKeyType(info.Algo)}
+	}
+
+	key := string(infoKey(name))
+	if err := f.writeItem(keyring.Item{Key: key, Data: marshalInfo(i)}, password, info.CreatedAt); err != nil {
+		return err
+	}
+
+	addrKey, err := addressKey(info)
+	if err != nil {
+		return err
+	}
+	return f.writeItem(keyring.Item{Key: addrKey, Data: []byte(key)}, password, info.CreatedAt)
+}
+
+func (f FileDAO) writeItem(item keyring.Item, password string, created time.Time) error {
+	if len(password) == 0 {
+		return fmt.Errorf("no password")
+	}
+
+	bytes, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	token, err := jose.Encrypt(
+		string(bytes), jose.PBES2_HS256_A128KW, jose.A256GCM, password,
+		jose.Headers(map[string]interface{}{"created": created.String()}),
+	)
+	if err != nil {
+		return err
+	}
+
+	filename, err := f.filename(item.Key)
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(filename, []byte(token), 0600)
+}
+
+// checkKeyhash verifies the password against the keyhash of the directory, the keyhash is written
+// from the password when the directory has none, as the iris CLI does for the first key
+func (f FileDAO) checkKeyhash(password string) error {
+	if len(password) == 0 {
+		return fmt.Errorf("no password")
+	}
+
+	filename, err := f.filename(keyhashFilename)
+	if err != nil {
+		return err
+	}
+
+	hash, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		return fileutil.WriteFileAtomic(filename, hash, 0600)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword(bytes.TrimSpace(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return fmt.Errorf("the password isn't the keyring passphrase: %w", ErrWrongPassword)
+	}
+	return err
+}
+
+func (f FileDAO) readItem(key, password string) (keyring.Item, time.Time, error) {
+	filename, err := f.filename(key)
+	if err != nil {
+		return keyring.Item{}, time.Time{}, err
+	}
+
+	if len(password) == 0 {
+		return keyring.Item{}, time.Time{}, fmt.Errorf("no password")
+	}
+
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return keyring.Item{}, time.Time{}, fmt.Errorf("%s not found: %w", key, err)
+	}
+
+	payload, headers, err := jose.Decode(string(bytes), password)
+	if err != nil {
+		if strings.Contains(err.Error(), "integrity check failed") {
+			return keyring.Item{}, time.Time{}, ErrWrongPassword
+		}
+		return keyring.Item{}, time.Time{}, err
+	}
+
+	var decoded keyring.Item
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		return keyring.Item{}, time.Time{}, err
+	}
+	return decoded, parseCreated(headers), nil
+}
+
+func (f FileDAO) remove(key string) error {
+	filename, err := f.filename(key)
+	if err != nil {
+		return err
+	}
+	return os.Remove(filename)
+}
+
+func (f FileDAO) filename(key string) (string, error) {
+	dir, err := f.resolveDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filenameEscape(key)), nil
+}
+
+func (f FileDAO) resolveDir() (string, error) {
+	if f.dir == "" {
+		return "", fmt.Errorf("no directory provided for file keyring")
+	}
+
+	dir := f.dir
+
+	// expand tilde for home directory
+	if strings.HasPrefix(dir, "~") {
+		home, err := homedir.Dir()
+		if err != nil {
+			return "", err
+		}
+		dir = strings.Replace(dir, "~", home, 1)
+	}
+
+	stat, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		err = os.MkdirAll(dir, 0700)
+	} else if err == nil && !stat.IsDir() {
+		err = fmt.Errorf("%s is a file, not a directory", dir)
+	}
+
+	return dir, err
+}
+
+// addressKey returns the key of the address index of the key, as written by the iris CLI
+func addressKey(info KeyInfo) (string, error) {
+	pubkey, err := PubKeyFromBytes(info.PubKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.%s", hex.EncodeToString(pubkey.Address().Bytes()), addressSuffix), nil
+}
+
+// parseCreated parses the created header of the keyring, written by time.Time.String
+func parseCreated(headers map[string]interface{}) time.Time {
+	created, ok := headers["created"].(string)
+	if !ok {
+		return time.Time{}
+	}
+
+	// drop the monotonic clock reading
+	if i := strings.Index(created, " m="); i >= 0 {
+		created = created[:i]
+	}
+	t, err := time.Parse(createdLayout, created)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/99designs/keyring"
+	"github.com/stretchr/testify/require"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
+)
+
+// testdata/keyring-file holds the key "alice" written by the keyring-file backend of the iris CLI,
+// with the keyring passphrase "12345678"
+const fixturePassword = "12345678"
+
+func TestFileDAOFixture(t *testing.T) {
+	dao := NewFileDAO(copyFixture(t))
+
+	names, err := dao.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice"}, names)
+	require.True(t, dao.Has("alice"))
+
+	info, err := dao.Read("alice", fixturePassword)
+	require.NoError(t, err)
+	require.Equal(t, "alice", info.Name)
+	require.Equal(t, "secp256k1", info.Algo)
+	require.False(t, info.CreatedAt.IsZero())
+
+	pubKey, err := cryptoamino.PubKeyFromBytes(info.PubKey)
+	require.NoError(t, err)
+	// iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z
+	require.Equal(t, "216CD2F09EED413905E2A1246A4AE3B3144C36C2", pubKey.Address().String())
+
+	privKey, err := cryptoamino.PrivKeyFromBytes([]byte(info.PrivKeyArmor))
+	require.NoError(t, err)
+	require.Equal(t, pubKey, privKey.PubKey())
+
+	_, err = dao.Read("alice", "wrong password")
+	require.True(t, errors.Is(err, ErrWrongPassword))
+
+	_, err = dao.ReadMetadata("alice")
+	require.True(t, errors.Is(err, ErrPasswordRequired))
+	require.True(t, errors.Is(err, ErrUnsupported))
+}
+
+func TestFileDAOKeyhash(t *testing.T) {
+	// the password of the keys written to the directory of the CLI must be its keyring passphrase
+	dao := NewFileDAO(copyFixture(t))
+	require.True(t, errors.Is(dao.Write("bob", "87654321", newKeyInfo("bob")), ErrWrongPassword))
+	require.False(t, dao.Has("bob"))
+	require.NoError(t, dao.Write("bob", fixturePassword, newKeyInfo("bob")))
+
+	// the first key of a new directory sets its keyring passphrase
+	dir := t.TempDir()
+	dao = NewFileDAO(dir)
+	require.NoError(t, dao.Write("bob", "87654321", newKeyInfo("bob")))
+	_, err := os.Stat(filepath.Join(dir, keyringFileDirName, keyhashFilename))
+	require.NoError(t, err)
+	require.True(t, errors.Is(dao.Write("carol", fixturePassword, newKeyInfo("carol")), ErrWrongPassword))
+	require.NoError(t, dao.Write("carol", "87654321", newKeyInfo("carol")))
+
+	names, err := dao.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"bob", "carol"}, names)
+}
+
+func TestFileDAOCompatibility(t *testing.T) {
+	dir := copyFixture(t)
+	dao := NewFileDAO(dir)
+
+	bob := newKeyInfo("bob")
+	require.NoError(t, dao.Write("bob", fixturePassword, bob))
+	require.Error(t, dao.Write("bob", fixturePassword, bob))
+
+	// the key is readable by the keyring of the CLI
+	kr := openKeyring(t, dir)
+	item, err := kr.Get("bob.info")
+	require.NoError(t, err)
+	decoded, err := unmarshalInfo(item.Data)
+	require.NoError(t, err)
+	require.Equal(t, "bob", decoded.GetName())
+	require.Equal(t, bob.PubKey, cryptoamino.MarshalPubkey(decoded.GetPubKey()))
+
+	addrKey := hexAddress(t, bob) + ".address"
+	item, err = kr.Get(addrKey)
+	require.NoError(t, err)
+	require.Equal(t, "bob.info", string(item.Data))
+
+	// rename
+	require.NoError(t, dao.Rename("bob", "carol", fixturePassword))
+	require.False(t, dao.Has("bob"))
+	item, err = openKeyring(t, dir).Get(addrKey)
+	require.NoError(t, err)
+	require.Equal(t, "carol.info", string(item.Data))
+
+	names, err := dao.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "carol"}, names)
+
+	// the password of a single key can't change, the directory keeps a single passphrase
+	require.True(t, errors.Is(dao.ChangePassword("carol", fixturePassword, "87654321"), ErrUnsupported))
+	for _, name := range names {
+		item, err := openKeyring(t, dir).Get(name + ".info")
+		require.NoError(t, err, name)
+		decoded, err := unmarshalInfo(item.Data)
+		require.NoError(t, err)
+		require.Equal(t, name, decoded.GetName())
+	}
+	info, err := dao.Read("carol", fixturePassword)
+	require.NoError(t, err)
+	require.Equal(t, bob.PrivKeyArmor, info.PrivKeyArmor)
+
+	// delete removes the address index too
+	require.NoError(t, dao.Delete("carol", fixturePassword))
+	require.False(t, dao.Has("carol"))
+	_, err = os.Stat(filepath.Join(dir, keyringFileDirName, addrKey))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileDAOWatchOnly(t *testing.T) {
+	dir := copyFixture(t)
+	dao := NewFileDAO(dir)
+
+	bob := newKeyInfo("bob")
+	bob.PrivKeyArmor = ""
+	require.NoError(t, dao.Write("bob", fixturePassword, bob))
+
+	// the watch-only keys are the offline keys of the CLI
+	item, err := openKeyring(t, dir).Get("bob.info")
+	require.NoError(t, err)
+	decoded, err := unmarshalInfo(item.Data)
+	require.NoError(t, err)
+	require.Equal(t, TypeOffline, decoded.GetType())
+
+	info, err := dao.Read("bob", fixturePassword)
+	require.NoError(t, err)
+	require.True(t, info.WatchOnly())
+	require.Equal(t, bob.PubKey, info.PubKey)
+
+	require.Error(t, dao.Write("carol", fixturePassword, KeyInfo{Name: "carol", Address: "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z"}))
+}
+
+func copyFixture(t *testing.T) string {
+	dir := t.TempDir()
+	src := filepath.Join("testdata", keyringFileDirName)
+	dst := filepath.Join(dir, keyringFileDirName)
+	require.NoError(t, os.Mkdir(dst, 0700))
+
+	files, err := ioutil.ReadDir(src)
+	require.NoError(t, err)
+	for _, file := range files {
+		bz, err := ioutil.ReadFile(filepath.Join(src, file.Name()))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dst, file.Name()), bz, 0600))
+	}
+	return dir
+}
+
+func openKeyring(t *testing.T, dir string) keyring.Keyring {
+	kr, err := keyring.Open(keyring.Config{
+		AllowedBackends: []keyring.BackendType{keyring.FileBackend},
+		FileDir:         filepath.Join(dir, keyringFileDirName),
+		FilePasswordFunc: func(string) (string, error) {
+			return fixturePassword, nil
+		},
+	})
+	require.NoError(t, err)
+	return kr
+}
+
+func hexAddress(t *testing.T, info KeyInfo) string {
+	key, err := addressKey(info)
+	require.NoError(t, err)
+	return key[:len(key)-len(".address")]
+}
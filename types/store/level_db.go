@@ -1,11 +1,16 @@
 package store
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	dbm "github.com/tendermint/tm-db"
+
+	cryptoamino "github.com/irisnet/irishub-sdk-go/crypto/codec"
 )
 
 const (
@@ -31,13 +36,16 @@ func NewLevelDB(rootDir string, crypto Crypto) (KeyDAO, error) {
 	}
 
 	if crypto == nil {
-		crypto = AES{}
+		crypto = AEAD{}
 	}
 
 	levelDB := LevelDBDAO{
 		db:     db,
 		Crypto: crypto,
 	}
+	if _, err := levelDB.MigrateMetadata(); err != nil {
+		return nil, err
+	}
 	return levelDB, nil
 }
 
@@ -47,18 +55,19 @@ func (k LevelDBDAO) Write(name, password string, info KeyInfo) error {
 		return fmt.Errorf("name %s has exist", name)
 	}
 
-	privStr, err := k.Encrypt(info.PrivKeyArmor, password)
-	if err != nil {
-		return err
+	// the watch-only keys have nothing to encrypt
+	if !info.WatchOnly() {
+		privStr, err := k.Encrypt(info.PrivKeyArmor, password)
+		if err != nil {
+			return err
+		}
+		info.PrivKeyArmor = privStr
 	}
 
-	info.PrivKeyArmor = privStr
-
-	bz, err := json.Marshal(info)
-	if err != nil {
-		return err
+	if info.CreatedAt.IsZero() {
+		info.CreatedAt = time.Now().UTC()
 	}
-	return k.db.SetSync(infoKey(name), bz)
+	return k.write(name, info)
 }
 
 // Read read a key information from the local store
@@ -72,8 +81,8 @@ func (k LevelDBDAO) Read(name, password string) (store KeyInfo, err error) {
 		return store, err
 	}
 
-	if len(password) > 0 {
-		privStr, err := k.Decrypt(store.PrivKeyArmor, password)
+	if len(password) > 0 && !store.WatchOnly() {
+		privStr, err := k.decrypt(name, store, password)
 		if err != nil {
 			return store, err
 		}
@@ -82,6 +91,102 @@ func (k LevelDBDAO) Read(name, password string) (store KeyInfo, err error) {
 	return
 }
 
+// ReEncrypt re-encrypts every key of the store in the current format of its Crypto,
+// password returns the password of the key of the name. It returns the names of
+// the re-encrypted keys, the keys already in the current format are skipped.
+func (k LevelDBDAO) ReEncrypt(password func(name string) (string, error)) ([]string, error) {
+	names, err := k.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var reEncrypted []string
+	for _, name := range names {
+		store, err := k.ReadMetadata(name)
+		if err != nil {
+			return reEncrypted, err
+		}
+		if !k.needsUpgrade(store.PrivKeyArmor) {
+			continue
+		}
+
+		pwd, err := password(name)
+		if err != nil {
+			return reEncrypted, fmt.Errorf("get the password of %s: %w", name, err)
+		}
+		if _, err := k.Read(name, pwd); err != nil {
+			return reEncrypted, fmt.Errorf("re-encrypt %s: %w", name, err)
+		}
+		reEncrypted = append(reEncrypted, name)
+	}
+	return reEncrypted, nil
+}
+
+// decrypt decrypts the private key of the stored information, the keys encrypted by the legacy AES
+// or by an older format of the Crypto are transparently re-encrypted in the current format
+func (k LevelDBDAO) decrypt(name string, store KeyInfo, password string) (string, error) {
+	if !k.needsUpgrade(store.PrivKeyArmor) {
+		return k.Decrypt(store.PrivKeyArmor, password)
+	}
+
+	var (
+		privStr string
+		err     error
+	)
+	if _, versioned := CryptoVersionOf(store.PrivKeyArmor); versioned {
+		privStr, err = k.Decrypt(store.PrivKeyArmor, password)
+	} else {
+		privStr, err = decryptLegacy(store, password)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := k.Encrypt(privStr, password)
+	if err != nil {
+		return "", err
+	}
+	store.PrivKeyArmor = encrypted
+	if err := k.write(name, store); err != nil {
+		return "", err
+	}
+	return privStr, nil
+}
+
+func (k LevelDBDAO) write(name string, store KeyInfo) error {
+	bz, err := json.Marshal(store)
+	if err != nil {
+		return err
+	}
+	return k.db.SetSync(infoKey(name), bz)
+}
+
+func (k LevelDBDAO) needsUpgrade(data string) bool {
+	// the watch-only keys
+	if len(data) == 0 {
+		return false
+	}
+	if crypto, ok := k.Crypto.(VersionedCrypto); ok {
+		return crypto.NeedsUpgrade(data)
+	}
+	return false
+}
+
+// decryptLegacy decrypts a private key encrypted by the legacy AES, which can't detect a wrong password
+// by itself, so the decrypted key must match the stored public key
+func decryptLegacy(store KeyInfo, password string) (string, error) {
+	privStr, err := AES{}.Decrypt(store.PrivKeyArmor, password)
+	if err != nil {
+		return "", err
+	}
+
+	privKey, err := cryptoamino.PrivKeyFromBytes([]byte(privStr))
+	if err != nil || !bytes.Equal(cryptoamino.MarshalPubkey(privKey.PubKey()), store.PubKey) {
+		return "", ErrWrongPassword
+	}
+	return privStr, nil
+}
+
 // ReadMetadata read a key information from the local store
 func (k LevelDBDAO) ReadMetadata(name string) (store KeyInfo, err error) {
 	bz, err := k.db.Get(infoKey(name))
@@ -97,14 +202,140 @@ func (k LevelDBDAO) ReadMetadata(name string) (store KeyInfo, err error) {
 
 // Delete delete a key from the local store
 func (k LevelDBDAO) Delete(name, password string) error {
-	_, err := k.Read(name, password)
-	if err != nil {
+	if _, err := k.unlock(name, password); err != nil {
 		return err
 	}
 	return k.db.DeleteSync(infoKey(name))
 }
 
-// Delete delete a key from the local store
+// unlock reads the key and decrypts its private key, unlike Read it requires the password
+// of a key with a private key, so an empty password can't skip the verification
+func (k LevelDBDAO) unlock(name, password string) (KeyInfo, error) {
+	store, err := k.ReadMetadata(name)
+	if err != nil {
+		return store, err
+	}
+	if len(store.PubKey) == 0 && len(store.Address) == 0 {
+		return store, fmt.Errorf("name %s not exist", name)
+	}
+	if store.WatchOnly() {
+		return store, nil
+	}
+	if len(password) == 0 {
+		return store, ErrEmptyPassword
+	}
+
+	privStr, err := k.decrypt(name, store, password)
+	if err != nil {
+		return store, err
+	}
+	store.PrivKeyArmor = privStr
+	return store, nil
+}
+
+// List returns the names of all the keys of the local store
+func (k LevelDBDAO) List() ([]string, error) {
+	it, err := k.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var names []string
+	for ; it.Valid(); it.Next() {
+		key := string(it.Key())
+		if strings.HasSuffix(key, "."+infoSuffix) {
+			names = append(names, strings.TrimSuffix(key, "."+infoSuffix))
+		}
+	}
+	return names, it.Error()
+}
+
+// ChangePassword re-encrypts the private key with the new password
+func (k LevelDBDAO) ChangePassword(name, oldPassword, newPassword string) error {
+	if len(newPassword) == 0 {
+		return fmt.Errorf("new password is empty")
+	}
+
+	store, err := k.unlock(name, oldPassword)
+	if err != nil {
+		return err
+	}
+	if store.WatchOnly() {
+		return nil
+	}
+
+	privStr, err := k.Encrypt(store.PrivKeyArmor, newPassword)
+	if err != nil {
+		return err
+	}
+	store.PrivKeyArmor = privStr
+	return k.write(name, store)
+}
+
+// Rename renames a key of the local store
+func (k LevelDBDAO) Rename(name, newName, password string) error {
+	if k.Has(newName) {
+		return fmt.Errorf("name %s has exist", newName)
+	}
+
+	// verify the password
+	if _, err := k.unlock(name, password); err != nil {
+		return err
+	}
+
+	// the stored armor is kept, it may have been re-encrypted by the verification
+	store, err := k.ReadMetadata(name)
+	if err != nil {
+		return err
+	}
+	store.Name = newName
+
+	bz, err := json.Marshal(store)
+	if err != nil {
+		return err
+	}
+
+	batch := k.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(infoKey(newName), bz); err != nil {
+		return err
+	}
+	if err := batch.Delete(infoKey(name)); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+// MigrateMetadata sets the creation time of the keys written by the previous versions, which didn't
+// record it, to the current time. It returns the names of the migrated keys.
+func (k LevelDBDAO) MigrateMetadata() ([]string, error) {
+	names, err := k.List()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var migrated []string
+	for _, name := range names {
+		store, err := k.ReadMetadata(name)
+		if err != nil {
+			return migrated, err
+		}
+		if !store.CreatedAt.IsZero() {
+			continue
+		}
+
+		store.CreatedAt = now
+		if err := k.write(name, store); err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, name)
+	}
+	return migrated, nil
+}
+
+// Has returns whether the specified user name exists
 func (k LevelDBDAO) Has(name string) bool {
 	existed, err := k.db.Has(infoKey(name))
 	if err != nil {
@@ -0,0 +1,141 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelDBKeys(t *testing.T) {
+	dao, err := NewLevelDB(t.TempDir(), testAEAD)
+	require.NoError(t, err)
+
+	alice := newKeyInfo("alice")
+	require.NoError(t, dao.Write("bob", "password", newKeyInfo("bob")))
+	require.NoError(t, dao.Write("alice", "password", alice))
+
+	names, err := dao.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "bob"}, names)
+
+	metadata, err := dao.ReadMetadata("alice")
+	require.NoError(t, err)
+	require.Equal(t, alice.PubKey, metadata.PubKey)
+	require.False(t, metadata.CreatedAt.IsZero())
+
+	// change the password
+	require.True(t, errors.Is(dao.ChangePassword("alice", "wrong", "new password"), ErrWrongPassword))
+	require.True(t, errors.Is(dao.ChangePassword("alice", "", "new password"), ErrEmptyPassword))
+	require.Error(t, dao.ChangePassword("dave", "password", "new password"))
+	info, err := dao.Read("alice", "password")
+	require.NoError(t, err)
+	require.Equal(t, alice.PrivKeyArmor, info.PrivKeyArmor)
+
+	require.NoError(t, dao.ChangePassword("alice", "password", "new password"))
+	_, err = dao.Read("alice", "password")
+	require.True(t, errors.Is(err, ErrWrongPassword))
+	info, err = dao.Read("alice", "new password")
+	require.NoError(t, err)
+	require.Equal(t, alice.PrivKeyArmor, info.PrivKeyArmor)
+	require.Equal(t, metadata.CreatedAt, info.CreatedAt)
+
+	// rename
+	require.Error(t, dao.Rename("alice", "bob", "new password"))
+	require.True(t, errors.Is(dao.Rename("alice", "carol", "password"), ErrWrongPassword))
+	require.True(t, errors.Is(dao.Rename("alice", "carol", ""), ErrEmptyPassword))
+	require.Error(t, dao.Rename("dave", "erin", "password"))
+	require.NoError(t, dao.Rename("alice", "carol", "new password"))
+	require.False(t, dao.Has("alice"))
+
+	info, err = dao.Read("carol", "new password")
+	require.NoError(t, err)
+	require.Equal(t, "carol", info.Name)
+	require.Equal(t, alice.PrivKeyArmor, info.PrivKeyArmor)
+
+	names, err = dao.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"bob", "carol"}, names)
+
+	// delete
+	require.True(t, errors.Is(dao.Delete("carol", ""), ErrEmptyPassword))
+	require.True(t, errors.Is(dao.Delete("carol", "password"), ErrWrongPassword))
+	require.NoError(t, dao.Delete("carol", "new password"))
+	require.False(t, dao.Has("carol"))
+}
+
+func TestMemoryChangePassword(t *testing.T) {
+	dao := NewMemory(nil)
+	require.NoError(t, dao.Write("alice", "password", newKeyInfo("alice")))
+	require.True(t, errors.Is(dao.ChangePassword("alice", "password", "new password"), ErrUnsupported))
+}
+
+func TestLevelDBMigrateMetadata(t *testing.T) {
+	dir := t.TempDir()
+	dao, err := NewLevelDB(dir, testAEAD)
+	require.NoError(t, err)
+	db := dao.(LevelDBDAO)
+
+	// a key written by a previous version, without creation time
+	info := newKeyInfo("alice")
+	bz, err := json.Marshal(map[string]interface{}{
+		"name":           info.Name,
+		"pubkey":         info.PubKey,
+		"priv_key_armor": info.PrivKeyArmor,
+		"algo":           info.Algo,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.db.SetSync(infoKey("alice"), bz))
+
+	metadata, err := db.ReadMetadata("alice")
+	require.NoError(t, err)
+	require.True(t, metadata.CreatedAt.IsZero())
+
+	migrated, err := db.MigrateMetadata()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice"}, migrated)
+
+	metadata, err = db.ReadMetadata("alice")
+	require.NoError(t, err)
+	require.False(t, metadata.CreatedAt.IsZero())
+
+	migrated, err = db.MigrateMetadata()
+	require.NoError(t, err)
+	require.Empty(t, migrated)
+}
+
+func TestLevelDBWatchOnly(t *testing.T) {
+	dao, err := NewLevelDB(t.TempDir(), testAEAD)
+	require.NoError(t, err)
+
+	byPubKey := newKeyInfo("alice")
+	byPubKey.PrivKeyArmor = ""
+	byAddress := KeyInfo{Name: "bob", Address: "iaa1y9kd9uy7a4qnjp0z5yjx5jhrkv2ycdkzqc0h8z"}
+	require.True(t, byPubKey.WatchOnly())
+	require.True(t, byAddress.WatchOnly())
+	require.False(t, KeyInfo{}.WatchOnly())
+
+	require.NoError(t, dao.Write("alice", "password", byPubKey))
+	require.NoError(t, dao.Write("bob", "password", byAddress))
+
+	for name, expected := range map[string]KeyInfo{"alice": byPubKey, "bob": byAddress} {
+		info, err := dao.Read(name, "password")
+		require.NoError(t, err)
+		require.True(t, info.WatchOnly())
+		require.Equal(t, expected.PubKey, info.PubKey)
+		require.Equal(t, expected.Address, info.Address)
+
+		// there is nothing to re-encrypt
+		require.NoError(t, dao.ChangePassword(name, "password", "new password"))
+	}
+
+	reEncrypted, err := dao.(LevelDBDAO).ReEncrypt(func(string) (string, error) { return "password", nil })
+	require.NoError(t, err)
+	require.Empty(t, reEncrypted)
+
+	require.NoError(t, dao.Rename("bob", "carol", "password"))
+	info, err := dao.ReadMetadata("carol")
+	require.NoError(t, err)
+	require.Equal(t, byAddress.Address, info.Address)
+}
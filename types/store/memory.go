@@ -1,6 +1,13 @@
 package store
 
-// Use memory as storage, use with caution in build environment
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Use memory as storage, use with caution in build environment.
+// The keys aren't encrypted, so the passwords aren't verified
 type MemoryDAO struct {
 	store map[string]KeyInfo
 	Crypto
@@ -8,7 +15,7 @@ type MemoryDAO struct {
 
 func NewMemory(crypto Crypto) MemoryDAO {
 	if crypto == nil {
-		crypto = AES{}
+		crypto = AEAD{}
 	}
 	return MemoryDAO{
 		store:  make(map[string]KeyInfo),
@@ -16,6 +23,9 @@ func NewMemory(crypto Crypto) MemoryDAO {
 	}
 }
 func (m MemoryDAO) Write(name, password string, store KeyInfo) error {
+	if store.CreatedAt.IsZero() {
+		store.CreatedAt = time.Now().UTC()
+	}
 	m.store[name] = store
 	return nil
 }
@@ -38,3 +48,33 @@ func (m MemoryDAO) Has(name string) bool {
 	_, ok := m.store[name]
 	return ok
 }
+
+func (m MemoryDAO) List() ([]string, error) {
+	names := make([]string, 0, len(m.store))
+	for name := range m.store {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ChangePassword is unsupported, the memory store doesn't encrypt the keys
+func (m MemoryDAO) ChangePassword(name, oldPassword, newPassword string) error {
+	return fmt.Errorf("%w: the memory store doesn't encrypt the keys", ErrUnsupported)
+}
+
+// Rename renames the key, the password isn't verified, the memory store doesn't encrypt the keys
+func (m MemoryDAO) Rename(name, newName, password string) error {
+	store, ok := m.store[name]
+	if !ok {
+		return fmt.Errorf("name %s not exist", name)
+	}
+	if m.Has(newName) {
+		return fmt.Errorf("name %s has exist", newName)
+	}
+
+	store.Name = newName
+	m.store[newName] = store
+	delete(m.store, name)
+	return nil
+}
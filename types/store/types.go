@@ -1,7 +1,9 @@
 package store
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 
@@ -10,6 +12,10 @@ import (
 
 var (
 	_ Info = &localInfo{}
+	_ Info = &offlineInfo{}
+
+	// ErrUnsupported is returned by the methods of a KeyDAO its storage can't support
+	ErrUnsupported = errors.New("not supported by the key store")
 )
 
 // KeyType reflects a human-readable type for key listing.
@@ -17,7 +23,8 @@ type KeyType uint
 
 // Info KeyTypes
 const (
-	TypeLocal KeyType = 0
+	TypeLocal   KeyType = 0
+	TypeOffline KeyType = 2
 )
 
 // KeyInfo saves the basic information of the key
@@ -26,6 +33,17 @@ type KeyInfo struct {
 	PubKey       []byte `json:"pubkey"`
 	PrivKeyArmor string `json:"priv_key_armor"`
 	Algo         string `json:"algo"`
+	// HDPath is the BIP44 path the key was derived by, the FileDAO doesn't store it
+	HDPath string `json:"hd_path,omitempty"`
+	// Address is the bech32 address of a watch-only key without public key, the FileDAO doesn't support them
+	Address string `json:"address,omitempty"`
+	// CreatedAt is set by the KeyDAO when the key is written
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WatchOnly returns whether the key is a watch-only key, identified by its public key or address without private key
+func (k KeyInfo) WatchOnly() bool {
+	return len(k.PrivKeyArmor) == 0 && (len(k.PubKey) > 0 || len(k.Address) > 0)
 }
 
 type KeyDAO interface {
@@ -40,6 +58,18 @@ type KeyDAO interface {
 
 	// Has returns whether the specified user name exists
 	Has(name string) bool
+
+	// ReadMetadata reads the key information without decrypting the private key
+	ReadMetadata(name string) (KeyInfo, error)
+
+	// List returns the names of all the keys, sorted
+	List() ([]string, error)
+
+	// ChangePassword re-encrypts the private key with the new password, atomically
+	ChangePassword(name, oldPassword, newPassword string) error
+
+	// Rename renames the key atomically, the stores encrypting the keys verify the password before
+	Rename(name, newName, password string) error
 }
 
 type Crypto interface {
@@ -47,6 +77,14 @@ type Crypto interface {
 	Decrypt(data string, password string) (string, error)
 }
 
+// VersionedCrypto is a Crypto whose encrypted data carries its format, so the data
+// encrypted by an older format or by the legacy AES can be re-encrypted
+type VersionedCrypto interface {
+	Crypto
+	// NeedsUpgrade returns whether the data is not encrypted in the current format
+	NeedsUpgrade(data string) bool
+}
+
 // Info is the publicly exposed information about a keypair
 type Info interface {
 	// Human-readable type for key listing
@@ -95,6 +133,38 @@ func (i localInfo) GetPath() (*hd.BIP44Params, error) {
 	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
 }
 
+// offlineInfo is the public information about a key stored elsewhere, as the offline keys of the iris CLI
+type offlineInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Algo   hd.PubKeyType `json:"algo"`
+}
+
+// GetType implements Info interface
+func (i offlineInfo) GetType() KeyType {
+	return TypeOffline
+}
+
+// GetName implements Info interface
+func (i offlineInfo) GetName() string {
+	return i.Name
+}
+
+// GetPubKey implements Info interface
+func (i offlineInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+// GetAlgo implements Info interface
+func (i offlineInfo) GetAlgo() hd.PubKeyType {
+	return i.Algo
+}
+
+// GetPath implements Info interface
+func (i offlineInfo) GetPath() (*hd.BIP44Params, error) {
+	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
+}
+
 // encoding info
 func marshalInfo(i Info) []byte {
 	return cdc.MustMarshalBinaryLengthPrefixed(i)
@@ -35,3 +35,11 @@ func (t Token) GetCoinType() CoinType {
 }
 
 type Tokens []Token
+
+// TokenRegistry stores the tokens used to convert the coins, the tokens found in it aren't queried from the chain
+type TokenRegistry interface {
+	// Token returns the token of the symbol or the min unit, false when it isn't registered
+	Token(denom string) (Token, bool)
+	// SaveTokens registers the tokens, replacing the registered tokens of the same symbol
+	SaveTokens(tokens ...Token) error
+}
@@ -0,0 +1,33 @@
+package fileutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "data.json")
+
+	require.NoError(t, WriteFileAtomic(filename, []byte("first"), 0600))
+	require.NoError(t, WriteFileAtomic(filename, []byte("second"), 0600))
+
+	bz, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "second", string(bz))
+
+	stat, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+
+	// the temporary files are removed
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	require.Error(t, WriteFileAtomic(filepath.Join(dir, "missing", "data.json"), []byte("data"), 0600))
+}
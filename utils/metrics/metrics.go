@@ -0,0 +1,277 @@
+// Package metrics provides the optional prometheus instrumentation of the SDK.
+//
+// A nil *Metrics is valid and records nothing, so the instrumentation costs nothing
+// unless it is enabled:
+//
+//	m := metrics.NewMetrics("irishub_sdk")
+//	prometheus.MustRegister(m)
+//
+//	cfg, _ := types.NewClientConfig(nodeURI, grpcAddr, chainID, types.MetricsOption(m))
+//
+// The grpc queries are recorded by an interceptor of the default grpc client, a custom
+// types.GRPCClient has to dial with UnaryClientInterceptor to record them.
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const subsystem = "client"
+
+// Cache names used by CacheAccess
+const (
+	CacheAccount = "account"
+	CacheToken   = "token"
+)
+
+// Metrics collects the metrics of the SDK, it implements prometheus.Collector
+type Metrics struct {
+	grpcQueries       *prometheus.CounterVec
+	grpcQueryDuration *prometheus.HistogramVec
+
+	abciQueries       *prometheus.CounterVec
+	abciQueryDuration *prometheus.HistogramVec
+
+	broadcasts        *prometheus.CounterVec
+	broadcastDuration *prometheus.HistogramVec
+	gasWanted         prometheus.Histogram
+	gasUsed           prometheus.Histogram
+
+	cacheAccesses           *prometheus.CounterVec
+	activeSubscriptions     prometheus.Gauge
+	sequenceMismatchRetries prometheus.Counter
+}
+
+var _ prometheus.Collector = (*Metrics)(nil)
+
+// NewMetrics returns the metrics of the SDK, every metric name is prefixed by the namespace
+func NewMetrics(namespace string) *Metrics {
+	gasBuckets := prometheus.ExponentialBuckets(50000, 2, 10)
+
+	return &Metrics{
+		grpcQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "grpc_queries_total",
+			Help:      "Number of grpc queries by module, method and status code.",
+		}, []string{"module", "method", "code"}),
+		grpcQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "grpc_query_duration_seconds",
+			Help:      "Latency of the grpc queries by module and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"module", "method"}),
+		abciQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "abci_queries_total",
+			Help:      "Number of abci queries by module, method and result code.",
+		}, []string{"module", "method", "code"}),
+		abciQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "abci_query_duration_seconds",
+			Help:      "Latency of the abci queries by module and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"module", "method"}),
+		broadcasts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "broadcasts_total",
+			Help:      "Number of broadcast transactions by mode, codespace and result code.",
+		}, []string{"mode", "codespace", "code"}),
+		broadcastDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "broadcast_duration_seconds",
+			Help:      "Latency of the broadcast transactions by mode.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"mode"}),
+		gasWanted: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "tx_gas_wanted",
+			Help:      "Gas wanted by the committed transactions.",
+			Buckets:   gasBuckets,
+		}),
+		gasUsed: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "tx_gas_used",
+			Help:      "Gas used by the committed transactions.",
+			Buckets:   gasBuckets,
+		}),
+		cacheAccesses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_accesses_total",
+			Help:      "Number of cache lookups by cache and result (hit or miss).",
+		}, []string{"cache", "result"}),
+		activeSubscriptions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "active_subscriptions",
+			Help:      "Number of active event subscriptions.",
+		}),
+		sequenceMismatchRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "sequence_mismatch_retries_total",
+			Help:      "Number of transactions rebuilt after an account sequence mismatch.",
+		}),
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.grpcQueries, m.grpcQueryDuration,
+		m.abciQueries, m.abciQueryDuration,
+		m.broadcasts, m.broadcastDuration, m.gasWanted, m.gasUsed,
+		m.cacheAccesses, m.activeSubscriptions, m.sequenceMismatchRetries,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	if m == nil {
+		return
+	}
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	if m == nil {
+		return
+	}
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// ObserveGRPCQuery records a grpc query, the method is the full grpc method name
+func (m *Metrics) ObserveGRPCQuery(fullMethod string, err error, start time.Time) {
+	if m == nil {
+		return
+	}
+	module, method := splitGRPCMethod(fullMethod)
+	m.grpcQueries.WithLabelValues(module, method, status.Code(err).String()).Inc()
+	m.grpcQueryDuration.WithLabelValues(module, method).Observe(time.Since(start).Seconds())
+}
+
+// ObserveABCIQuery records an abci query, the code is the code of the response or -1 if the request failed
+func (m *Metrics) ObserveABCIQuery(path string, code int64, start time.Time) {
+	if m == nil {
+		return
+	}
+	module, method := splitABCIPath(path)
+	m.abciQueries.WithLabelValues(module, method, strconv.FormatInt(code, 10)).Inc()
+	m.abciQueryDuration.WithLabelValues(module, method).Observe(time.Since(start).Seconds())
+}
+
+// ObserveBroadcast records a broadcast transaction, the code is the code of the result or -1 if the request failed
+func (m *Metrics) ObserveBroadcast(mode, codespace string, code int64, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.broadcasts.WithLabelValues(mode, codespace, strconv.FormatInt(code, 10)).Inc()
+	m.broadcastDuration.WithLabelValues(mode).Observe(time.Since(start).Seconds())
+}
+
+// ObserveGas records the gas of a committed transaction
+func (m *Metrics) ObserveGas(wanted, used int64) {
+	if m == nil {
+		return
+	}
+	m.gasWanted.Observe(float64(wanted))
+	m.gasUsed.Observe(float64(used))
+}
+
+// CacheAccess records a lookup in the cache of the name
+func (m *Metrics) CacheAccess(cache string, hit bool) {
+	if m == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheAccesses.WithLabelValues(cache, result).Inc()
+}
+
+// SubscriptionAdded increments the number of active subscriptions
+func (m *Metrics) SubscriptionAdded() {
+	if m == nil {
+		return
+	}
+	m.activeSubscriptions.Inc()
+}
+
+// SubscriptionRemoved decrements the number of active subscriptions
+func (m *Metrics) SubscriptionRemoved() {
+	if m == nil {
+		return
+	}
+	m.activeSubscriptions.Dec()
+}
+
+// SequenceMismatchRetry records a transaction rebuilt after an account sequence mismatch
+func (m *Metrics) SequenceMismatchRetry() {
+	if m == nil {
+		return
+	}
+	m.sequenceMismatchRetries.Inc()
+}
+
+// UnaryClientInterceptor returns a grpc interceptor recording every query of the connection
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		m.ObserveGRPCQuery(method, err, start)
+		return err
+	}
+}
+
+// splitGRPCMethod splits "/irismod.token.Query/Token" into "token" and "Token"
+func splitGRPCMethod(fullMethod string) (module, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	i := strings.LastIndex(fullMethod, "/")
+	if i < 0 {
+		return "unknown", fullMethod
+	}
+
+	service, method := fullMethod[:i], fullMethod[i+1:]
+	parts := strings.Split(service, ".")
+	if len(parts) >= 2 {
+		// skip the version of the package, e.g. cosmos.bank.v1beta1.Query
+		module = parts[1]
+	} else {
+		module = service
+	}
+	return module, method
+}
+
+// splitABCIPath splits "/custom/bank/balances" and "/store/bank/key" into "bank" and the method,
+// and "/app/simulate" into "app" and "simulate"
+func splitABCIPath(path string) (module, method string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 3 && (parts[0] == "custom" || parts[0] == "store"):
+		return parts[1], strings.Join(parts[2:], "/")
+	case len(parts) >= 2:
+		return parts[0], strings.Join(parts[1:], "/")
+	default:
+		return "unknown", path
+	}
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	require.NotPanics(t, func() {
+		m.ObserveGRPCQuery("/irismod.token.Query/Token", nil, time.Now())
+		m.ObserveABCIQuery("/app/simulate", 0, time.Now())
+		m.ObserveBroadcast("sync", "sdk", 0, time.Now())
+		m.ObserveGas(1, 1)
+		m.CacheAccess(CacheToken, true)
+		m.SubscriptionAdded()
+		m.SubscriptionRemoved()
+		m.SequenceMismatchRetry()
+	})
+	require.Equal(t, 0, testutil.CollectAndCount(m))
+}
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics("test")
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(m))
+
+	m.ObserveGRPCQuery("/irismod.token.Query/Token", nil, time.Now())
+	m.ObserveGRPCQuery("/irismod.token.Query/Token", errors.New("failed"), time.Now())
+	require.Equal(t, float64(1), testutil.ToFloat64(m.grpcQueries.WithLabelValues("token", "Token", "OK")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.grpcQueries.WithLabelValues("token", "Token", "Unknown")))
+
+	m.ObserveABCIQuery("/custom/bank/balances", 0, time.Now())
+	require.Equal(t, float64(1), testutil.ToFloat64(m.abciQueries.WithLabelValues("bank", "balances", "0")))
+
+	m.ObserveBroadcast("commit", "sdk", 5, time.Now())
+	require.Equal(t, float64(1), testutil.ToFloat64(m.broadcasts.WithLabelValues("commit", "sdk", "5")))
+
+	m.CacheAccess(CacheAccount, true)
+	m.CacheAccess(CacheAccount, false)
+	m.CacheAccess(CacheAccount, true)
+	require.Equal(t, float64(2), testutil.ToFloat64(m.cacheAccesses.WithLabelValues(CacheAccount, "hit")))
+
+	m.SubscriptionAdded()
+	m.SubscriptionAdded()
+	m.SubscriptionRemoved()
+	require.Equal(t, float64(1), testutil.ToFloat64(m.activeSubscriptions))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.NotEmpty(t, families)
+}
+
+func TestSplitGRPCMethod(t *testing.T) {
+	testCases := []struct {
+		fullMethod, module, method string
+	}{
+		{"/irismod.token.Query/Token", "token", "Token"},
+		{"/cosmos.bank.v1beta1.Query/AllBalances", "bank", "AllBalances"},
+		{"/Query/Token", "Query", "Token"},
+		{"Token", "unknown", "Token"},
+	}
+
+	for _, tc := range testCases {
+		module, method := splitGRPCMethod(tc.fullMethod)
+		require.Equal(t, tc.module, module, tc.fullMethod)
+		require.Equal(t, tc.method, method, tc.fullMethod)
+	}
+}
+
+func TestSplitABCIPath(t *testing.T) {
+	testCases := []struct {
+		path, module, method string
+	}{
+		{"/app/simulate", "app", "simulate"},
+		{"/custom/bank/balances", "bank", "balances"},
+		{"/store/acc/key", "acc", "key"},
+		{"version", "unknown", "version"},
+	}
+
+	for _, tc := range testCases {
+		module, method := splitABCIPath(tc.path)
+		require.Equal(t, tc.module, module, tc.path)
+		require.Equal(t, tc.method, method, tc.path)
+	}
+}
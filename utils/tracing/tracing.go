@@ -0,0 +1,167 @@
+// Package tracing provides the OpenTelemetry instrumentation of the SDK.
+//
+// The SDK traces with a no-op tracer unless a TracerProvider is configured:
+//
+//	cfg, _ := types.NewClientConfig(nodeURI, grpcAddr, chainID,
+//		types.TracerProviderOption(provider),
+//	)
+//
+// BuildAndSend is traced with one span per stage (address and account lookup, fee
+// conversion, signing and broadcasting), every grpc query gets a client span whose
+// context is propagated in the grpc metadata, and the subscription handlers run in
+// their own spans.
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// InstrumentationName is the name of the tracer of the SDK
+const InstrumentationName = "github.com/irisnet/irishub-sdk-go"
+
+// Attribute keys of the spans
+const (
+	AttributeKeyTxHash          = "tx.hash"
+	AttributeKeyTxHeight        = "tx.height"
+	AttributeKeyTxMsgTypes      = "tx.msg_types"
+	AttributeKeyTxGasWanted     = "tx.gas_wanted"
+	AttributeKeyTxGasUsed       = "tx.gas_used"
+	AttributeKeyAccountName     = "account.name"
+	AttributeKeyAccountAddress  = "account.address"
+	AttributeKeyAccountNumber   = "account.number"
+	AttributeKeyAccountSequence = "account.sequence"
+	AttributeKeyBroadcastMode   = "broadcast.mode"
+	AttributeKeyABCIPath        = "abci.path"
+	AttributeKeyCode            = "code"
+	AttributeKeyCodespace       = "codespace"
+	AttributeKeyQuery           = "query"
+	AttributeKeyDenoms          = "denoms"
+)
+
+// Tracer starts the spans of the SDK and propagates their context to the grpc queries
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer returns a tracer of the provider, the no-op provider is used when it is nil
+func NewTracer(provider trace.TracerProvider, propagator propagation.TextMapPropagator) Tracer {
+	if provider == nil {
+		provider = trace.NewNoopTracerProvider()
+	}
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+	return Tracer{
+		tracer:     provider.Tracer(InstrumentationName),
+		propagator: propagator,
+	}
+}
+
+// Start starts a span of the name as a child of the span of the context
+func (t Tracer) Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if t.tracer == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// MsgTypes returns the attribute holding the types of the messages, separated by commas
+func MsgTypes(msgTypes []string) attribute.KeyValue {
+	return attribute.String(AttributeKeyTxMsgTypes, strings.Join(msgTypes, ","))
+}
+
+// UnaryClientInterceptor returns a grpc interceptor starting a client span for every query,
+// and injecting the context of the span in the outgoing metadata
+func (t Tracer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if t.tracer == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		service, name := splitMethod(method)
+		ctx, span := t.tracer.Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemKey.String("grpc"),
+				semconv.RPCServiceKey.String(service),
+				semconv.RPCMethodKey.String(name),
+			),
+		)
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		t.propagator.Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		End(span, err)
+		return err
+	}
+}
+
+// Extract returns the context carrying the span context propagated in the metadata of an incoming grpc request
+func (t Tracer) Extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return t.propagator.Extract(ctx, metadataCarrier(md))
+}
+
+// metadataCarrier adapts the grpc metadata to propagation.TextMapCarrier
+type metadataCarrier metadata.MD
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// splitMethod splits "/irismod.token.Query/Token" into "irismod.token.Query" and "Token"
+func splitMethod(fullMethod string) (service, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	i := strings.LastIndex(fullMethod, "/")
+	if i < 0 {
+		return "", fullMethod
+	}
+	return fullMethod[:i], fullMethod[i+1:]
+}
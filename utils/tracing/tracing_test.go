@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNoopTracer(t *testing.T) {
+	tracer := NewTracer(nil, nil)
+	ctx, span := tracer.Start(context.Background(), "noop")
+	require.False(t, span.SpanContext().IsValid())
+	End(span, errors.New("failed"))
+
+	var zero Tracer
+	_, span = zero.Start(ctx, "zero")
+	require.False(t, span.SpanContext().IsValid())
+
+	invoked := false
+	err := zero.UnaryClientInterceptor()(ctx, "/irismod.token.Query/Token", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			invoked = true
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.True(t, invoked)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), nil)
+
+	ctx, parent := tracer.Start(context.Background(), "BuildAndSend")
+
+	var propagated trace.SpanContext
+	err := tracer.UnaryClientInterceptor()(ctx, "/irismod.token.Query/Token", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, ok := metadata.FromOutgoingContext(ctx)
+			require.True(t, ok)
+			require.NotEmpty(t, md.Get("traceparent"))
+
+			// the server side sees the span of the query
+			incoming := metadata.NewIncomingContext(context.Background(), md)
+			propagated = trace.SpanContextFromContext(tracer.Extract(incoming))
+			return errors.New("failed")
+		},
+	)
+	require.Error(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	query := spans[0]
+	require.Equal(t, "/irismod.token.Query/Token", query.Name)
+	require.Equal(t, trace.SpanKindClient, query.SpanKind)
+	require.Equal(t, codes.Error, query.Status.Code)
+	require.Equal(t, parent.SpanContext().TraceID(), query.SpanContext.TraceID())
+	require.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID())
+
+	require.True(t, propagated.IsRemote())
+	require.Equal(t, query.SpanContext.TraceID(), propagated.TraceID())
+	require.Equal(t, query.SpanContext.SpanID(), propagated.SpanID())
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/irismod.token.Query/Token")
+	require.Equal(t, "irismod.token.Query", service)
+	require.Equal(t, "Token", method)
+
+	service, method = splitMethod("Token")
+	require.Empty(t, service)
+	require.Equal(t, "Token", method)
+}